@@ -1,14 +1,30 @@
 package main
 
 import (
-	"log"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
 
 	"backend/config"
 	"backend/db"
 	"backend/handlers"
+	"backend/jobs"
+	"backend/metrics"
+	"backend/middleware"
+	"backend/pkg/blob"
+	"backend/pkg/logging"
+	"backend/pkg/notifier"
+	"backend/realtime"
 	"backend/repositories"
 	"backend/routes"
 	"backend/services"
+	"backend/tracing"
 
 	_ "backend/docs"
 
@@ -22,40 +38,314 @@ import (
 // @host localhost:8080
 // @BasePath /
 func main() {
+	slog.SetDefault(logging.New(os.Stdout, slog.LevelInfo))
+
+	// Exiting only here lets the deferred cleanups in run finish
+	if err := run(); err != nil {
+		var invalid invalidConfigError
+		if errors.As(err, &invalid) {
+			// One entry per invalid setting
+			slog.Error("Invalid configuration", "errors", strings.Split(invalid.Error(), "\n"))
+		} else {
+			slog.Error("Server failed", "error", err)
+		}
+		os.Exit(1)
+	}
+}
+
+// invalidConfigError holds every invalid setting, one per line
+type invalidConfigError struct{ error }
+
+// run starts the server, or runs the subcommand given, until it fails or a
+// signal asks it to stop
+func run() error {
+	configFile := flag.String("config", "", "YAML, TOML or JSON file to read settings from, the environment takes precedence")
+	storage := flag.String("storage", "", "where to keep data: postgres, sqlite or memory, overriding STORAGE")
+	printConfig := flag.Bool("print-config", false, "print the effective settings with secrets redacted and exit")
+	flag.Parse()
+
 	// Load config
-	cfg, err := config.LoadConfig()
+	cfg, err := config.LoadConfig(*configFile)
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+	if *storage != "" {
+		cfg.Storage = *storage
+	}
+	if *printConfig {
+		if err := cfg.Print(os.Stdout); err != nil {
+			return fmt.Errorf("print config: %w", err)
+		}
+		return nil
+	}
+	// migrate only needs the storage settings, so it works on a checkout
+	// without the secrets the server requires
+	validate := cfg.Validate
+	if flag.Arg(0) == "migrate" {
+		validate = cfg.ValidateStorage
+	}
+	if err := validate(); err != nil {
+		return invalidConfigError{err}
+	}
+	logLevel, err := logging.ParseLevel(cfg.LogLevel)
+	if err != nil {
+		return fmt.Errorf("invalid LOG_LEVEL: %w", err)
+	}
+	logger := logging.New(os.Stdout, logLevel)
+	slog.SetDefault(logger)
+
+	shutdownTracing, err := tracing.Setup(context.Background(), tracing.Config{
+		Exporter:     cfg.TracingExporter,
+		OTLPEndpoint: cfg.OTLPEndpoint,
+		ServiceName:  cfg.TracingServiceName,
+	})
 	if err != nil {
-		log.Fatalf("Failed to load config: %v", err)
+		return fmt.Errorf("set up tracing: %w", err)
+	}
+	defer shutdownTracing(context.Background())
+
+	if flag.Arg(0) == "migrate" {
+		if err := runMigrate(cfg, flag.Args()[1:]); err != nil {
+			return fmt.Errorf("migrate: %w", err)
+		}
+		return nil
+	}
+
+	// Initialize Fiber app
+	app := fiber.New(fiber.Config{
+		// Bodies are read by middleware.BodyLimit, so attachment uploads can
+		// be larger than the rest
+		StreamRequestBody:            true,
+		DisablePreParseMultipartForm: true,
+		ErrorHandler:                 handlers.ErrorHandler,
+		// Every line written is JSON
+		DisableStartupMessage: true,
+	})
+
+	app.Use(middleware.RequestContext(cfg.RequestTimeout))
+	app.Use(middleware.Tracing())
+	app.Use(middleware.RequestID(logger))
+	app.Use(middleware.AccessLog())
+	app.Use(middleware.Metrics())
+	app.Use(middleware.BodyLimit(fiber.DefaultBodyLimit, routes.HasOwnBodyLimit))
+
+	// Shared by every storage so shutdown can end the open streams
+	hub := realtime.NewHub()
+
+	switch cfg.Storage {
+	case "postgres":
+		cleanup, err := setupPostgres(app, cfg, hub)
+		if err != nil {
+			return err
+		}
+		defer cleanup()
+	case "sqlite":
+		database, err := db.ConnectSQLite(cfg.SQLitePath)
+		if err != nil {
+			return fmt.Errorf("open SQLite database: %w", err)
+		}
+		defer database.Close()
+		metrics.RegisterDB(database.DB, "sqlite")
+		if err := migrateOnStart(database, db.SQLite); err != nil {
+			return err
+		}
+		if err := setupHealth(app, database, db.SQLite); err != nil {
+			return err
+		}
+		setupCore(app, cfg, hub, repositories.NewSQLiteUserRepository(*database), repositories.NewSQLiteTaskRepository(*database), repositories.NewTransactor(database))
+	case "memory":
+		slog.Warn("Storing users and tasks in memory, they are lost on exit")
+		store := repositories.NewMemoryStore()
+		if err := setupHealth(app, nil, ""); err != nil {
+			return err
+		}
+		setupCore(app, cfg, hub, store.Users(), store.Tasks(), store.Transactor())
+	default:
+		return fmt.Errorf("unknown storage %q, expected postgres, sqlite or memory", cfg.Storage)
 	}
 
+	app.Get("/metrics", metrics.Handler())
+
+	// Setup Swagger
+	app.Get("/swagger/*", swagger.HandlerDefault)
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	// Start server
+	listenErr := make(chan error, 1)
+	go func() {
+		slog.Info("Server listening", "address", cfg.ListenAddr)
+		listenErr <- app.Listen(cfg.ListenAddr)
+	}()
+
+	select {
+	case err := <-listenErr:
+		return fmt.Errorf("listen: %w", err)
+	case <-ctx.Done():
+	}
+	// A second signal kills the server right away
+	stop()
+
+	slog.Info("Shutting down", "timeout", cfg.ShutdownTimeout.String())
+	// Streams only end when their client goes away, which would hold up
+	// draining until the timeout
+	hub.Close()
+	if err := app.ShutdownWithTimeout(cfg.ShutdownTimeout); err != nil {
+		slog.Error("Failed to drain requests", "error", err)
+	}
+	// The deferred cleanups stop the workers and close the database
+	return nil
+}
+
+// setupPostgres wires every feature to PostgreSQL and starts the background
+// workers. The returned function stops them and closes the database; on
+// error, whatever was started is stopped already.
+func setupPostgres(app *fiber.App, cfg *config.Config, hub *realtime.Hub) (_ func(), err error) {
 	// Connect to database
 	database, err := db.Connect(cfg.DBHost, cfg.DBPort, cfg.DBUser, cfg.DBPassword, cfg.DBName)
 	if err != nil {
-		log.Fatalf("Failed to connect to database: %v", err)
+		return nil, fmt.Errorf("connect to database: %w", err)
+	}
+	cleanup := []func(){func() { database.Close() }}
+	stop := func() {
+		// Stop in the reverse order things were started
+		for i := len(cleanup) - 1; i >= 0; i-- {
+			cleanup[i]()
+		}
+	}
+	defer func() {
+		if err != nil {
+			stop()
+		}
+	}()
+	metrics.RegisterDB(database.DB, "postgres")
+	if err := migrateOnStart(database, db.Postgres); err != nil {
+		return nil, err
+	}
+	if err := setupHealth(app, database, db.Postgres); err != nil {
+		return nil, err
 	}
-	defer database.Close()
 
 	// Initialize repositories
 	userRepo := repositories.NewUserRepository(*database)
 	taskRepo := repositories.NewTaskRepository(*database)
+	jobRepo := repositories.NewJobRepository(*database)
+	webhookRepo := repositories.NewWebhookRepository(*database)
+	notificationRepo := repositories.NewNotificationRepository(*database)
+	mentionRepo := repositories.NewMentionRepository(*database)
+	attachmentRepo := repositories.NewAttachmentRepository(*database)
+	workLogRepo := repositories.NewWorkLogRepository(*database)
+	transitionRepo := repositories.NewStatusTransitionRepository(*database)
+	calendarTokenRepo := repositories.NewCalendarTokenRepository(*database)
+	transactor := repositories.NewTransactor(database)
+
+	// Initialize blob storage
+	var blobStore blob.BlobStore
+	switch cfg.BlobStore {
+	case "s3":
+		blobStore, err = blob.NewS3Store(blob.S3Config{
+			Endpoint:  cfg.S3Endpoint,
+			Region:    cfg.S3Region,
+			Bucket:    cfg.S3Bucket,
+			AccessKey: cfg.S3AccessKey,
+			SecretKey: cfg.S3SecretKey,
+		})
+	case "local":
+		blobStore, err = blob.NewLocalStore(cfg.BlobLocalDir)
+	default:
+		return nil, fmt.Errorf("unknown BLOB_STORE %q, expected local or s3", cfg.BlobStore)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("initialize blob store: %w", err)
+	}
 
 	// Initialize services
-	userService := services.NewUserService(userRepo)
-	taskService := services.NewTaskService(taskRepo)
+	reminderOffsets, err := cfg.ReminderOffsetDurations()
+	if err != nil {
+		return nil, fmt.Errorf("invalid REMINDER_OFFSETS: %w", err)
+	}
+	reminderService := services.NewReminderService(jobRepo, taskRepo, userRepo, notifier.NewLogNotifier(), reminderOffsets)
+	webhookService := services.NewWebhookService(webhookRepo, jobRepo)
+	notificationService := services.NewNotificationService(notificationRepo)
+	mentionService := services.NewMentionService(mentionRepo, userRepo, taskRepo, notificationService)
+	attachmentService := services.NewAttachmentService(attachmentRepo, taskRepo, jobRepo, blobStore, cfg.MaxAttachmentSize, cfg.AttachmentTypes())
 
-	// Initialize handlers
-	userHandler := handlers.NewUserHandler(userService)
-	taskHandler := handlers.NewTaskHandler(taskService)
+	var publisher realtime.Publisher = hub
+	if cfg.RealtimePGNotify {
+		fanout, err := realtime.NewPGFanout(hub, *database, db.DSN(cfg.DBHost, cfg.DBPort, cfg.DBUser, cfg.DBPassword, cfg.DBName))
+		if err != nil {
+			return nil, fmt.Errorf("listen for task events: %w", err)
+		}
+		cleanup = append(cleanup, func() { fanout.Close() })
+		publisher = fanout
+	}
 
-	// Initialize Fiber app
-	app := fiber.New()
+	userService := services.NewUserService(userRepo, authConfig(cfg))
+	timeTrackingService := services.NewTimeTrackingService(workLogRepo, taskRepo)
+	analyticsService := services.NewAnalyticsService(transitionRepo)
+	dashboardService := services.NewDashboardService(taskRepo)
+	exportService := services.NewExportService(taskRepo, userRepo)
+	calendarService := services.NewCalendarService(calendarTokenRepo, taskRepo, cfg.TaskURLTemplate)
+	taskService := services.NewTaskService(taskRepo, transactor, reminderService, webhookService, services.NewTaskStreamPublisher(publisher), notificationService, mentionService, attachmentService, analyticsService, services.NewTaskMetrics())
+	importService := services.NewImportService(taskService, userRepo)
+
+	// Start background workers
+	pool := jobs.NewPool(jobRepo, cfg.WorkerConcurrency)
+	pool.Register(services.JobTypeTaskReminder, reminderService.HandleReminder)
+	pool.Register(services.JobTypeWebhookDelivery, webhookService.HandleDelivery)
+	pool.Register(services.JobTypeAttachmentPurge, attachmentService.HandlePurge)
+	pool.Start(context.Background())
+	cleanup = append(cleanup, pool.Stop)
 
 	// Setup routes
-	routes.SetupRoutes(app, userHandler, taskHandler)
+	routes.SetupRoutes(app, cfg,
+		handlers.NewUserHandler(userService),
+		handlers.NewTaskHandler(taskService),
+		handlers.NewWebhookHandler(webhookService),
+		handlers.NewStreamHandler(hub),
+		handlers.NewNotificationHandler(notificationService),
+		handlers.NewMentionHandler(mentionService),
+		handlers.NewAttachmentHandler(attachmentService),
+		handlers.NewTimeTrackingHandler(timeTrackingService),
+		handlers.NewAnalyticsHandler(analyticsService),
+		handlers.NewDashboardHandler(dashboardService),
+		handlers.NewExportHandler(exportService),
+		handlers.NewImportHandler(importService),
+		handlers.NewCalendarHandler(calendarService),
+	)
 
-	// Setup Swagger
-	app.Get("/swagger/*", swagger.HandlerDefault)
+	return stop, nil
+}
 
-	// Start server
-	log.Fatal(app.Listen(":8080"))
+// setupCore wires the features that only need users and tasks, for the
+// storages other than PostgreSQL. Features backed by other tables are not
+// available.
+func setupCore(app *fiber.App, cfg *config.Config, hub *realtime.Hub, userRepo repositories.UserRepositoryInterface, taskRepo repositories.TaskRepositoryInterface, transactor repositories.Transactor) {
+	userService := services.NewUserService(userRepo, authConfig(cfg))
+	taskService := services.NewTaskService(taskRepo, transactor, services.NewTaskStreamPublisher(hub), services.NewTaskMetrics())
+
+	routes.SetupRoutes(app, cfg,
+		handlers.NewUserHandler(userService),
+		handlers.NewTaskHandler(taskService),
+		nil,
+		handlers.NewStreamHandler(hub),
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		handlers.NewDashboardHandler(services.NewDashboardService(taskRepo)),
+		handlers.NewExportHandler(services.NewExportService(taskRepo, userRepo)),
+		handlers.NewImportHandler(services.NewImportService(taskService, userRepo)),
+		nil,
+	)
+}
+
+func authConfig(cfg *config.Config) services.AuthConfig {
+	return services.AuthConfig{
+		JWTSecret:     cfg.JWTSecret,
+		TokenLifetime: cfg.TokenLifetime,
+		BcryptCost:    cfg.BcryptCost,
+	}
 }
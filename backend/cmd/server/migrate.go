@@ -0,0 +1,110 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+
+	"backend/config"
+	"backend/db"
+
+	"github.com/jmoiron/sqlx"
+)
+
+const migrateUsage = "usage: server migrate up | down [N] | status | force VERSION"
+
+// runMigrate runs the migrate subcommand against the configured storage:
+//
+//	up            apply all pending migrations
+//	down [N]      revert the last N migrations, 1 by default
+//	status        print the schema version and pending migrations
+//	force VERSION record VERSION as applied and clear the dirty flag
+func runMigrate(cfg *config.Config, args []string) error {
+	if len(args) == 0 {
+		return errors.New(migrateUsage)
+	}
+
+	database, dialect, err := connectDatabase(cfg)
+	if err != nil {
+		return err
+	}
+	defer database.Close()
+	migrator, err := db.NewMigrator(database, dialect)
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+
+	switch {
+	case args[0] == "up" && len(args) == 1:
+		if err := migrator.Up(ctx); err != nil {
+			return err
+		}
+	case args[0] == "down" && len(args) <= 2:
+		steps := 1
+		if len(args) == 2 {
+			if steps, err = strconv.Atoi(args[1]); err != nil || steps < 1 {
+				return fmt.Errorf("invalid number of migrations %q", args[1])
+			}
+		}
+		if err := migrator.Down(ctx, steps); err != nil {
+			return err
+		}
+	case args[0] == "force" && len(args) == 2:
+		version, err := strconv.ParseInt(args[1], 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid version %q", args[1])
+		}
+		if err := migrator.Force(ctx, version); err != nil {
+			return err
+		}
+	case args[0] == "status" && len(args) == 1:
+	default:
+		return errors.New(migrateUsage)
+	}
+
+	status, err := migrator.Status(ctx)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("version %d of %d", status.Version, status.Latest)
+	if status.Dirty {
+		fmt.Print(" (dirty)")
+	}
+	fmt.Println()
+	for _, migration := range status.Pending {
+		fmt.Printf("pending %06d_%s\n", migration.Version, migration.Name)
+	}
+	return nil
+}
+
+// connectDatabase opens the database of the configured storage
+func connectDatabase(cfg *config.Config) (*sqlx.DB, db.Dialect, error) {
+	switch cfg.Storage {
+	case "postgres":
+		database, err := db.Connect(cfg.DBHost, cfg.DBPort, cfg.DBUser, cfg.DBPassword, cfg.DBName)
+		return database, db.Postgres, err
+	case "sqlite":
+		database, err := db.ConnectSQLite(cfg.SQLitePath)
+		return database, db.SQLite, err
+	default:
+		return nil, "", fmt.Errorf("storage %q has no database to migrate", cfg.Storage)
+	}
+}
+
+// migrateOnStart applies pending migrations before the server starts. It
+// refuses to start on a schema newer than the binary, since this version's
+// queries may not work against it.
+func migrateOnStart(database *sqlx.DB, dialect db.Dialect) error {
+	migrator, err := db.NewMigrator(database, dialect)
+	if err != nil {
+		return fmt.Errorf("load migrations: %w", err)
+	}
+	if err := migrator.Up(context.Background()); err != nil {
+		return fmt.Errorf("migrate database: %w", err)
+	}
+	slog.Info("Database schema is up to date", "version", migrator.Latest())
+	return nil
+}
@@ -1,31 +1,297 @@
 package config
 
 import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"reflect"
+	"strings"
+	"time"
+
+	"backend/pkg/logging"
+
 	"github.com/spf13/viper"
+	"golang.org/x/crypto/bcrypt"
 )
 
 type Config struct {
+	// Where users and tasks are kept: postgres, sqlite or memory
+	Storage    string `mapstructure:"STORAGE"`
+	SQLitePath string `mapstructure:"SQLITE_PATH"`
+
 	DBHost     string `mapstructure:"DB_HOST"`
 	DBPort     string `mapstructure:"DB_PORT"`
 	DBUser     string `mapstructure:"DB_USER"`
-	DBPassword string `mapstructure:"DB_PASSWORD"`
+	DBPassword string `mapstructure:"DB_PASSWORD" secret:"true"`
 	DBName     string `mapstructure:"DB_NAME"`
-	JWTSecret  string `mapstructure:"JWT_SECRET"`
+
+	// HTTP server: the address to listen on and the comma separated origins
+	// browsers may call the API from
+	ListenAddr  string `mapstructure:"LISTEN_ADDR"`
+	CORSOrigins string `mapstructure:"CORS_ORIGINS"`
+
+	// Authentication: the key tokens are signed with, how long they are
+	// valid and the bcrypt cost passwords are hashed with
+	JWTSecret     string        `mapstructure:"JWT_SECRET" secret:"true"`
+	TokenLifetime time.Duration `mapstructure:"TOKEN_LIFETIME"`
+	BcryptCost    int           `mapstructure:"BCRYPT_COST"`
+
+	// Least severe logs written: debug, info, warn or error
+	LogLevel string `mapstructure:"LOG_LEVEL"`
+
+	// Tracing: where spans go (none, stdout or otlp), the OTLP/HTTP collector
+	// URL and the service name spans are reported under
+	TracingExporter    string `mapstructure:"TRACING_EXPORTER"`
+	OTLPEndpoint       string `mapstructure:"OTLP_ENDPOINT"`
+	TracingServiceName string `mapstructure:"TRACING_SERVICE_NAME"`
+
+	// Cancel the database work of requests that take longer than this
+	RequestTimeout time.Duration `mapstructure:"REQUEST_TIMEOUT"`
+	// On SIGTERM or SIGINT, wait this long for requests in flight to finish
+	ShutdownTimeout time.Duration `mapstructure:"SHUTDOWN_TIMEOUT"`
+
+	// Background jobs
+	WorkerConcurrency int    `mapstructure:"WORKER_CONCURRENCY"`
+	ReminderOffsets   string `mapstructure:"REMINDER_OFFSETS"`
+
+	// Fan task events out to other server instances via LISTEN/NOTIFY
+	RealtimePGNotify bool `mapstructure:"REALTIME_PG_NOTIFY"`
+
+	// Attachments
+	BlobStore              string `mapstructure:"BLOB_STORE"`
+	BlobLocalDir           string `mapstructure:"BLOB_LOCAL_DIR"`
+	S3Endpoint             string `mapstructure:"S3_ENDPOINT"`
+	S3Region               string `mapstructure:"S3_REGION"`
+	S3Bucket               string `mapstructure:"S3_BUCKET"`
+	S3AccessKey            string `mapstructure:"S3_ACCESS_KEY" secret:"true"`
+	S3SecretKey            string `mapstructure:"S3_SECRET_KEY" secret:"true"`
+	MaxAttachmentSize      int64  `mapstructure:"MAX_ATTACHMENT_SIZE"`
+	AllowedAttachmentTypes string `mapstructure:"ALLOWED_ATTACHMENT_TYPES"`
+
+	// Link to a task from calendar feeds, "{id}" is replaced by the task ID
+	TaskURLTemplate string `mapstructure:"TASK_URL_TEMPLATE"`
 }
 
-func LoadConfig() (config *Config, err error) {
-	viper.SetConfigFile(".env")
-	viper.AutomaticEnv()
+// minJWTSecretLength is the fewest bytes of JWT_SECRET accepted, the size of
+// the HS256 signature
+const minJWTSecretLength = 32
 
-	err = viper.ReadInConfig()
-	if err != nil {
-		return nil, err
+// LoadConfig reads the configuration from, in increasing precedence: the
+// defaults, the YAML, TOML or JSON file at path if it is not empty, a .env
+// file in the working directory if there is one, and the environment. Keys
+// in files are the environment variable names, in any case. The result is
+// not validated, see Validate.
+func LoadConfig(path string) (*Config, error) {
+	v := viper.New()
+	setDefaults(v)
+
+	// Every key, so the environment applies to those without a default too
+	for _, key := range keys() {
+		if err := v.BindEnv(key); err != nil {
+			return nil, err
+		}
 	}
 
-	err = viper.Unmarshal(&config)
-	if err != nil {
-		return nil, err
+	if path != "" {
+		v.SetConfigFile(path)
+		if err := v.ReadInConfig(); err != nil {
+			return nil, fmt.Errorf("read config file: %w", err)
+		}
+	}
+
+	v.SetConfigFile(".env")
+	if err := v.MergeInConfig(); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("read .env: %w", err)
 	}
 
+	config := &Config{}
+	if err := v.Unmarshal(config); err != nil {
+		return nil, err
+	}
 	return config, nil
 }
+
+func setDefaults(v *viper.Viper) {
+	v.SetDefault("STORAGE", "postgres")
+	v.SetDefault("SQLITE_PATH", "./data/tasks.db")
+	v.SetDefault("DB_HOST", "localhost")
+	v.SetDefault("DB_PORT", "5432")
+	v.SetDefault("LISTEN_ADDR", ":8080")
+	v.SetDefault("CORS_ORIGINS", "http://localhost:3000")
+	v.SetDefault("TOKEN_LIFETIME", "72h")
+	v.SetDefault("BCRYPT_COST", 14)
+	v.SetDefault("LOG_LEVEL", "info")
+	v.SetDefault("TRACING_EXPORTER", "none")
+	v.SetDefault("OTLP_ENDPOINT", "")
+	v.SetDefault("TRACING_SERVICE_NAME", "task-manager")
+	v.SetDefault("REQUEST_TIMEOUT", "30s")
+	v.SetDefault("SHUTDOWN_TIMEOUT", "15s")
+	v.SetDefault("WORKER_CONCURRENCY", 4)
+	v.SetDefault("REMINDER_OFFSETS", "24h")
+	v.SetDefault("REALTIME_PG_NOTIFY", false)
+	v.SetDefault("BLOB_STORE", "local")
+	v.SetDefault("BLOB_LOCAL_DIR", "./data/attachments")
+	v.SetDefault("MAX_ATTACHMENT_SIZE", 10<<20)
+	v.SetDefault("ALLOWED_ATTACHMENT_TYPES", "image/png,image/jpeg,image/gif,image/webp,text/plain,application/pdf,application/zip,application/x-gzip")
+
+	v.SetDefault("TASK_URL_TEMPLATE", "http://localhost:3000/dashboard?task={id}")
+}
+
+// keys returns the name of every setting, in the order of the fields of Config
+func keys() []string {
+	t := reflect.TypeOf(Config{})
+	keys := make([]string, t.NumField())
+	for i := range keys {
+		keys[i] = t.Field(i).Tag.Get("mapstructure")
+	}
+	return keys
+}
+
+// problems collects invalid settings
+type problems []error
+
+func (p *problems) invalid(key, format string, args ...any) {
+	*p = append(*p, fmt.Errorf("%s %s", key, fmt.Sprintf(format, args...)))
+}
+
+// ValidateStorage reports every invalid storage or database setting, one per
+// line. These are all the migrate subcommand needs.
+func (c *Config) ValidateStorage() error {
+	var errs problems
+	c.validateStorage(&errs)
+	return errors.Join(errs...)
+}
+
+func (c *Config) validateStorage(errs *problems) {
+	invalid := errs.invalid
+
+	switch c.Storage {
+	case "postgres":
+		if c.DBHost == "" {
+			invalid("DB_HOST", "is required with postgres storage")
+		}
+		if c.DBName == "" {
+			invalid("DB_NAME", "is required with postgres storage")
+		}
+	case "sqlite":
+		if c.SQLitePath == "" {
+			invalid("SQLITE_PATH", "is required with sqlite storage")
+		}
+	case "memory":
+	default:
+		invalid("STORAGE", "must be postgres, sqlite or memory, got %q", c.Storage)
+	}
+}
+
+// Validate reports every invalid setting, one per line
+func (c *Config) Validate() error {
+	var errs problems
+	c.validateStorage(&errs)
+	invalid := errs.invalid
+
+	if c.ListenAddr == "" {
+		invalid("LISTEN_ADDR", "is required")
+	}
+	if origins := strings.TrimSpace(c.CORSOrigins); origins == "" || origins == "*" {
+		// Credentials are allowed, which browsers refuse with any origin
+		invalid("CORS_ORIGINS", "must list the allowed origins, * is not supported")
+	}
+
+	if len(c.JWTSecret) < minJWTSecretLength {
+		invalid("JWT_SECRET", "must be at least %d bytes, got %d", minJWTSecretLength, len(c.JWTSecret))
+	}
+	if c.TokenLifetime <= 0 {
+		invalid("TOKEN_LIFETIME", "must be positive, got %s", c.TokenLifetime)
+	}
+	if c.BcryptCost < bcrypt.MinCost || c.BcryptCost > bcrypt.MaxCost {
+		invalid("BCRYPT_COST", "must be between %d and %d, got %d", bcrypt.MinCost, bcrypt.MaxCost, c.BcryptCost)
+	}
+
+	if _, err := logging.ParseLevel(c.LogLevel); err != nil {
+		invalid("LOG_LEVEL", "must be debug, info, warn or error, got %q", c.LogLevel)
+	}
+	switch c.TracingExporter {
+	case "none", "stdout", "otlp":
+	default:
+		invalid("TRACING_EXPORTER", "must be none, stdout or otlp, got %q", c.TracingExporter)
+	}
+
+	if c.RequestTimeout < 0 {
+		invalid("REQUEST_TIMEOUT", "must not be negative, got %s", c.RequestTimeout)
+	}
+	if c.ShutdownTimeout <= 0 {
+		invalid("SHUTDOWN_TIMEOUT", "must be positive, got %s", c.ShutdownTimeout)
+	}
+
+	if c.WorkerConcurrency < 1 {
+		invalid("WORKER_CONCURRENCY", "must be at least 1, got %d", c.WorkerConcurrency)
+	}
+	if _, err := c.ReminderOffsetDurations(); err != nil {
+		invalid("REMINDER_OFFSETS", "must be a comma separated list of durations, such as 24h,1h: %v", err)
+	}
+
+	switch c.BlobStore {
+	case "local":
+		if c.BlobLocalDir == "" {
+			invalid("BLOB_LOCAL_DIR", "is required with the local blob store")
+		}
+	case "s3":
+		if c.S3Bucket == "" {
+			invalid("S3_BUCKET", "is required with the s3 blob store")
+		}
+	default:
+		invalid("BLOB_STORE", "must be local or s3, got %q", c.BlobStore)
+	}
+	if c.MaxAttachmentSize <= 0 {
+		invalid("MAX_ATTACHMENT_SIZE", "must be positive, got %d", c.MaxAttachmentSize)
+	}
+
+	return errors.Join(errs...)
+}
+
+// Print writes every setting as KEY=value lines, which can be used as a .env
+// file. Secrets that are set are redacted.
+func (c *Config) Print(w io.Writer) error {
+	value := reflect.ValueOf(c).Elem()
+	for i, key := range keys() {
+		field := value.Field(i)
+		text := fmt.Sprint(field.Interface())
+		if value.Type().Field(i).Tag.Get("secret") == "true" && !field.IsZero() {
+			text = logging.Redacted
+		}
+		if _, err := fmt.Fprintf(w, "%s=%s\n", key, text); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ReminderOffsetDurations parses ReminderOffsets, a comma separated list of
+// durations before a task's due date at which reminders are sent.
+func (c *Config) ReminderOffsetDurations() ([]time.Duration, error) {
+	var offsets []time.Duration
+	for _, part := range strings.Split(c.ReminderOffsets, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		offset, err := time.ParseDuration(part)
+		if err != nil {
+			return nil, err
+		}
+		offsets = append(offsets, offset)
+	}
+	return offsets, nil
+}
+
+// AttachmentTypes returns AllowedAttachmentTypes as a list of MIME types
+func (c *Config) AttachmentTypes() []string {
+	var types []string
+	for _, part := range strings.Split(c.AllowedAttachmentTypes, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			types = append(types, part)
+		}
+	}
+	return types
+}
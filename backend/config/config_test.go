@@ -0,0 +1,174 @@
+package config
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"backend/pkg/logging"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// inDir runs the test in dir, where LoadConfig looks for .env
+func inDir(t *testing.T, dir string) {
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(dir))
+	t.Cleanup(func() { os.Chdir(wd) })
+}
+
+func writeFile(t *testing.T, dir, name, content string) string {
+	path := filepath.Join(dir, name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func validConfig(t *testing.T) *Config {
+	inDir(t, t.TempDir())
+	cfg, err := LoadConfig("")
+	require.NoError(t, err)
+	cfg.DBName = "tasks"
+	cfg.JWTSecret = strings.Repeat("s", minJWTSecretLength)
+	return cfg
+}
+
+func TestLoadConfig_Defaults(t *testing.T) {
+	inDir(t, t.TempDir())
+
+	cfg, err := LoadConfig("")
+	require.NoError(t, err)
+	assert.Equal(t, "postgres", cfg.Storage)
+	assert.Equal(t, ":8080", cfg.ListenAddr)
+	assert.Equal(t, "http://localhost:3000", cfg.CORSOrigins)
+	assert.Equal(t, 72*time.Hour, cfg.TokenLifetime)
+	assert.Equal(t, 14, cfg.BcryptCost)
+	assert.Equal(t, 15*time.Second, cfg.ShutdownTimeout)
+	assert.Empty(t, cfg.JWTSecret)
+}
+
+func TestLoadConfig_Precedence(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		content string
+	}{
+		{
+			name: "YAML",
+			file: "config.yaml",
+			content: `listen_addr: ":9000"
+db_name: from-file
+db_user: from-file
+bcrypt_cost: 10
+`,
+		},
+		{
+			name: "TOML",
+			file: "config.toml",
+			content: `LISTEN_ADDR = ":9000"
+DB_NAME = "from-file"
+DB_USER = "from-file"
+BCRYPT_COST = 10
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			inDir(t, dir)
+			path := writeFile(t, dir, tt.file, tt.content)
+			writeFile(t, dir, ".env", "DB_NAME=from-dotenv\nDB_USER=from-dotenv\n")
+			t.Setenv("DB_USER", "from-env")
+			t.Setenv("JWT_SECRET", "from-env")
+
+			cfg, err := LoadConfig(path)
+			require.NoError(t, err)
+			assert.Equal(t, ":9000", cfg.ListenAddr)
+			assert.Equal(t, 10, cfg.BcryptCost)
+			assert.Equal(t, "from-dotenv", cfg.DBName)
+			assert.Equal(t, "from-env", cfg.DBUser)
+			// No default, set only in the environment
+			assert.Equal(t, "from-env", cfg.JWTSecret)
+			assert.Equal(t, 72*time.Hour, cfg.TokenLifetime)
+		})
+	}
+}
+
+func TestLoadConfig_MissingFile(t *testing.T) {
+	inDir(t, t.TempDir())
+
+	_, err := LoadConfig("missing.yaml")
+	assert.ErrorContains(t, err, "read config file")
+}
+
+func TestConfig_Validate(t *testing.T) {
+	assert.NoError(t, validConfig(t).Validate())
+
+	cfg := validConfig(t)
+	cfg.Storage = "mongo"
+	cfg.CORSOrigins = "*"
+	cfg.JWTSecret = "short"
+	cfg.BcryptCost = 40
+	cfg.TokenLifetime = 0
+	cfg.LogLevel = "loud"
+	cfg.ReminderOffsets = "1d"
+	cfg.BlobStore = "s3"
+
+	err := cfg.Validate()
+	require.Error(t, err)
+	assert.Equal(t, []string{
+		`STORAGE must be postgres, sqlite or memory, got "mongo"`,
+		"CORS_ORIGINS must list the allowed origins, * is not supported",
+		"JWT_SECRET must be at least 32 bytes, got 5",
+		"TOKEN_LIFETIME must be positive, got 0s",
+		"BCRYPT_COST must be between 4 and 31, got 40",
+		`LOG_LEVEL must be debug, info, warn or error, got "loud"`,
+		`REMINDER_OFFSETS must be a comma separated list of durations, such as 24h,1h: time: unknown unit "d" in duration "1d"`,
+		"S3_BUCKET is required with the s3 blob store",
+	}, strings.Split(err.Error(), "\n"))
+}
+
+func TestConfig_Validate_Postgres(t *testing.T) {
+	cfg := validConfig(t)
+	cfg.DBName = ""
+	assert.EqualError(t, cfg.Validate(), "DB_NAME is required with postgres storage")
+
+	cfg.Storage = "memory"
+	assert.NoError(t, cfg.Validate())
+}
+
+func TestConfig_ValidateStorage(t *testing.T) {
+	cfg := validConfig(t)
+	// As shipped in .env_example
+	cfg.JWTSecret = ""
+	assert.NoError(t, cfg.ValidateStorage())
+	assert.Error(t, cfg.Validate())
+
+	cfg.Storage = "sqlite"
+	cfg.SQLitePath = ""
+	assert.EqualError(t, cfg.ValidateStorage(), "SQLITE_PATH is required with sqlite storage")
+}
+
+func TestConfig_Print(t *testing.T) {
+	cfg := validConfig(t)
+	cfg.DBPassword = "hunter22"
+
+	var buf bytes.Buffer
+	require.NoError(t, cfg.Print(&buf))
+
+	out := buf.String()
+	assert.Contains(t, out, "LISTEN_ADDR=:8080\n")
+	assert.Contains(t, out, "TOKEN_LIFETIME=72h0m0s\n")
+	assert.Contains(t, out, "DB_PASSWORD="+logging.Redacted+"\n")
+	assert.Contains(t, out, "JWT_SECRET="+logging.Redacted+"\n")
+	// Secrets that are not set are shown as such
+	assert.Contains(t, out, "S3_SECRET_KEY=\n")
+	assert.NotContains(t, out, "hunter22")
+	assert.NotContains(t, out, cfg.JWTSecret)
+	assert.Len(t, strings.Split(strings.TrimSpace(out), "\n"), len(keys()))
+}
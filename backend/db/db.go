@@ -1,6 +1,7 @@
 package db
 
 import (
+	"backend/tracing"
 	"fmt"
 
 	"github.com/jmoiron/sqlx"
@@ -8,8 +9,26 @@ import (
 )
 
 func Connect(host, port, user, password, dbname string) (*sqlx.DB, error) {
-	dsn := fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=disable",
-		host, port, user, password, dbname)
+	return connect("postgres", DSN(host, port, user, password, dbname), "postgresql")
+}
 
-	return sqlx.Connect("postgres", dsn)
+// connect opens and pings a database whose statements are traced.
+// system is the OpenTelemetry name of the database.
+func connect(driverName, dsn, system string) (*sqlx.DB, error) {
+	sqlDB, err := tracing.OpenDB(driverName, dsn, system)
+	if err != nil {
+		return nil, err
+	}
+	database := sqlx.NewDb(sqlDB, driverName)
+	if err := database.Ping(); err != nil {
+		database.Close()
+		return nil, err
+	}
+	return database, nil
+}
+
+// DSN builds the lib/pq connection string used by Connect
+func DSN(host, port, user, password, dbname string) string {
+	return fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=disable",
+		host, port, user, password, dbname)
 }
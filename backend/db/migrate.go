@@ -0,0 +1,347 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+)
+
+//go:embed migrations/*.sql migrations/sqlite/*.sql
+var migrationFiles embed.FS
+
+// Dialect names the SQL dialect a set of migrations is written in
+type Dialect string
+
+const (
+	Postgres Dialect = "postgres"
+	SQLite   Dialect = "sqlite"
+)
+
+// migrationLockID is the PostgreSQL advisory lock held while migrating, so
+// instances starting together apply each migration once. It spells "task".
+const migrationLockID int64 = 0x7461736b
+
+var (
+	// ErrSchemaNewer is returned when the database has migrations this
+	// binary does not know about, i.e. a newer version has migrated it
+	ErrSchemaNewer = errors.New("database schema is newer than this binary")
+	// ErrDirty is returned when a migration failed halfway. Fix the schema by
+	// hand, then force the version.
+	ErrDirty = errors.New("database schema is dirty, fix it and run migrate force")
+)
+
+// Migration is one version of the schema
+type Migration struct {
+	Version int64
+	Name    string
+	Up      string
+	Down    string
+}
+
+// MigrationStatus describes how far a database is migrated
+type MigrationStatus struct {
+	Version int64
+	Dirty   bool
+	Latest  int64
+	Pending []Migration
+}
+
+// Migrator applies the migrations embedded in the binary. The version is
+// kept in the schema_migrations table golang-migrate uses, so databases
+// migrated with the migrate CLI carry on where it left off.
+type Migrator struct {
+	db         *sqlx.DB
+	dialect    Dialect
+	migrations []Migration
+}
+
+func NewMigrator(db *sqlx.DB, dialect Dialect) (*Migrator, error) {
+	dir := "migrations"
+	if dialect == SQLite {
+		dir = "migrations/sqlite"
+	} else if dialect != Postgres {
+		return nil, fmt.Errorf("unknown dialect %q", dialect)
+	}
+
+	migrations, err := loadMigrations(migrationFiles, dir)
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{db: db, dialect: dialect, migrations: migrations}, nil
+}
+
+// Latest returns the version of the newest embedded migration
+func (m *Migrator) Latest() int64 {
+	if len(m.migrations) == 0 {
+		return 0
+	}
+	return m.migrations[len(m.migrations)-1].Version
+}
+
+// Status reports the database's version and the migrations not yet applied
+func (m *Migrator) Status(ctx context.Context) (*MigrationStatus, error) {
+	status := &MigrationStatus{Latest: m.Latest()}
+	err := m.withLock(ctx, func(conn *sqlx.Conn) error {
+		var err error
+		status.Version, status.Dirty, err = m.version(ctx, conn)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return m.withPending(status), nil
+}
+
+// ReadStatus is Status without the migration lock and without creating the
+// schema_migrations table, for frequent checks such as readiness probes. A
+// database without the table is at version 0.
+func (m *Migrator) ReadStatus(ctx context.Context) (*MigrationStatus, error) {
+	status := &MigrationStatus{Latest: m.Latest()}
+	query := `SELECT COUNT(*) FROM pg_tables WHERE schemaname = current_schema() AND tablename = 'schema_migrations'`
+	if m.dialect == SQLite {
+		query = `SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = 'schema_migrations'`
+	}
+	var tables int
+	if err := m.db.GetContext(ctx, &tables, query); err != nil {
+		return nil, err
+	}
+	if tables > 0 {
+		var err error
+		status.Version, status.Dirty, err = m.version(ctx, m.db)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return m.withPending(status), nil
+}
+
+// withPending fills in the migrations newer than status.Version
+func (m *Migrator) withPending(status *MigrationStatus) *MigrationStatus {
+	for _, migration := range m.migrations {
+		if migration.Version > status.Version {
+			status.Pending = append(status.Pending, migration)
+		}
+	}
+	return status
+}
+
+// Up applies the pending migrations in order, each in its own transaction
+func (m *Migrator) Up(ctx context.Context) error {
+	return m.withLock(ctx, func(conn *sqlx.Conn) error {
+		for {
+			applied, err := m.step(ctx, conn, func(version int64) (*Migration, int64, error) {
+				if version > m.Latest() {
+					return nil, 0, fmt.Errorf("%w: at version %d, this binary knows up to %d", ErrSchemaNewer, version, m.Latest())
+				}
+				for i := range m.migrations {
+					if m.migrations[i].Version > version {
+						return &m.migrations[i], m.migrations[i].Version, nil
+					}
+				}
+				return nil, 0, nil
+			}, func(migration *Migration) string { return migration.Up })
+			if err != nil || !applied {
+				return err
+			}
+		}
+	})
+}
+
+// Down reverts the last steps migrations
+func (m *Migrator) Down(ctx context.Context, steps int) error {
+	return m.withLock(ctx, func(conn *sqlx.Conn) error {
+		for ; steps > 0; steps-- {
+			applied, err := m.step(ctx, conn, func(version int64) (*Migration, int64, error) {
+				for i := range m.migrations {
+					if m.migrations[i].Version != version {
+						continue
+					}
+					if m.migrations[i].Down == "" {
+						return nil, 0, fmt.Errorf("migration %d has no down migration", version)
+					}
+					var previous int64
+					if i > 0 {
+						previous = m.migrations[i-1].Version
+					}
+					return &m.migrations[i], previous, nil
+				}
+				if version == 0 {
+					return nil, 0, nil
+				}
+				return nil, 0, fmt.Errorf("%w: at version %d, which this binary does not know", ErrSchemaNewer, version)
+			}, func(migration *Migration) string { return migration.Down })
+			if err != nil || !applied {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Force records version as the database's version and clears the dirty flag
+// without running any migration
+func (m *Migrator) Force(ctx context.Context, version int64) error {
+	if version < 0 {
+		return fmt.Errorf("invalid version %d", version)
+	}
+	return m.withLock(ctx, func(conn *sqlx.Conn) error {
+		tx, err := conn.BeginTxx(ctx, nil)
+		if err != nil {
+			return err
+		}
+		defer tx.Rollback()
+
+		if err := setVersion(ctx, tx, version); err != nil {
+			return err
+		}
+		return tx.Commit()
+	})
+}
+
+// step runs the migration next picks for the current version and records
+// the version it returns, in one transaction. It reports whether a migration
+// ran.
+func (m *Migrator) step(ctx context.Context, conn *sqlx.Conn, next func(version int64) (*Migration, int64, error), query func(*Migration) string) (bool, error) {
+	// SQLite transactions begin with BEGIN IMMEDIATE, so reading the version
+	// inside the transaction keeps other processes out until it commits
+	tx, err := conn.BeginTxx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	version, dirty, err := m.version(ctx, tx)
+	if err != nil {
+		return false, err
+	}
+	if dirty {
+		return false, fmt.Errorf("%w: version %d", ErrDirty, version)
+	}
+
+	migration, target, err := next(version)
+	if err != nil || migration == nil {
+		return false, err
+	}
+	if _, err := tx.ExecContext(ctx, query(migration)); err != nil {
+		return false, fmt.Errorf("migration %d_%s: %w", migration.Version, migration.Name, err)
+	}
+	if err := setVersion(ctx, tx, target); err != nil {
+		return false, err
+	}
+	return true, tx.Commit()
+}
+
+// withLock creates the schema_migrations table if needed and calls fn with a
+// connection holding the migration lock on PostgreSQL
+func (m *Migrator) withLock(ctx context.Context, fn func(conn *sqlx.Conn) error) error {
+	conn, err := m.db.Connx(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if m.dialect == Postgres {
+		if _, err := conn.ExecContext(ctx, `SELECT pg_advisory_lock($1)`, migrationLockID); err != nil {
+			return fmt.Errorf("acquiring migration lock: %w", err)
+		}
+		// Unlock even when ctx is done, or the lock lives on with the
+		// pooled connection
+		defer conn.ExecContext(context.Background(), `SELECT pg_advisory_unlock($1)`, migrationLockID)
+	}
+
+	versionType := "BIGINT"
+	if m.dialect == SQLite {
+		versionType = "INTEGER"
+	}
+	if _, err := conn.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS schema_migrations (version `+versionType+` NOT NULL PRIMARY KEY, dirty BOOLEAN NOT NULL)`); err != nil {
+		return err
+	}
+	return fn(conn)
+}
+
+func (m *Migrator) version(ctx context.Context, q sqlx.QueryerContext) (version int64, dirty bool, err error) {
+	err = q.QueryRowxContext(ctx, `SELECT version, dirty FROM schema_migrations LIMIT 1`).Scan(&version, &dirty)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, false, nil
+	}
+	return version, dirty, err
+}
+
+// setVersion replaces the recorded version. Version 0 means no migrations,
+// which golang-migrate records as an empty table.
+func setVersion(ctx context.Context, tx *sqlx.Tx, version int64) error {
+	if _, err := tx.ExecContext(ctx, `DELETE FROM schema_migrations`); err != nil {
+		return err
+	}
+	if version == 0 {
+		return nil
+	}
+	_, err := tx.ExecContext(ctx, `INSERT INTO schema_migrations (version, dirty) VALUES ($1, $2)`, version, false)
+	return err
+}
+
+// loadMigrations reads the {version}_{name}.{up|down}.sql files in dir,
+// ordered by version
+func loadMigrations(fsys fs.FS, dir string) ([]Migration, error) {
+	files, err := fs.Glob(fsys, dir+"/*.sql")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := make(map[int64]*Migration)
+	for _, file := range files {
+		base := path.Base(file)
+		parts := strings.SplitN(base, "_", 2)
+		version, err := strconv.ParseInt(parts[0], 10, 64)
+		if err != nil || len(parts) != 2 || version <= 0 {
+			return nil, fmt.Errorf("%s: migration files must be named {version}_{name}.{up|down}.sql", file)
+		}
+
+		var direction string
+		name := parts[1]
+		switch {
+		case strings.HasSuffix(name, ".up.sql"):
+			direction, name = "up", strings.TrimSuffix(name, ".up.sql")
+		case strings.HasSuffix(name, ".down.sql"):
+			direction, name = "down", strings.TrimSuffix(name, ".down.sql")
+		default:
+			return nil, fmt.Errorf("%s: migration files must end in .up.sql or .down.sql", file)
+		}
+
+		body, err := fs.ReadFile(fsys, file)
+		if err != nil {
+			return nil, err
+		}
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: name}
+			byVersion[version] = migration
+		} else if migration.Name != name {
+			return nil, fmt.Errorf("%s: version %d is also used by %s", file, version, migration.Name)
+		}
+		if direction == "up" {
+			migration.Up = string(body)
+		} else {
+			migration.Down = string(body)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if migration.Up == "" {
+			return nil, fmt.Errorf("migration %d_%s has no up migration", migration.Version, migration.Name)
+		}
+		migrations = append(migrations, *migration)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return migrations, nil
+}
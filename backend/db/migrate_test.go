@@ -0,0 +1,219 @@
+package db
+
+import (
+	"context"
+	"path/filepath"
+	"sync"
+	"testing"
+	"testing/fstest"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestMigrator(t *testing.T) (*Migrator, *sqlx.DB) {
+	database, err := ConnectSQLite(filepath.Join(t.TempDir(), "tasks.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() { database.Close() })
+
+	migrator, err := NewMigrator(database, SQLite)
+	require.NoError(t, err)
+	return migrator, database
+}
+
+func TestMigrator_Up(t *testing.T) {
+	migrator, database := newTestMigrator(t)
+	ctx := context.Background()
+
+	status, err := migrator.Status(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), status.Version)
+	assert.Len(t, status.Pending, len(migrator.migrations))
+
+	require.NoError(t, migrator.Up(ctx))
+	status, err = migrator.Status(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, migrator.Latest(), status.Version)
+	assert.False(t, status.Dirty)
+	assert.Empty(t, status.Pending)
+
+	_, err = database.Exec(`INSERT INTO users (username, email, password, created_at, updated_at) VALUES ('a', 'a@example.com', 'x', '2030-01-01 00:00:00.000000', '2030-01-01 00:00:00.000000')`)
+	require.NoError(t, err)
+
+	// Running again applies nothing and keeps the data
+	require.NoError(t, migrator.Up(ctx))
+	var users int
+	require.NoError(t, database.Get(&users, `SELECT COUNT(*) FROM users`))
+	assert.Equal(t, 1, users)
+}
+
+func TestMigrator_Up_Concurrent(t *testing.T) {
+	migrator, _ := newTestMigrator(t)
+
+	var wg sync.WaitGroup
+	errs := make([]error, 4)
+	for i := range errs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs[i] = migrator.Up(context.Background())
+		}(i)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		assert.NoError(t, err)
+	}
+	status, err := migrator.Status(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, migrator.Latest(), status.Version)
+}
+
+func TestMigrator_Down(t *testing.T) {
+	migrator, database := newTestMigrator(t)
+	ctx := context.Background()
+	require.NoError(t, migrator.Up(ctx))
+
+	require.NoError(t, migrator.Down(ctx, 1))
+	status, err := migrator.Status(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, migrator.migrations[len(migrator.migrations)-2].Version, status.Version)
+	assert.Len(t, status.Pending, 1)
+
+	// Reverting more steps than were applied stops at an empty schema
+	require.NoError(t, migrator.Down(ctx, 100))
+	status, err = migrator.Status(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), status.Version)
+	var tables int
+	require.NoError(t, database.Get(&tables, `SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name IN ('users', 'tasks')`))
+	assert.Equal(t, 0, tables)
+
+	require.NoError(t, migrator.Up(ctx))
+}
+
+func TestMigrator_ReadStatus(t *testing.T) {
+	migrator, database := newTestMigrator(t)
+	ctx := context.Background()
+
+	status, err := migrator.ReadStatus(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), status.Version)
+	assert.Len(t, status.Pending, len(migrator.migrations))
+	var tables int
+	require.NoError(t, database.Get(&tables, `SELECT COUNT(*) FROM sqlite_master WHERE name = 'schema_migrations'`))
+	assert.Zero(t, tables, "reading the status must not create schema_migrations")
+
+	require.NoError(t, migrator.Up(ctx))
+	status, err = migrator.ReadStatus(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, migrator.Latest(), status.Version)
+	assert.Empty(t, status.Pending)
+
+	_, err = database.Exec(`UPDATE schema_migrations SET dirty = TRUE`)
+	require.NoError(t, err)
+	status, err = migrator.ReadStatus(ctx)
+	require.NoError(t, err)
+	assert.True(t, status.Dirty)
+}
+
+func TestMigrator_SchemaNewer(t *testing.T) {
+	migrator, _ := newTestMigrator(t)
+	ctx := context.Background()
+	require.NoError(t, migrator.Up(ctx))
+
+	require.NoError(t, migrator.Force(ctx, migrator.Latest()+1))
+	assert.ErrorIs(t, migrator.Up(ctx), ErrSchemaNewer)
+	assert.ErrorIs(t, migrator.Down(ctx, 1), ErrSchemaNewer)
+}
+
+func TestMigrator_Dirty(t *testing.T) {
+	migrator, database := newTestMigrator(t)
+	ctx := context.Background()
+
+	// As left by golang-migrate after a failed migration
+	_, err := database.Exec(`CREATE TABLE schema_migrations (version INTEGER NOT NULL PRIMARY KEY, dirty BOOLEAN NOT NULL)`)
+	require.NoError(t, err)
+	_, err = database.Exec(`INSERT INTO schema_migrations (version, dirty) VALUES (1, TRUE)`)
+	require.NoError(t, err)
+
+	assert.ErrorIs(t, migrator.Up(ctx), ErrDirty)
+	status, err := migrator.Status(ctx)
+	require.NoError(t, err)
+	assert.True(t, status.Dirty)
+
+	require.NoError(t, migrator.Force(ctx, 0))
+	require.NoError(t, migrator.Up(ctx))
+}
+
+func TestLoadMigrations(t *testing.T) {
+	migrations, err := loadMigrations(fstest.MapFS{
+		"m/000002_tasks.up.sql":   {Data: []byte("CREATE TABLE tasks ()")},
+		"m/000001_users.up.sql":   {Data: []byte("CREATE TABLE users ()")},
+		"m/000001_users.down.sql": {Data: []byte("DROP TABLE users")},
+	}, "m")
+	require.NoError(t, err)
+	assert.Equal(t, []Migration{
+		{Version: 1, Name: "users", Up: "CREATE TABLE users ()", Down: "DROP TABLE users"},
+		{Version: 2, Name: "tasks", Up: "CREATE TABLE tasks ()"},
+	}, migrations)
+
+	for name, fsys := range map[string]fstest.MapFS{
+		"no version":   {"m/users.up.sql": {}},
+		"no direction": {"m/000001_users.sql": {}},
+		"no up":        {"m/000001_users.down.sql": {}},
+		"two names":    {"m/000001_users.up.sql": {}, "m/000001_people.down.sql": {}},
+	} {
+		_, err := loadMigrations(fsys, "m")
+		assert.Error(t, err, name)
+	}
+}
+
+func TestSQLiteTaskIntegrityMigration(t *testing.T) {
+	migrator, database := newTestMigrator(t)
+	ctx := context.Background()
+	require.NoError(t, migrator.Up(ctx))
+	require.NoError(t, migrator.Down(ctx, 1))
+
+	// Rows the constraints would reject, written before they existed
+	_, err := database.Exec(`INSERT INTO users (id, username, email, password, created_at, updated_at) VALUES (1, 'a', 'a@example.com', 'x', '2030-01-01 00:00:00.000000', '2030-01-01 00:00:00.000000')`)
+	require.NoError(t, err)
+	_, err = database.Exec(`INSERT INTO tasks (id, title, status, assignee_id, assigner_id, priority, created_at, updated_at) VALUES
+		(1, 'Valid', 'DONE', 1, 1, 2, '2030-01-01 00:00:00.000000', '2030-01-01 00:00:00.000000'),
+		(5, 'Invalid', 'BLOCKED', 7, 1, 9, '2030-01-01 00:00:00.000000', '2030-01-01 00:00:00.000000')`)
+	require.NoError(t, err)
+	_, err = database.Exec(`DELETE FROM tasks WHERE id = 5`)
+	require.NoError(t, err)
+	_, err = database.Exec(`INSERT INTO tasks (id, title, status, assignee_id, assigner_id, priority, created_at, updated_at) VALUES
+		(2, 'Invalid', 'BLOCKED', 7, 1, 9, '2030-01-01 00:00:00.000000', '2030-01-01 00:00:00.000000')`)
+	require.NoError(t, err)
+
+	require.NoError(t, migrator.Up(ctx))
+
+	type row struct {
+		ID         int64  `db:"id"`
+		Status     string `db:"status"`
+		AssigneeID *int64 `db:"assignee_id"`
+		Priority   int    `db:"priority"`
+	}
+	var rows []row
+	require.NoError(t, database.Select(&rows, `SELECT id, status, assignee_id, priority FROM tasks ORDER BY id`))
+	one := int64(1)
+	assert.Equal(t, []row{{1, "DONE", &one, 2}, {2, "TO_DO", nil, 3}}, rows)
+
+	// IDs of deleted tasks are not reused
+	var id int64
+	require.NoError(t, database.Get(&id, `INSERT INTO tasks (title, status, priority, created_at, updated_at) VALUES ('New', 'TO_DO', 1, '2030-01-01 00:00:00.000000', '2030-01-01 00:00:00.000000') RETURNING id`))
+	assert.Equal(t, int64(6), id)
+
+	_, err = database.Exec(`UPDATE tasks SET status = 'BLOCKED' WHERE id = 1`)
+	assert.Error(t, err)
+
+	// Deleting a user unassigns their tasks
+	_, err = database.Exec(`DELETE FROM users WHERE id = 1`)
+	require.NoError(t, err)
+	var assigned int
+	require.NoError(t, database.Get(&assigned, `SELECT COUNT(*) FROM tasks WHERE assignee_id IS NOT NULL OR assigner_id IS NOT NULL`))
+	assert.Equal(t, 0, assigned)
+}
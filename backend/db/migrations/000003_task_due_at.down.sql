@@ -0,0 +1,3 @@
+-- Drop due date column
+DROP INDEX IF EXISTS idx_due_at;
+ALTER TABLE tasks DROP COLUMN due_at;
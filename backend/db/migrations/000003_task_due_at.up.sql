@@ -0,0 +1,4 @@
+-- Optional due date used for reminders
+ALTER TABLE tasks ADD COLUMN due_at TIMESTAMP;
+
+CREATE INDEX idx_due_at ON tasks(due_at);
@@ -0,0 +1,2 @@
+-- Drop jobs table
+DROP TABLE jobs;
@@ -0,0 +1,3 @@
+-- Drop webhook tables
+DROP TABLE webhook_deliveries;
+DROP TABLE webhook_subscriptions;
@@ -0,0 +1,3 @@
+-- Drop notification tables
+DROP TABLE notification_preferences;
+DROP TABLE notifications;
@@ -0,0 +1,23 @@
+-- In-app notifications shown in a user's inbox
+CREATE TABLE notifications (
+    id BIGSERIAL PRIMARY KEY,
+    user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    type VARCHAR(64) NOT NULL,
+    task_id INT REFERENCES tasks(id) ON DELETE CASCADE,
+    actor_id INT REFERENCES users(id) ON DELETE SET NULL,
+    message TEXT NOT NULL,
+    read_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Per-user opt-outs; a missing row means the type is enabled
+CREATE TABLE notification_preferences (
+    user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    type VARCHAR(64) NOT NULL,
+    enabled BOOLEAN NOT NULL,
+    PRIMARY KEY (user_id, type)
+);
+
+-- Indexes
+CREATE INDEX idx_notifications_user_unread ON notifications(user_id, created_at DESC) WHERE read_at IS NULL;
+CREATE INDEX idx_notifications_user_id ON notifications(user_id, created_at DESC);
@@ -0,0 +1,2 @@
+-- Drop mentions table
+DROP TABLE mentions;
@@ -0,0 +1,12 @@
+-- @username mentions found in task text. Mentions of users who cannot see
+-- the task are kept but flagged and never notified.
+CREATE TABLE mentions (
+    id BIGSERIAL PRIMARY KEY,
+    task_id INT NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    source VARCHAR(64) NOT NULL,
+    mentioned_user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    actor_id INT REFERENCES users(id) ON DELETE SET NULL,
+    flagged BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (task_id, source, mentioned_user_id)
+);
@@ -0,0 +1,17 @@
+-- Files attached to tasks. task_id deliberately has no foreign key: when a
+-- task is deleted a background job removes the stored blobs and then these
+-- rows.
+CREATE TABLE attachments (
+    id BIGSERIAL PRIMARY KEY,
+    task_id INT NOT NULL,
+    uploader_id INT REFERENCES users(id) ON DELETE SET NULL,
+    filename VARCHAR(255) NOT NULL,
+    content_type VARCHAR(255) NOT NULL,
+    size_bytes BIGINT NOT NULL,
+    checksum_sha256 CHAR(64) NOT NULL,
+    storage_key TEXT NOT NULL UNIQUE,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Indexes
+CREATE INDEX idx_attachments_task_id ON attachments(task_id);
@@ -0,0 +1,4 @@
+-- Drop time tracking
+DROP TABLE work_logs;
+ALTER TABLE tasks DROP COLUMN remaining_estimate_minutes;
+ALTER TABLE tasks DROP COLUMN original_estimate_minutes;
@@ -0,0 +1,22 @@
+-- Estimates in minutes
+ALTER TABLE tasks ADD COLUMN original_estimate_minutes INT;
+ALTER TABLE tasks ADD COLUMN remaining_estimate_minutes INT;
+
+-- Time spent on tasks, from timers (ended_at is NULL while running) or
+-- entered manually
+CREATE TABLE work_logs (
+    id BIGSERIAL PRIMARY KEY,
+    task_id INT NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    started_at TIMESTAMP NOT NULL,
+    ended_at TIMESTAMP,
+    duration_seconds INT,
+    note TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Indexes
+CREATE INDEX idx_work_logs_task_id ON work_logs(task_id, started_at);
+CREATE INDEX idx_work_logs_user_id ON work_logs(user_id, started_at);
+-- A user can only have one running timer
+CREATE UNIQUE INDEX idx_work_logs_running_timer ON work_logs(user_id) WHERE ended_at IS NULL;
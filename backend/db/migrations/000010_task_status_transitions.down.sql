@@ -0,0 +1,2 @@
+-- Drop status transitions
+DROP TABLE task_status_transitions;
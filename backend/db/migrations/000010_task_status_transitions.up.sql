@@ -0,0 +1,22 @@
+-- Status changes of tasks, used for cycle-time and throughput analytics.
+-- from_status is NULL for the status a task was created with.
+CREATE TABLE task_status_transitions (
+    id BIGSERIAL PRIMARY KEY,
+    task_id INT NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    from_status VARCHAR(255),
+    to_status VARCHAR(255) NOT NULL,
+    changed_by INT REFERENCES users(id) ON DELETE SET NULL,
+    changed_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Indexes
+CREATE INDEX idx_task_status_transitions_task_id ON task_status_transitions(task_id, changed_at);
+CREATE INDEX idx_task_status_transitions_changed_at ON task_status_transitions(to_status, changed_at);
+
+-- Backfill existing tasks. Their history is unknown, so assume they were
+-- created as TO_DO and moved to their current status on their last update.
+INSERT INTO task_status_transitions (task_id, from_status, to_status, changed_at)
+SELECT id, NULL, 'TO_DO', created_at FROM tasks;
+
+INSERT INTO task_status_transitions (task_id, from_status, to_status, changed_at)
+SELECT id, 'TO_DO', status, updated_at FROM tasks WHERE status <> 'TO_DO';
@@ -0,0 +1,2 @@
+-- Drop calendar tokens
+DROP TABLE calendar_tokens;
@@ -0,0 +1,7 @@
+-- Secret tokens for iCalendar feed URLs. Only a SHA-256 hash of the token is
+-- stored, and each user has at most one.
+CREATE TABLE calendar_tokens (
+    user_id INT PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    token_hash VARCHAR(64) NOT NULL UNIQUE,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
@@ -0,0 +1,74 @@
+-- Foreign keys and CHECK constraints for tasks, and time zones for every table.
+-- Existing rows the new constraints would reject are repaired first.
+
+-- Unassign tasks whose assignee or assigner no longer exists
+UPDATE tasks SET assignee_id = NULL WHERE assignee_id NOT IN (SELECT id FROM users);
+UPDATE tasks SET assigner_id = NULL WHERE assigner_id NOT IN (SELECT id FROM users);
+
+-- Deleting a user keeps their tasks, unassigned
+ALTER TABLE tasks
+    ADD CONSTRAINT tasks_assignee_id_fkey FOREIGN KEY (assignee_id) REFERENCES users(id) ON DELETE SET NULL,
+    ADD CONSTRAINT tasks_assigner_id_fkey FOREIGN KEY (assigner_id) REFERENCES users(id) ON DELETE SET NULL;
+
+-- Updates did not validate the status and creates did not validate the
+-- priority, so fall back to TO_DO and clamp priorities into range
+UPDATE tasks SET status = 'TO_DO' WHERE status NOT IN ('TO_DO', 'IN_PROGRESS', 'DONE');
+UPDATE tasks SET priority = GREATEST(1, LEAST(3, priority)) WHERE priority NOT BETWEEN 1 AND 3;
+
+ALTER TABLE tasks
+    ADD CONSTRAINT tasks_status_check CHECK (status IN ('TO_DO', 'IN_PROGRESS', 'DONE')),
+    ADD CONSTRAINT tasks_priority_check CHECK (priority BETWEEN 1 AND 3);
+
+-- Existing values are taken to be UTC: the server normalised due dates to
+-- UTC before storing them, and NOW() wrote the database's time zone, which
+-- is UTC unless configured otherwise
+ALTER TABLE users
+    ALTER COLUMN created_at TYPE TIMESTAMPTZ USING created_at AT TIME ZONE 'UTC',
+    ALTER COLUMN updated_at TYPE TIMESTAMPTZ USING updated_at AT TIME ZONE 'UTC';
+
+ALTER TABLE tasks
+    ALTER COLUMN created_at TYPE TIMESTAMPTZ USING created_at AT TIME ZONE 'UTC',
+    ALTER COLUMN updated_at TYPE TIMESTAMPTZ USING updated_at AT TIME ZONE 'UTC',
+    ALTER COLUMN due_at TYPE TIMESTAMPTZ USING due_at AT TIME ZONE 'UTC';
+
+ALTER TABLE webhook_subscriptions
+    ALTER COLUMN created_at TYPE TIMESTAMPTZ USING created_at AT TIME ZONE 'UTC',
+    ALTER COLUMN updated_at TYPE TIMESTAMPTZ USING updated_at AT TIME ZONE 'UTC';
+
+ALTER TABLE webhook_deliveries
+    ALTER COLUMN created_at TYPE TIMESTAMPTZ USING created_at AT TIME ZONE 'UTC',
+    ALTER COLUMN updated_at TYPE TIMESTAMPTZ USING updated_at AT TIME ZONE 'UTC';
+
+ALTER TABLE notifications
+    ALTER COLUMN read_at TYPE TIMESTAMPTZ USING read_at AT TIME ZONE 'UTC',
+    ALTER COLUMN created_at TYPE TIMESTAMPTZ USING created_at AT TIME ZONE 'UTC';
+
+ALTER TABLE mentions
+    ALTER COLUMN created_at TYPE TIMESTAMPTZ USING created_at AT TIME ZONE 'UTC';
+
+ALTER TABLE attachments
+    ALTER COLUMN created_at TYPE TIMESTAMPTZ USING created_at AT TIME ZONE 'UTC';
+
+ALTER TABLE work_logs
+    ALTER COLUMN started_at TYPE TIMESTAMPTZ USING started_at AT TIME ZONE 'UTC',
+    ALTER COLUMN ended_at TYPE TIMESTAMPTZ USING ended_at AT TIME ZONE 'UTC',
+    ALTER COLUMN created_at TYPE TIMESTAMPTZ USING created_at AT TIME ZONE 'UTC';
+
+ALTER TABLE task_status_transitions
+    ALTER COLUMN changed_at TYPE TIMESTAMPTZ USING changed_at AT TIME ZONE 'UTC';
+
+ALTER TABLE calendar_tokens
+    ALTER COLUMN created_at TYPE TIMESTAMPTZ USING created_at AT TIME ZONE 'UTC';
+
+-- 000004 declares the jobs columns TIMESTAMPTZ, but databases migrated
+-- before it did have TIMESTAMP. With the session in UTC the plain cast reads
+-- TIMESTAMP values as UTC and leaves TIMESTAMPTZ values as they are.
+SET LOCAL TIME ZONE 'UTC';
+ALTER TABLE jobs
+    ALTER COLUMN run_at TYPE TIMESTAMPTZ,
+    ALTER COLUMN locked_until TYPE TIMESTAMPTZ,
+    ALTER COLUMN created_at TYPE TIMESTAMPTZ,
+    ALTER COLUMN updated_at TYPE TIMESTAMPTZ;
+
+-- The primary key is already indexed
+DROP INDEX IF EXISTS idx_id;
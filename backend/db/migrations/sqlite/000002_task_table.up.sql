@@ -0,0 +1,21 @@
+-- Tasks table, with the columns later PostgreSQL migrations added to it.
+-- Timestamps are stored as UTC text in "YYYY-MM-DD HH:MM:SS.SSSSSS" form.
+CREATE TABLE tasks (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    title TEXT NOT NULL,
+    description TEXT,
+    status TEXT NOT NULL,
+    assignee_id INTEGER,
+    assigner_id INTEGER,
+    priority INTEGER NOT NULL,
+    due_at TIMESTAMP,
+    original_estimate_minutes INTEGER,
+    remaining_estimate_minutes INTEGER,
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX idx_assignee_id ON tasks(assignee_id);
+CREATE INDEX idx_assigner_id ON tasks(assigner_id);
+CREATE INDEX idx_priority ON tasks(priority);
+CREATE INDEX idx_due_at ON tasks(due_at);
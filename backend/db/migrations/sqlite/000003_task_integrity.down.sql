@@ -0,0 +1,29 @@
+-- Rebuild tasks without foreign keys and CHECK constraints
+ALTER TABLE tasks RENAME TO tasks_old;
+
+CREATE TABLE tasks (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    title TEXT NOT NULL,
+    description TEXT,
+    status TEXT NOT NULL,
+    assignee_id INTEGER,
+    assigner_id INTEGER,
+    priority INTEGER NOT NULL,
+    due_at TIMESTAMP,
+    original_estimate_minutes INTEGER,
+    remaining_estimate_minutes INTEGER,
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL
+);
+
+INSERT INTO tasks SELECT * FROM tasks_old;
+
+DELETE FROM sqlite_sequence WHERE name = 'tasks';
+INSERT INTO sqlite_sequence (name, seq) SELECT 'tasks', seq FROM sqlite_sequence WHERE name = 'tasks_old';
+
+DROP TABLE tasks_old;
+
+CREATE INDEX idx_assignee_id ON tasks(assignee_id);
+CREATE INDEX idx_assigner_id ON tasks(assigner_id);
+CREATE INDEX idx_priority ON tasks(priority);
+CREATE INDEX idx_due_at ON tasks(due_at);
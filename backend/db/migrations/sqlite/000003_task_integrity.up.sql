@@ -0,0 +1,40 @@
+-- Foreign keys and CHECK constraints for tasks. SQLite cannot add them to an
+-- existing table, so the table is rebuilt, repairing rows they would reject.
+ALTER TABLE tasks RENAME TO tasks_old;
+
+-- Deleting a user keeps their tasks, unassigned
+CREATE TABLE tasks (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    title TEXT NOT NULL,
+    description TEXT,
+    status TEXT NOT NULL CHECK (status IN ('TO_DO', 'IN_PROGRESS', 'DONE')),
+    assignee_id INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    assigner_id INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    priority INTEGER NOT NULL CHECK (priority BETWEEN 1 AND 3),
+    due_at TIMESTAMP,
+    original_estimate_minutes INTEGER,
+    remaining_estimate_minutes INTEGER,
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL
+);
+
+INSERT INTO tasks (id, title, description, status, assignee_id, assigner_id, priority, due_at,
+    original_estimate_minutes, remaining_estimate_minutes, created_at, updated_at)
+SELECT id, title, description,
+    CASE WHEN status IN ('TO_DO', 'IN_PROGRESS', 'DONE') THEN status ELSE 'TO_DO' END,
+    (SELECT id FROM users WHERE id = assignee_id),
+    (SELECT id FROM users WHERE id = assigner_id),
+    MAX(1, MIN(3, priority)),
+    due_at, original_estimate_minutes, remaining_estimate_minutes, created_at, updated_at
+FROM tasks_old;
+
+-- Keep IDs of deleted tasks from being reused
+DELETE FROM sqlite_sequence WHERE name = 'tasks';
+INSERT INTO sqlite_sequence (name, seq) SELECT 'tasks', seq FROM sqlite_sequence WHERE name = 'tasks_old';
+
+DROP TABLE tasks_old;
+
+CREATE INDEX idx_assignee_id ON tasks(assignee_id);
+CREATE INDEX idx_assigner_id ON tasks(assigner_id);
+CREATE INDEX idx_priority ON tasks(priority);
+CREATE INDEX idx_due_at ON tasks(due_at);
@@ -0,0 +1,23 @@
+package db
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/jmoiron/sqlx"
+	_ "modernc.org/sqlite"
+)
+
+// ConnectSQLite opens the SQLite database in file, creating it and its
+// directory if needed. Migrate it with NewMigrator(database, SQLite).
+func ConnectSQLite(file string) (*sqlx.DB, error) {
+	if err := os.MkdirAll(filepath.Dir(file), 0o755); err != nil {
+		return nil, err
+	}
+
+	// Transactions take the write lock when they begin, so a read followed by
+	// a write in one transaction cannot fail halfway, and writers wait for
+	// each other instead of failing with SQLITE_BUSY
+	dsn := "file:" + file + "?_pragma=foreign_keys(1)&_pragma=journal_mode(WAL)&_pragma=busy_timeout(5000)&_txlock=immediate"
+	return connect("sqlite", dsn, "sqlite")
+}
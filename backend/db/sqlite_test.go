@@ -0,0 +1,48 @@
+package db
+
+import (
+	"backend/tracing"
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+func TestConnectSQLite(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "data", "tasks.db")
+
+	database, err := ConnectSQLite(path)
+	require.NoError(t, err)
+	defer database.Close()
+
+	var foreignKeys int
+	require.NoError(t, database.Get(&foreignKeys, `PRAGMA foreign_keys`))
+	assert.Equal(t, 1, foreignKeys)
+	assert.FileExists(t, path)
+}
+
+func TestConnectSQLite_Traced(t *testing.T) {
+	exporter := tracing.InMemory()
+	database, err := ConnectSQLite(filepath.Join(t.TempDir(), "tasks.db"))
+	require.NoError(t, err)
+	defer database.Close()
+
+	// Statements outside of a trace are not traced
+	_, err = database.Exec(`SELECT 1`)
+	require.NoError(t, err)
+	assert.Empty(t, exporter.GetSpans())
+
+	ctx, parent := tracing.Tracer().Start(context.Background(), "request")
+	_, err = database.ExecContext(ctx, `SELECT 2`)
+	require.NoError(t, err)
+	parent.End()
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 2)
+	assert.Equal(t, parent.SpanContext().SpanID(), spans[0].Parent.SpanID())
+	assert.Contains(t, spans[0].Attributes, attribute.String("db.statement", "SELECT 2"))
+	assert.Contains(t, spans[0].Attributes, attribute.String("db.system", "sqlite"))
+}
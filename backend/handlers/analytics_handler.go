@@ -0,0 +1,123 @@
+package handlers
+
+import (
+	"backend/models"
+	"backend/services"
+	"fmt"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type AnalyticsHandler struct {
+	analyticsService services.AnalyticsServiceInterface
+}
+
+func NewAnalyticsHandler(analyticsService services.AnalyticsServiceInterface) *AnalyticsHandler {
+	return &AnalyticsHandler{analyticsService: analyticsService}
+}
+
+// GetCycleTime godoc
+// @Summary Lead and cycle time
+// @Description Lead time (created to done) and cycle time (first in progress to done) of tasks completed in [from, to). Only tasks you assigned or are assigned to are included. Defaults to the last 30 days.
+// @Tags analytics
+// @Produce json
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer {token}"
+// @Param from query string false "Start of the range (RFC 3339 or YYYY-MM-DD)"
+// @Param to query string false "End of the range (RFC 3339 or YYYY-MM-DD)"
+// @Param assignee_id query int false "Only tasks assigned to this user"
+// @Success 200 {object} services.CycleTimeReport
+// @Failure 400 {object} Problem
+// @Failure 500 {object} Problem
+// @Router /api/analytics/cycle-time [get]
+func (h *AnalyticsHandler) GetCycleTime(c *fiber.Ctx) error {
+	filter, err := parseAnalyticsFilter(c)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	report, err := h.analyticsService.CycleTime(c.UserContext(), filter)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(report)
+}
+
+// GetThroughput godoc
+// @Summary Weekly throughput
+// @Description Number of tasks completed per week (starting Monday) in [from, to). Defaults to the last 30 days.
+// @Tags analytics
+// @Produce json
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer {token}"
+// @Param from query string false "Start of the range (RFC 3339 or YYYY-MM-DD)"
+// @Param to query string false "End of the range (RFC 3339 or YYYY-MM-DD)"
+// @Param assignee_id query int false "Only tasks assigned to this user"
+// @Success 200 {object} services.ThroughputReport
+// @Failure 400 {object} Problem
+// @Failure 500 {object} Problem
+// @Router /api/analytics/throughput [get]
+func (h *AnalyticsHandler) GetThroughput(c *fiber.Ctx) error {
+	filter, err := parseAnalyticsFilter(c)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	report, err := h.analyticsService.Throughput(c.UserContext(), filter)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(report)
+}
+
+// GetCumulativeFlow godoc
+// @Summary Cumulative flow
+// @Description Number of tasks in each status at the end of every day in [from, to), up to a year. Defaults to the last 30 days.
+// @Tags analytics
+// @Produce json
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer {token}"
+// @Param from query string false "Start of the range (RFC 3339 or YYYY-MM-DD)"
+// @Param to query string false "End of the range (RFC 3339 or YYYY-MM-DD)"
+// @Param assignee_id query int false "Only tasks assigned to this user"
+// @Success 200 {object} services.CumulativeFlowReport
+// @Failure 400 {object} Problem
+// @Failure 500 {object} Problem
+// @Router /api/analytics/cumulative-flow [get]
+func (h *AnalyticsHandler) GetCumulativeFlow(c *fiber.Ctx) error {
+	filter, err := parseAnalyticsFilter(c)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	report, err := h.analyticsService.CumulativeFlow(c.UserContext(), filter)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(report)
+}
+
+func parseAnalyticsFilter(c *fiber.Ctx) (models.AnalyticsFilter, error) {
+	userID, err := parseUserID(c)
+	if err != nil {
+		return models.AnalyticsFilter{}, err
+	}
+	from, to, err := parseTimeRange(c)
+	if err != nil {
+		return models.AnalyticsFilter{}, err
+	}
+
+	filter := models.AnalyticsFilter{ViewerID: userID, From: from, To: to}
+	if raw := c.Query("assignee_id"); raw != "" {
+		assigneeID, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return models.AnalyticsFilter{}, fmt.Errorf("invalid assignee_id: %w", err)
+		}
+		filter.AssigneeID = &assigneeID
+	}
+	return filter, nil
+}
@@ -0,0 +1,181 @@
+package handlers
+
+import (
+	"backend/services"
+	"context"
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type AttachmentHandler struct {
+	attachmentService services.AttachmentServiceInterface
+}
+
+func NewAttachmentHandler(attachmentService services.AttachmentServiceInterface) *AttachmentHandler {
+	return &AttachmentHandler{attachmentService: attachmentService}
+}
+
+// UploadAttachment godoc
+// @Summary Upload an attachment
+// @Description Attach a file to a task. Size and content type are limited by server configuration.
+// @Tags attachments
+// @Accept multipart/form-data
+// @Produce json
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer {token}"
+// @Param id path int true "Task ID"
+// @Param file formData file true "File to attach"
+// @Success 201 {object} models.Attachment
+// @Failure 400 {object} Problem
+// @Failure 404 {object} Problem
+// @Failure 413 {object} Problem
+// @Failure 415 {object} Problem
+// @Failure 500 {object} Problem
+// @Router /api/task/{id}/attachments [post]
+func (h *AttachmentHandler) UploadAttachment(c *fiber.Ctx) error {
+	userID, err := parseUserID(c)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+	taskID, err := c.ParamsInt("id")
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "A multipart file field named \"file\" is required")
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+	defer file.Close()
+
+	attachment, err := h.attachmentService.Upload(c.UserContext(), userID, int64(taskID), fileHeader.Filename, fileHeader.Size, file)
+	if err != nil {
+		return uploadError(err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(attachment)
+}
+
+// ListAttachments godoc
+// @Summary List attachments
+// @Tags attachments
+// @Produce json
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer {token}"
+// @Param id path int true "Task ID"
+// @Success 200 {object} []models.Attachment
+// @Failure 404 {object} Problem
+// @Failure 500 {object} Problem
+// @Router /api/task/{id}/attachments [get]
+func (h *AttachmentHandler) ListAttachments(c *fiber.Ctx) error {
+	userID, err := parseUserID(c)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+	taskID, err := c.ParamsInt("id")
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	attachments, err := h.attachmentService.List(c.UserContext(), userID, int64(taskID))
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(attachments)
+}
+
+// DownloadAttachment godoc
+// @Summary Download an attachment
+// @Tags attachments
+// @Produce octet-stream
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer {token}"
+// @Param id path int true "Task ID"
+// @Param attachmentId path int true "Attachment ID"
+// @Success 200 {file} file
+// @Failure 404 {object} Problem
+// @Failure 500 {object} Problem
+// @Router /api/task/{id}/attachments/{attachmentId} [get]
+func (h *AttachmentHandler) DownloadAttachment(c *fiber.Ctx) error {
+	userID, err := parseUserID(c)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+	taskID, err := c.ParamsInt("id")
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+	attachmentID, err := c.ParamsInt("attachmentId")
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	// The content is read after the handler returns, when the request
+	// context is cancelled, so only the context's values are kept
+	ctx := context.WithoutCancel(c.UserContext())
+	attachment, content, err := h.attachmentService.Open(ctx, userID, int64(taskID), int64(attachmentID))
+	if err != nil {
+		return err
+	}
+
+	c.Attachment(attachment.Filename)
+	c.Set(fiber.HeaderContentType, attachment.ContentType)
+	c.Set("X-Content-Type-Options", "nosniff")
+	c.Set("X-Checksum-SHA256", attachment.ChecksumSHA256)
+
+	// fasthttp closes the stream once the body has been written
+	return c.Status(fiber.StatusOK).SendStream(content, int(attachment.SizeBytes))
+}
+
+// DeleteAttachment godoc
+// @Summary Delete an attachment
+// @Tags attachments
+// @Produce json
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer {token}"
+// @Param id path int true "Task ID"
+// @Param attachmentId path int true "Attachment ID"
+// @Success 200 {object} map[string]string
+// @Failure 404 {object} Problem
+// @Failure 500 {object} Problem
+// @Router /api/task/{id}/attachments/{attachmentId} [delete]
+func (h *AttachmentHandler) DeleteAttachment(c *fiber.Ctx) error {
+	userID, err := parseUserID(c)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+	taskID, err := c.ParamsInt("id")
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+	attachmentID, err := c.ParamsInt("attachmentId")
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	if err := h.attachmentService.Delete(c.UserContext(), userID, int64(taskID), int64(attachmentID)); err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Attachment deleted successfully",
+	})
+}
+
+// uploadError gives uploads over the size limit or of a type that is not
+// allowed their own statuses
+func uploadError(err error) error {
+	switch {
+	case errors.Is(err, services.ErrAttachmentTooLarge):
+		return fiber.NewError(fiber.StatusRequestEntityTooLarge, err.Error())
+	case errors.Is(err, services.ErrAttachmentTypeInvalid):
+		return fiber.NewError(fiber.StatusUnsupportedMediaType, err.Error())
+	}
+	return err
+}
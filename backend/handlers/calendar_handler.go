@@ -0,0 +1,123 @@
+package handlers
+
+import (
+	"backend/pkg/logging"
+	"backend/services"
+	"context"
+	"io"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type CalendarHandler struct {
+	calendarService services.CalendarServiceInterface
+}
+
+func NewCalendarHandler(calendarService services.CalendarServiceInterface) *CalendarHandler {
+	return &CalendarHandler{calendarService: calendarService}
+}
+
+// CreateCalendarToken godoc
+// @Summary Create a calendar feed URL
+// @Description Issue a secret URL for subscribing to your assigned tasks from a calendar app. Any previous URL stops working. The URL is only shown once.
+// @Tags calendar
+// @Produce json
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer {token}"
+// @Success 201 {object} map[string]string
+// @Failure 500 {object} Problem
+// @Router /api/calendar/token [post]
+func (h *CalendarHandler) CreateCalendarToken(c *fiber.Ctx) error {
+	userID, err := parseUserID(c)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	token, calendarToken, err := h.calendarService.CreateToken(c.UserContext(), userID)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"token":      token,
+		"url":        c.BaseURL() + "/api/calendar/feed/" + token + ".ics",
+		"created_at": calendarToken.CreatedAt,
+	})
+}
+
+// GetCalendarToken godoc
+// @Summary Calendar feed status
+// @Description Whether you have an active calendar feed URL and when it was created. The URL itself cannot be shown again.
+// @Tags calendar
+// @Produce json
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer {token}"
+// @Success 200 {object} models.CalendarToken
+// @Failure 404 {object} Problem
+// @Failure 500 {object} Problem
+// @Router /api/calendar/token [get]
+func (h *CalendarHandler) GetCalendarToken(c *fiber.Ctx) error {
+	userID, err := parseUserID(c)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	calendarToken, err := h.calendarService.GetToken(c.UserContext(), userID)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(calendarToken)
+}
+
+// RevokeCalendarToken godoc
+// @Summary Revoke the calendar feed URL
+// @Tags calendar
+// @Produce json
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer {token}"
+// @Success 200 {object} map[string]string
+// @Failure 404 {object} Problem
+// @Failure 500 {object} Problem
+// @Router /api/calendar/token [delete]
+func (h *CalendarHandler) RevokeCalendarToken(c *fiber.Ctx) error {
+	userID, err := parseUserID(c)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	if err := h.calendarService.RevokeToken(c.UserContext(), userID); err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Calendar feed revoked successfully",
+	})
+}
+
+// GetCalendarFeed godoc
+// @Summary Calendar feed
+// @Description iCalendar feed of the tasks assigned to the token's owner, as VTODOs plus a VEVENT at each due date. Authenticated by the secret token in the URL.
+// @Tags calendar
+// @Produce text/calendar
+// @Param token path string true "Calendar feed token"
+// @Success 200 {string} string "iCalendar feed"
+// @Failure 404 {object} Problem
+// @Router /api/calendar/feed/{token}.ics [get]
+func (h *CalendarHandler) GetCalendarFeed(c *fiber.Ctx) error {
+	userID, err := h.calendarService.Authenticate(c.UserContext(), c.Params("token"))
+	if err != nil {
+		return err
+	}
+
+	c.Set(fiber.HeaderContentType, "text/calendar; charset=utf-8")
+	c.Set(fiber.HeaderCacheControl, "private, max-age=300")
+
+	streamBody(c, func(ctx context.Context, w io.Writer) {
+		if err := h.calendarService.WriteFeed(ctx, userID, w); err != nil {
+			logging.FromContext(ctx).Error("calendar feed failed", "user_id", userID, "error", err)
+		}
+	})
+
+	return nil
+}
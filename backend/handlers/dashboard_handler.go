@@ -0,0 +1,39 @@
+package handlers
+
+import (
+	"backend/services"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type DashboardHandler struct {
+	dashboardService services.DashboardServiceInterface
+}
+
+func NewDashboardHandler(dashboardService services.DashboardServiceInterface) *DashboardHandler {
+	return &DashboardHandler{dashboardService: dashboardService}
+}
+
+// GetDashboard godoc
+// @Summary Personal dashboard
+// @Description Counts of tasks assigned to and created by the authenticated user by status and priority, overdue tasks, tasks due this week and recently updated tasks
+// @Tags dashboard
+// @Produce json
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer {token}"
+// @Success 200 {object} services.Dashboard
+// @Failure 500 {object} Problem
+// @Router /api/dashboard [get]
+func (h *DashboardHandler) GetDashboard(c *fiber.Ctx) error {
+	userID, err := parseUserID(c)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	dashboard, err := h.dashboardService.Get(c.UserContext(), userID)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dashboard)
+}
@@ -0,0 +1,82 @@
+package handlers
+
+import (
+	"backend/pkg/logging"
+	"backend/pkg/validate"
+	"backend/services"
+	"errors"
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Problem is an RFC 7807 problem details body
+// @Description Error response, served as application/problem+json
+type Problem struct {
+	Type     string `json:"type"`
+	Title    string `json:"title"`
+	Status   int    `json:"status"`
+	Detail   string `json:"detail,omitempty"`
+	Instance string `json:"instance,omitempty"`
+	// Errors lists the invalid fields of a request body
+	Errors validate.Errors `json:"errors,omitempty"`
+}
+
+// problemStatuses maps the kinds of service errors to HTTP statuses
+var problemStatuses = []struct {
+	kind   error
+	status int
+}{
+	{services.ErrNotFound, fiber.StatusNotFound},
+	{services.ErrConflict, fiber.StatusConflict},
+	{services.ErrValidation, fiber.StatusBadRequest},
+	{services.ErrForbidden, fiber.StatusForbidden},
+	{services.ErrUnauthenticated, fiber.StatusUnauthorized},
+}
+
+// ErrorHandler is the app's fiber.Config.ErrorHandler. It writes the errors
+// handlers return as problem details, with the status of the error's kind or
+// of a *fiber.Error. Invalid request bodies are a 400 listing the invalid
+// fields. Any other error is logged and reported as a 500 without
+// its text, which can come from the database driver.
+func ErrorHandler(c *fiber.Ctx, err error) error {
+	status, detail := problemStatus(err)
+	if detail == "" {
+		logging.FromContext(c.UserContext()).Error("request failed", "method", c.Method(), "path", c.Path(), "error", err)
+	}
+
+	problem := Problem{
+		Type:     "about:blank",
+		Title:    http.StatusText(status),
+		Status:   status,
+		Detail:   detail,
+		Instance: c.OriginalURL(),
+	}
+	errors.As(err, &problem.Errors)
+
+	body, err := c.App().Config().JSONEncoder(problem)
+	if err != nil {
+		return err
+	}
+	c.Set(fiber.HeaderContentType, "application/problem+json")
+	return c.Status(status).Send(body)
+}
+
+// problemStatus returns the status and detail to report err with. The
+// detail is empty for unexpected errors.
+func problemStatus(err error) (int, string) {
+	var fiberErr *fiber.Error
+	if errors.As(err, &fiberErr) {
+		return fiberErr.Code, fiberErr.Message
+	}
+	var fieldErrs validate.Errors
+	if errors.As(err, &fieldErrs) {
+		return fiber.StatusBadRequest, fieldErrs.Error()
+	}
+	for _, mapping := range problemStatuses {
+		if errors.Is(err, mapping.kind) {
+			return mapping.status, err.Error()
+		}
+	}
+	return fiber.StatusInternalServerError, ""
+}
@@ -0,0 +1,91 @@
+package handlers
+
+import (
+	"backend/services"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestErrorHandler(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected Problem
+	}{
+		{
+			name:     "Not found",
+			err:      services.ErrTaskNotFound,
+			expected: Problem{Title: "Not Found", Status: fiber.StatusNotFound, Detail: "task not found"},
+		},
+		{
+			name:     "Conflict",
+			err:      services.ErrEmailTaken,
+			expected: Problem{Title: "Conflict", Status: fiber.StatusConflict, Detail: "email already exists"},
+		},
+		{
+			name:     "Validation",
+			err:      fmt.Errorf("%w: task_ids is required", services.ErrInvalidBulkOperation),
+			expected: Problem{Title: "Bad Request", Status: fiber.StatusBadRequest, Detail: "invalid bulk operation: task_ids is required"},
+		},
+		{
+			name:     "Forbidden",
+			err:      services.ErrTaskForbidden,
+			expected: Problem{Title: "Forbidden", Status: fiber.StatusForbidden, Detail: "only the task's assigner can do this"},
+		},
+		{
+			name:     "Fiber error",
+			err:      fiber.NewError(fiber.StatusUnauthorized, "Invalid credentials"),
+			expected: Problem{Title: "Unauthorized", Status: fiber.StatusUnauthorized, Detail: "Invalid credentials"},
+		},
+		{
+			name:     "Upload too large",
+			err:      uploadError(services.ErrAttachmentTooLarge),
+			expected: Problem{Title: "Request Entity Too Large", Status: fiber.StatusRequestEntityTooLarge, Detail: "attachment is too large"},
+		},
+		{
+			name:     "Upload of a type that is not allowed",
+			err:      uploadError(services.ErrAttachmentTypeInvalid),
+			expected: Problem{Title: "Unsupported Media Type", Status: fiber.StatusUnsupportedMediaType, Detail: "attachment type is not allowed"},
+		},
+		{
+			name:     "Unknown error hides its text",
+			err:      fmt.Errorf("loading task: %w", sql.ErrNoRows),
+			expected: Problem{Title: "Internal Server Error", Status: fiber.StatusInternalServerError},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := fiber.New(fiber.Config{ErrorHandler: ErrorHandler})
+			app.Get("/api/task/:id", func(c *fiber.Ctx) error {
+				return tt.err
+			})
+
+			resp, err := app.Test(httptest.NewRequest("GET", "/api/task/7?x=1", nil))
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected.Status, resp.StatusCode)
+			assert.Equal(t, "application/problem+json", resp.Header.Get("Content-Type"))
+
+			var problem Problem
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&problem))
+			tt.expected.Type = "about:blank"
+			tt.expected.Instance = "/api/task/7?x=1"
+			assert.Equal(t, tt.expected, problem)
+		})
+	}
+}
+
+func TestErrorHandler_UnknownRoute(t *testing.T) {
+	app := fiber.New(fiber.Config{ErrorHandler: ErrorHandler})
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/missing", nil))
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+}
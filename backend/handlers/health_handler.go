@@ -0,0 +1,78 @@
+package handlers
+
+import (
+	"backend/pkg/logging"
+	"context"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// readinessTimeout bounds the checks of a readiness probe
+const readinessTimeout = 2 * time.Second
+
+// HealthCheck is a dependency the server needs to serve requests
+type HealthCheck struct {
+	Name  string
+	Check func(ctx context.Context) error
+}
+
+// HealthStatus is the result of a health probe
+// @Description Health of the server and of each dependency checked
+type HealthStatus struct {
+	// ok or unavailable
+	Status string `json:"status"`
+	// ok or failed, by dependency
+	Checks map[string]string `json:"checks,omitempty"`
+}
+
+type HealthHandler struct {
+	checks []HealthCheck
+}
+
+func NewHealthHandler(checks ...HealthCheck) *HealthHandler {
+	return &HealthHandler{checks: checks}
+}
+
+// Liveness godoc
+// @Summary Liveness probe
+// @Description Reports that the server is running. Dependencies are not checked, so an outage of the database does not get every instance restarted.
+// @Tags health
+// @Produce json
+// @Success 200 {object} HealthStatus
+// @Router /healthz [get]
+func (h *HealthHandler) Liveness(c *fiber.Ctx) error {
+	return c.JSON(HealthStatus{Status: "ok"})
+}
+
+// Readiness godoc
+// @Summary Readiness probe
+// @Description Reports whether the server can serve requests: the database answers and its schema has no pending or failed migrations.
+// @Tags health
+// @Produce json
+// @Success 200 {object} HealthStatus
+// @Failure 503 {object} HealthStatus
+// @Router /readyz [get]
+func (h *HealthHandler) Readiness(c *fiber.Ctx) error {
+	ctx, cancel := context.WithTimeout(c.UserContext(), readinessTimeout)
+	defer cancel()
+
+	result := HealthStatus{Status: "ok", Checks: make(map[string]string, len(h.checks))}
+	for _, check := range h.checks {
+		// Errors are logged rather than returned, they can describe the
+		// infrastructure
+		if err := check.Check(ctx); err != nil {
+			logging.FromContext(ctx).Warn("readiness check failed", "check", check.Name, "error", err)
+			result.Status = "unavailable"
+			result.Checks[check.Name] = "failed"
+			continue
+		}
+		result.Checks[check.Name] = "ok"
+	}
+
+	status := fiber.StatusOK
+	if result.Status != "ok" {
+		status = fiber.StatusServiceUnavailable
+	}
+	return c.Status(status).JSON(result)
+}
@@ -0,0 +1,48 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHealthHandler(t *testing.T) {
+	ok := HealthCheck{Name: "database", Check: func(context.Context) error { return nil }}
+	failing := HealthCheck{Name: "migrations", Check: func(context.Context) error { return errors.New("2 migrations pending") }}
+
+	tests := []struct {
+		name     string
+		path     string
+		checks   []HealthCheck
+		status   int
+		expected HealthStatus
+	}{
+		{"Live without checking", "/healthz", []HealthCheck{failing}, fiber.StatusOK, HealthStatus{Status: "ok"}},
+		{"Ready", "/readyz", []HealthCheck{ok}, fiber.StatusOK, HealthStatus{Status: "ok", Checks: map[string]string{"database": "ok"}}},
+		{"Not ready", "/readyz", []HealthCheck{ok, failing}, fiber.StatusServiceUnavailable,
+			HealthStatus{Status: "unavailable", Checks: map[string]string{"database": "ok", "migrations": "failed"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewHealthHandler(tt.checks...)
+			app := fiber.New()
+			app.Get("/healthz", handler.Liveness)
+			app.Get("/readyz", handler.Readiness)
+
+			resp, err := app.Test(httptest.NewRequest("GET", tt.path, nil))
+			require.NoError(t, err)
+			assert.Equal(t, tt.status, resp.StatusCode)
+
+			var body HealthStatus
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+			assert.Equal(t, tt.expected, body)
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package handlers
+
+import (
+	"backend/services"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type ImportHandler struct {
+	importService services.ImportServiceInterface
+}
+
+func NewImportHandler(importService services.ImportServiceInterface) *ImportHandler {
+	return &ImportHandler{importService: importService}
+}
+
+// ImportTasks godoc
+// @Summary Import tasks
+// @Description Create tasks from a CSV file or a JSON array of objects. Rows are validated first and problems are reported per row. In transactional mode nothing is created unless every row is valid; in best_effort mode the valid rows are created.
+// @Tags tasks
+// @Accept multipart/form-data
+// @Produce json
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer {token}"
+// @Param file formData file true "CSV or JSON file"
+// @Param format formData string false "csv or json, defaults to the file extension"
+// @Param mode formData string false "transactional (default) or best_effort"
+// @Param dry_run formData bool false "Validate without creating tasks"
+// @Param mapping formData string false "JSON object mapping task fields to source columns, e.g. {\"title\": \"Summary\", \"assignee\": \"Owner\"}"
+// @Success 200 {object} services.ImportResult
+// @Failure 400 {object} Problem
+// @Failure 422 {object} services.ImportResult
+// @Failure 500 {object} Problem
+// @Router /api/task/import [post]
+func (h *ImportHandler) ImportTasks(c *fiber.Ctx) error {
+	userID, err := parseUserID(c)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "A multipart file field named \"file\" is required")
+	}
+
+	options := services.ImportOptions{
+		Format: services.ImportFormat(c.FormValue("format")),
+		Mode:   services.ImportMode(c.FormValue("mode")),
+		DryRun: c.FormValue("dry_run") == "true",
+	}
+	if options.Format == "" {
+		options.Format = services.ImportFormat(strings.TrimPrefix(strings.ToLower(filepath.Ext(fileHeader.Filename)), "."))
+	}
+	if raw := c.FormValue("mapping"); raw != "" {
+		if err := json.Unmarshal([]byte(raw), &options.Mapping); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, "mapping must be a JSON object of field names to columns")
+		}
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+	defer file.Close()
+
+	result, err := h.importService.Import(c.UserContext(), userID, file, options)
+	if err != nil {
+		return err
+	}
+
+	// A transactional import with invalid rows created nothing
+	if !result.DryRun && result.Created < result.Total && result.Mode == services.ImportTransactional {
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(result)
+	}
+	return c.Status(fiber.StatusOK).JSON(result)
+}
@@ -0,0 +1,45 @@
+package handlers
+
+import (
+	"backend/services"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type MentionHandler struct {
+	mentionService services.MentionServiceInterface
+}
+
+func NewMentionHandler(mentionService services.MentionServiceInterface) *MentionHandler {
+	return &MentionHandler{mentionService: mentionService}
+}
+
+// GetTaskMentions godoc
+// @Summary List mentions in a task
+// @Description List users mentioned in a task. Flagged mentions name users who cannot see the task and were not notified.
+// @Tags tasks
+// @Produce json
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer {token}"
+// @Param id path int true "Task ID"
+// @Success 200 {object} []models.Mention
+// @Failure 404 {object} Problem
+// @Failure 500 {object} Problem
+// @Router /api/task/{id}/mentions [get]
+func (h *MentionHandler) GetTaskMentions(c *fiber.Ctx) error {
+	userID, err := parseUserID(c)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	mentions, err := h.mentionService.ListForTask(c.UserContext(), userID, int64(id))
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(mentions)
+}
@@ -0,0 +1,153 @@
+package handlers
+
+import (
+	"backend/models"
+	"backend/services"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type NotificationHandler struct {
+	notificationService services.NotificationServiceInterface
+}
+
+func NewNotificationHandler(notificationService services.NotificationServiceInterface) *NotificationHandler {
+	return &NotificationHandler{notificationService: notificationService}
+}
+
+// ListNotifications godoc
+// @Summary List notifications
+// @Description List the authenticated user's notifications, unread only unless all=true
+// @Tags notifications
+// @Produce json
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer {token}"
+// @Param all query bool false "Include notifications that were already read"
+// @Param limit query int false "Maximum number of notifications (default 50, max 100)"
+// @Success 200 {object} []models.Notification
+// @Failure 500 {object} Problem
+// @Router /api/notifications [get]
+func (h *NotificationHandler) ListNotifications(c *fiber.Ctx) error {
+	userID, err := parseUserID(c)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	notifications, err := h.notificationService.List(c.UserContext(), userID, !c.QueryBool("all", false), c.QueryInt("limit", 50))
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(notifications)
+}
+
+// MarkNotificationRead godoc
+// @Summary Mark a notification as read
+// @Tags notifications
+// @Produce json
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer {token}"
+// @Param id path int true "Notification ID"
+// @Success 200 {object} map[string]string
+// @Failure 404 {object} Problem
+// @Failure 500 {object} Problem
+// @Router /api/notifications/{id}/read [post]
+func (h *NotificationHandler) MarkNotificationRead(c *fiber.Ctx) error {
+	userID, err := parseUserID(c)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	if err := h.notificationService.MarkRead(c.UserContext(), userID, int64(id)); err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Notification marked as read",
+	})
+}
+
+// MarkAllNotificationsRead godoc
+// @Summary Mark all notifications as read
+// @Tags notifications
+// @Produce json
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer {token}"
+// @Success 200 {object} map[string]int64
+// @Failure 500 {object} Problem
+// @Router /api/notifications/read-all [post]
+func (h *NotificationHandler) MarkAllNotificationsRead(c *fiber.Ctx) error {
+	userID, err := parseUserID(c)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	updated, err := h.notificationService.MarkAllRead(c.UserContext(), userID)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"updated": updated,
+	})
+}
+
+// GetNotificationPreferences godoc
+// @Summary Get notification preferences
+// @Description Whether each notification type creates inbox entries for the authenticated user
+// @Tags notifications
+// @Produce json
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer {token}"
+// @Success 200 {object} map[string]bool
+// @Failure 500 {object} Problem
+// @Router /api/notifications/preferences [get]
+func (h *NotificationHandler) GetNotificationPreferences(c *fiber.Ctx) error {
+	userID, err := parseUserID(c)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	preferences, err := h.notificationService.GetPreferences(c.UserContext(), userID)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(preferences)
+}
+
+// UpdateNotificationPreferences godoc
+// @Summary Update notification preferences
+// @Description Enable or disable notification types. Types left out keep their current setting.
+// @Tags notifications
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer {token}"
+// @Param preferences body map[string]bool true "Notification type to enabled flag"
+// @Success 200 {object} map[string]bool
+// @Failure 400 {object} Problem
+// @Failure 500 {object} Problem
+// @Router /api/notifications/preferences [put]
+func (h *NotificationHandler) UpdateNotificationPreferences(c *fiber.Ctx) error {
+	userID, err := parseUserID(c)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	var preferences map[models.NotificationType]bool
+	if err := c.BodyParser(&preferences); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	updated, err := h.notificationService.UpdatePreferences(c.UserContext(), userID, preferences)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(updated)
+}
@@ -0,0 +1,17 @@
+package handlers
+
+import (
+	"backend/pkg/validate"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// parseBody parses the request body into out and validates it against its
+// validate tags. Invalid fields are returned as validate.Errors, which
+// ErrorHandler lists in the problem details.
+func parseBody(c *fiber.Ctx, out interface{}) error {
+	if err := c.BodyParser(out); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	}
+	return validate.Struct(out)
+}
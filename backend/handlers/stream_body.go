@@ -0,0 +1,51 @@
+package handlers
+
+import (
+	"backend/pkg/logging"
+	"bufio"
+	"context"
+	"io"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// streamBody sends the response body from write, which runs after the
+// handler has returned and so after the request context has ended. The
+// context write gets keeps the request's logger and is cancelled when the
+// server shuts down or a write to the client fails, so the work behind an
+// abandoned download stops.
+func streamBody(c *fiber.Ctx, write func(ctx context.Context, w io.Writer)) {
+	logger := logging.FromContext(c.UserContext())
+	// Closed by Server.Shutdown. The fasthttp context itself must not be
+	// kept, it is reused once the client goes away.
+	shutdown := c.Context().Done()
+
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		ctx, cancel := context.WithCancel(logging.WithLogger(context.Background(), logger))
+		defer cancel()
+		go func() {
+			select {
+			case <-shutdown:
+				cancel()
+			case <-ctx.Done():
+			}
+		}()
+
+		write(ctx, &cancelOnError{w: w, cancel: cancel})
+		w.Flush()
+	})
+}
+
+// cancelOnError cancels a context on the first failed write
+type cancelOnError struct {
+	w      io.Writer
+	cancel context.CancelFunc
+}
+
+func (c *cancelOnError) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	if err != nil {
+		c.cancel()
+	}
+	return n, err
+}
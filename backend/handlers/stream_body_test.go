@@ -0,0 +1,83 @@
+package handlers
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// serveStream serves write as a streamed body on a real connection, which
+// app.Test does not use, and returns the app and its URL
+func serveStream(t *testing.T, write func(ctx context.Context, w io.Writer)) (*fiber.App, string) {
+	app := fiber.New(fiber.Config{DisableStartupMessage: true})
+	app.Get("/stream", func(c *fiber.Ctx) error {
+		streamBody(c, write)
+		return nil
+	})
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go app.Listener(ln)
+	t.Cleanup(func() { app.ShutdownWithTimeout(time.Second) })
+	return app, "http://" + ln.Addr().String() + "/stream"
+}
+
+func TestStreamBody_CancelledOnShutdown(t *testing.T) {
+	done := make(chan error, 1)
+	app, url := serveStream(t, func(ctx context.Context, w io.Writer) {
+		// More than the writer buffers, so the client sees it
+		io.WriteString(w, "started\n"+strings.Repeat(" ", 64<<10))
+		<-ctx.Done()
+		done <- ctx.Err()
+	})
+
+	resp, err := http.Get(url)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	line, err := bufio.NewReader(resp.Body).ReadString('\n')
+	require.NoError(t, err)
+	assert.Equal(t, "started\n", line)
+
+	go app.ShutdownWithTimeout(5 * time.Second)
+	select {
+	case err := <-done:
+		assert.ErrorIs(t, err, context.Canceled)
+	case <-time.After(5 * time.Second):
+		t.Fatal("the stream was not cancelled on shutdown")
+	}
+}
+
+func TestStreamBody_CancelledWhenClientGoesAway(t *testing.T) {
+	done := make(chan error, 1)
+	_, url := serveStream(t, func(ctx context.Context, w io.Writer) {
+		chunk := strings.Repeat("x", 4096)
+		for ctx.Err() == nil {
+			if _, err := io.WriteString(w, chunk); err != nil {
+				break
+			}
+		}
+		done <- ctx.Err()
+	})
+
+	resp, err := http.Get(url)
+	require.NoError(t, err)
+	_, err = io.ReadFull(resp.Body, make([]byte, 4096))
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	select {
+	case err := <-done:
+		assert.ErrorIs(t, err, context.Canceled)
+	case <-time.After(5 * time.Second):
+		t.Fatal("the stream was not cancelled when the client went away")
+	}
+}
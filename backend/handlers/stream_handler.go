@@ -0,0 +1,78 @@
+package handlers
+
+import (
+	"backend/realtime"
+	"bufio"
+	"fmt"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// heartbeatInterval keeps idle connections open through proxies
+const heartbeatInterval = 25 * time.Second
+
+type StreamHandler struct {
+	hub *realtime.Hub
+}
+
+func NewStreamHandler(hub *realtime.Hub) *StreamHandler {
+	return &StreamHandler{hub: hub}
+}
+
+// StreamTasks godoc
+// @Summary Stream task events
+// @Description Server-Sent Events stream of task.created, task.updated and task.deleted events for tasks the caller assigned or is assigned to. Browsers using EventSource may pass the token as the access_token query parameter.
+// @Tags tasks
+// @Produce text/event-stream
+// @Security BearerAuth
+// @Param Authorization header string false "Bearer {token}"
+// @Param access_token query string false "JWT, for clients that cannot set headers"
+// @Success 200 {string} string "event stream"
+// @Failure 401 {object} Problem
+// @Router /api/task/stream [get]
+func (h *StreamHandler) StreamTasks(c *fiber.Ctx) error {
+	userID, err := parseUserID(c)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	c.Set(fiber.HeaderContentType, "text/event-stream")
+	c.Set(fiber.HeaderCacheControl, "no-cache")
+	c.Set(fiber.HeaderConnection, "keep-alive")
+	c.Set("X-Accel-Buffering", "no")
+
+	messages, unsubscribe := h.hub.Subscribe(userID)
+
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		defer unsubscribe()
+
+		heartbeat := time.NewTicker(heartbeatInterval)
+		defer heartbeat.Stop()
+
+		// Tell the client the stream is live before the first event
+		fmt.Fprint(w, ": connected\n\n")
+		if err := w.Flush(); err != nil {
+			return
+		}
+
+		for {
+			select {
+			case msg, ok := <-messages:
+				if !ok {
+					return
+				}
+				fmt.Fprintf(w, "event: %s\ndata: %s\n\n", msg.Event, msg.Data)
+			case <-heartbeat.C:
+				fmt.Fprint(w, ": ping\n\n")
+			}
+
+			// Flush fails once the client has gone away
+			if err := w.Flush(); err != nil {
+				return
+			}
+		}
+	})
+
+	return nil
+}
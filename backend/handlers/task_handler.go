@@ -29,78 +29,68 @@ type TaskHandlerInterface interface {
 // @Produce json
 // @Security BearerAuth
 // @Param Authorization header string true "Bearer {token}"
-// @Param task body models.Task true "Task object"
+// @Param task body models.TaskRequest true "Task details"
 // @Success 201 {object} models.Task
-// @Failure 400 {object} map[string]string "Bad Request"
-// @Failure 401 {object} map[string]string "Unauthorized"
-// @Failure 500 {object} map[string]string "Internal Server Error"
+// @Failure 400 {object} Problem "Bad Request, with the invalid fields, or an unknown assignee"
+// @Failure 401 {object} Problem "Unauthorized"
+// @Failure 500 {object} Problem "Internal Server Error"
 // @Router /api/task [post]
 func (h *TaskHandler) CreateTask(c *fiber.Ctx) error {
 	// Decrypt the jwt token
 	// Get userID directly from context
 	parsedID, err := parseUserID(c)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": err.Error(),
-		})
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
 	}
-	var task models.Task
-	if err := c.BodyParser(&task); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": err.Error(),
-		})
-	}
-
-	if !task.Status.IsValid() {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Invalid status. Must be TO_DO, IN_PROGRESS, or DONE",
-		})
+	var req models.TaskRequest
+	if err := parseBody(c, &req); err != nil {
+		return err
 	}
+	task := req.Task()
 	task.AssignerID = &parsedID
 
-	createdTask, err := h.taskService.Create(&task)
+	createdTask, err := h.taskService.Create(c.UserContext(), task)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": err.Error(),
-		})
+		return err
 	}
 
 	return c.Status(fiber.StatusCreated).JSON(createdTask)
 }
 
 // @Summary Update a task
-// @Description Update a task with the provided details
+// @Description Replace the details of a task
 // @Tags tasks
 // @Accept json
 // @Produce json
 // @Security BearerAuth
 // @Param Authorization header string true "Bearer {token}"
 // @Param id path int true "Task ID"
-// @Param task body models.Task true "Task object"
+// @Param task body models.TaskRequest true "Task details"
 // @Success 200 {object} models.Task
-// @Failure 400 {object} map[string]string
-// @Failure 500 {object} map[string]string
+// @Failure 400 {object} Problem
+// @Failure 404 {object} Problem
+// @Failure 500 {object} Problem
 // @Router /api/task/{id} [put]
 func (h *TaskHandler) UpdateTask(c *fiber.Ctx) error {
-	parsedID, err := parseUserID(c)
+	userID, err := parseUserID(c)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+	id, err := c.ParamsInt("id")
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": err.Error(),
-		})
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
 	}
-	var task models.Task
-	if err := c.BodyParser(&task); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": err.Error(),
-		})
+	var req models.TaskRequest
+	if err := parseBody(c, &req); err != nil {
+		return err
 	}
 
-	task.AssignerID = &parsedID
-	updatedTask, err := h.taskService.Update(&task)
+	task := req.Task()
+	task.ID = int64(id)
+	task.AssignerID = &userID
+	updatedTask, err := h.taskService.Update(c.UserContext(), task)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": err.Error(),
-		})
+		return err
 	}
 
 	return c.Status(fiber.StatusOK).JSON(updatedTask)
@@ -115,28 +105,23 @@ func (h *TaskHandler) UpdateTask(c *fiber.Ctx) error {
 // @Param Authorization header string true "Bearer {token}"
 // @Param id path int true "Task ID"
 // @Success 200 {object} models.Task
-// @Failure 400 {object} map[string]string
-// @Failure 500 {object} map[string]string
+// @Failure 400 {object} Problem
+// @Failure 404 {object} Problem
+// @Failure 500 {object} Problem
 // @Router /api/task/{id} [get]
 func (h *TaskHandler) GetTask(c *fiber.Ctx) error {
 	_, err := parseUserID(c)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": err.Error(),
-		})
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
 	}
 	id, err := c.ParamsInt("id")
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": err.Error(),
-		})
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
 	}
 
-	task, err := h.taskService.Get(int64(id))
+	task, err := h.taskService.Get(c.UserContext(), int64(id))
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": err.Error(),
-		})
+		return err
 	}
 
 	return c.Status(fiber.StatusOK).JSON(task)
@@ -151,28 +136,23 @@ func (h *TaskHandler) GetTask(c *fiber.Ctx) error {
 // @Param Authorization header string true "Bearer {token}"
 // @Param id path int true "Task ID"
 // @Success 200 {object} map[string]string
-// @Failure 400 {object} map[string]string
-// @Failure 500 {object} map[string]string
+// @Failure 400 {object} Problem
+// @Failure 404 {object} Problem
+// @Failure 500 {object} Problem
 // @Router /api/task/{id} [delete]
 func (h *TaskHandler) DeleteTask(c *fiber.Ctx) error {
 	_, err := parseUserID(c)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": err.Error(),
-		})
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
 	}
 	id, err := c.ParamsInt("id")
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": err.Error(),
-		})
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
 	}
 
-	err = h.taskService.Delete(int64(id))
+	err = h.taskService.Delete(c.UserContext(), int64(id))
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": err.Error(),
-		})
+		return err
 	}
 
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
@@ -189,35 +169,60 @@ func (h *TaskHandler) DeleteTask(c *fiber.Ctx) error {
 // @Security BearerAuth
 // @Param Authorization header string true "Bearer {token}"
 // @Success 200 {object} []models.Task
-// @Failure 400 {object} map[string]string
-// @Failure 500 {object} map[string]string
+// @Failure 400 {object} Problem
+// @Failure 500 {object} Problem
 // @Router /api/task/assigner [get]
 func (h *TaskHandler) GetTasksByAssignerID(c *fiber.Ctx) error {
 	userIDInterface := c.Locals("userId")
 	if userIDInterface == nil {
-		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-			"error": "User ID not found in context",
-		})
+		return fiber.NewError(fiber.StatusUnauthorized, "User ID not found in context")
 	}
 
 	userID, ok := userIDInterface.(int64)
 	if !ok {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": "Invalid user ID format",
-		})
+		return fiber.NewError(fiber.StatusInternalServerError, "Invalid user ID format")
 	}
 
-	tasks, err := h.taskService.GetTasksByAssignerID(userID)
+	tasks, err := h.taskService.GetTasksByAssignerID(c.UserContext(), userID)
 	if err != nil {
-		fmt.Printf("Error getting tasks: %v\n", err)
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": err.Error(),
-		})
+		return err
 	}
 
 	return c.Status(fiber.StatusOK).JSON(tasks)
 }
 
+// BulkUpdateTasks godoc
+// @Summary Change or delete several tasks
+// @Description Apply a status change, reassignment, priority change or delete to a list of tasks in one transaction. Tasks you cannot see or change are skipped and reported per item. Status and priority can be changed by a task's assigner or assignee; reassigning and deleting need its assigner.
+// @Tags tasks
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer {token}"
+// @Param operation body models.BulkOperation true "Bulk operation"
+// @Success 200 {object} models.BulkResult
+// @Failure 400 {object} Problem
+// @Failure 500 {object} Problem
+// @Router /api/task/bulk [post]
+func (h *TaskHandler) BulkUpdateTasks(c *fiber.Ctx) error {
+	userID, err := parseUserID(c)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	var op models.BulkOperation
+	if err := c.BodyParser(&op); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	result, err := h.taskService.Bulk(c.UserContext(), userID, op)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(result)
+}
+
 func parseUserID(c *fiber.Ctx) (int64, error) {
 	userID := fmt.Sprintf("%d", c.Locals("userId"))
 	var parsedID int64
@@ -0,0 +1,109 @@
+package handlers
+
+import (
+	"backend/models"
+	"backend/services/mocks"
+	"encoding/json"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTaskTestApp(t *testing.T) (*fiber.App, *mocks.MockTaskServiceInterface) {
+	ctrl := gomock.NewController(t)
+	mockService := mocks.NewMockTaskServiceInterface(ctrl)
+	handler := NewTaskHandler(mockService)
+
+	app := fiber.New(fiber.Config{ErrorHandler: ErrorHandler})
+	app.Use(func(c *fiber.Ctx) error {
+		c.Locals("userId", int64(1))
+		return c.Next()
+	})
+	app.Post("/api/task", handler.CreateTask)
+	app.Put("/api/task/:id", handler.UpdateTask)
+	return app, mockService
+}
+
+func TestTaskHandler_CreateTask(t *testing.T) {
+	t.Run("Ignores fields clients cannot set", func(t *testing.T) {
+		app, mockService := newTaskTestApp(t)
+		me := int64(1)
+		mockService.EXPECT().
+			Create(gomock.Any(), &models.Task{Title: "Write docs", Status: models.StatusToDo, Priority: 2, AssignerID: &me}).
+			DoAndReturn(func(_ interface{}, task *models.Task) (*models.Task, error) {
+				task.ID = 7
+				return task, nil
+			})
+
+		req := httptest.NewRequest("POST", "/api/task", strings.NewReader(
+			`{"id":99,"title":"Write docs","status":"TO_DO","priority":2,"assigner_id":5,"created_at":"2020-01-01T00:00:00Z"}`))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req)
+		require.NoError(t, err)
+		assert.Equal(t, fiber.StatusCreated, resp.StatusCode)
+	})
+
+	t.Run("Lists invalid fields", func(t *testing.T) {
+		app, _ := newTaskTestApp(t)
+
+		req := httptest.NewRequest("POST", "/api/task", strings.NewReader(
+			`{"title":"  ","status":"BLOCKED","priority":4,"original_estimate_minutes":-5}`))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req)
+		require.NoError(t, err)
+		assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+
+		var problem Problem
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&problem))
+		assert.Equal(t, []string{"title", "status", "priority", "original_estimate_minutes"}, fieldNames(problem))
+		assert.Equal(t, "must be at most 3", problem.Errors[2].Message)
+	})
+}
+
+func TestTaskHandler_UpdateTask(t *testing.T) {
+	t.Run("Takes the ID from the path", func(t *testing.T) {
+		app, mockService := newTaskTestApp(t)
+		me := int64(1)
+		mockService.EXPECT().
+			Update(gomock.Any(), &models.Task{ID: 7, Title: "Write docs", Status: models.StatusDone, Priority: 1, AssignerID: &me}).
+			DoAndReturn(func(_ interface{}, task *models.Task) (*models.Task, error) {
+				return task, nil
+			})
+
+		req := httptest.NewRequest("PUT", "/api/task/7", strings.NewReader(
+			`{"id":8,"title":"Write docs","status":"DONE","priority":1,"assigner_id":5}`))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req)
+		require.NoError(t, err)
+		body, _ := io.ReadAll(resp.Body)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode, string(body))
+	})
+
+	t.Run("Validates like create", func(t *testing.T) {
+		app, _ := newTaskTestApp(t)
+
+		req := httptest.NewRequest("PUT", "/api/task/7", strings.NewReader(`{"status":"DONE","priority":0}`))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req)
+		require.NoError(t, err)
+		assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+
+		var problem Problem
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&problem))
+		assert.Equal(t, []string{"title", "priority"}, fieldNames(problem))
+	})
+}
+
+func fieldNames(problem Problem) []string {
+	var names []string
+	for _, fieldErr := range problem.Errors {
+		names = append(names, fieldErr.Field)
+	}
+	return names
+}
@@ -0,0 +1,268 @@
+package handlers
+
+import (
+	"backend/services"
+	"fmt"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// defaultTotalsRange is used when a totals request has no from parameter
+const defaultTotalsRange = 30 * 24 * time.Hour
+
+type TimeTrackingHandler struct {
+	timeTrackingService services.TimeTrackingServiceInterface
+}
+
+func NewTimeTrackingHandler(timeTrackingService services.TimeTrackingServiceInterface) *TimeTrackingHandler {
+	return &TimeTrackingHandler{timeTrackingService: timeTrackingService}
+}
+
+type startTimerRequest struct {
+	Note string `json:"note"`
+}
+
+type logWorkRequest struct {
+	DurationMinutes int        `json:"duration_minutes"`
+	StartedAt       *time.Time `json:"started_at"`
+	Note            string     `json:"note"`
+}
+
+// StartTimer godoc
+// @Summary Start a timer
+// @Description Start tracking time on a task. Only one timer can run per user.
+// @Tags time tracking
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer {token}"
+// @Param id path int true "Task ID"
+// @Param timer body startTimerRequest false "Optional note"
+// @Success 201 {object} models.WorkLog
+// @Failure 404 {object} Problem
+// @Failure 409 {object} Problem
+// @Failure 500 {object} Problem
+// @Router /api/task/{id}/timer/start [post]
+func (h *TimeTrackingHandler) StartTimer(c *fiber.Ctx) error {
+	userID, err := parseUserID(c)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+	taskID, err := c.ParamsInt("id")
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	var req startTimerRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+		}
+	}
+
+	workLog, err := h.timeTrackingService.StartTimer(c.UserContext(), userID, int64(taskID), req.Note)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(workLog)
+}
+
+// StopTimer godoc
+// @Summary Stop a timer
+// @Tags time tracking
+// @Produce json
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer {token}"
+// @Param id path int true "Task ID"
+// @Success 200 {object} models.WorkLog
+// @Failure 409 {object} Problem
+// @Failure 500 {object} Problem
+// @Router /api/task/{id}/timer/stop [post]
+func (h *TimeTrackingHandler) StopTimer(c *fiber.Ctx) error {
+	userID, err := parseUserID(c)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+	taskID, err := c.ParamsInt("id")
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	workLog, err := h.timeTrackingService.StopTimer(c.UserContext(), userID, int64(taskID))
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(workLog)
+}
+
+// LogWork godoc
+// @Summary Log work
+// @Description Record time spent on a task without a timer
+// @Tags time tracking
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer {token}"
+// @Param id path int true "Task ID"
+// @Param worklog body logWorkRequest true "Work log"
+// @Success 201 {object} models.WorkLog
+// @Failure 400 {object} Problem
+// @Failure 404 {object} Problem
+// @Failure 500 {object} Problem
+// @Router /api/task/{id}/worklogs [post]
+func (h *TimeTrackingHandler) LogWork(c *fiber.Ctx) error {
+	userID, err := parseUserID(c)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+	taskID, err := c.ParamsInt("id")
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	var req logWorkRequest
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	duration := time.Duration(req.DurationMinutes) * time.Minute
+	workLog, err := h.timeTrackingService.LogWork(c.UserContext(), userID, int64(taskID), req.StartedAt, duration, req.Note)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(workLog)
+}
+
+// ListWorkLogs godoc
+// @Summary List work logs
+// @Tags time tracking
+// @Produce json
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer {token}"
+// @Param id path int true "Task ID"
+// @Success 200 {object} []models.WorkLog
+// @Failure 404 {object} Problem
+// @Failure 500 {object} Problem
+// @Router /api/task/{id}/worklogs [get]
+func (h *TimeTrackingHandler) ListWorkLogs(c *fiber.Ctx) error {
+	userID, err := parseUserID(c)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+	taskID, err := c.ParamsInt("id")
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	workLogs, err := h.timeTrackingService.ListWorkLogs(c.UserContext(), userID, int64(taskID))
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(workLogs)
+}
+
+// GetTaskTimeTotals godoc
+// @Summary Time logged on a task
+// @Description Total finished work per user on a task, for work started in [from, to). Defaults to the last 30 days.
+// @Tags time tracking
+// @Produce json
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer {token}"
+// @Param id path int true "Task ID"
+// @Param from query string false "Start of the range (RFC 3339 or YYYY-MM-DD)"
+// @Param to query string false "End of the range (RFC 3339 or YYYY-MM-DD)"
+// @Success 200 {object} services.TaskTimeReport
+// @Failure 400 {object} Problem
+// @Failure 404 {object} Problem
+// @Failure 500 {object} Problem
+// @Router /api/task/{id}/time-totals [get]
+func (h *TimeTrackingHandler) GetTaskTimeTotals(c *fiber.Ctx) error {
+	userID, err := parseUserID(c)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+	taskID, err := c.ParamsInt("id")
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+	from, to, err := parseTimeRange(c)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	report, err := h.timeTrackingService.TaskTotals(c.UserContext(), userID, int64(taskID), from, to)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(report)
+}
+
+// GetUserTimeTotals godoc
+// @Summary Time logged by a user
+// @Description Total finished work per task for a user, for work started in [from, to). Only tasks the caller assigned or is assigned to are included. Defaults to the caller and the last 30 days.
+// @Tags time tracking
+// @Produce json
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer {token}"
+// @Param user_id query int false "User ID"
+// @Param from query string false "Start of the range (RFC 3339 or YYYY-MM-DD)"
+// @Param to query string false "End of the range (RFC 3339 or YYYY-MM-DD)"
+// @Success 200 {object} services.UserTimeReport
+// @Failure 400 {object} Problem
+// @Failure 500 {object} Problem
+// @Router /api/time-totals [get]
+func (h *TimeTrackingHandler) GetUserTimeTotals(c *fiber.Ctx) error {
+	viewerID, err := parseUserID(c)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+	from, to, err := parseTimeRange(c)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	userID := int64(c.QueryInt("user_id", int(viewerID)))
+	report, err := h.timeTrackingService.UserTotals(c.UserContext(), viewerID, userID, from, to)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(report)
+}
+
+// parseTimeRange reads the from and to query parameters. to defaults to now
+// and from to defaultTotalsRange before to.
+func parseTimeRange(c *fiber.Ctx) (time.Time, time.Time, error) {
+	to := time.Now().UTC()
+	if raw := c.Query("to"); raw != "" {
+		parsed, err := parseTimeParam(raw)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid to: %w", err)
+		}
+		to = parsed
+	}
+
+	from := to.Add(-defaultTotalsRange)
+	if raw := c.Query("from"); raw != "" {
+		parsed, err := parseTimeParam(raw)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid from: %w", err)
+		}
+		from = parsed
+	}
+
+	return from, to, nil
+}
+
+func parseTimeParam(raw string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, raw); err == nil {
+		return t.UTC(), nil
+	}
+	return time.Parse(time.DateOnly, raw)
+}
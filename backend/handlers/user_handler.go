@@ -3,7 +3,6 @@ package handlers
 import (
 	"backend/models"
 	"backend/services"
-	"fmt"
 
 	"github.com/gofiber/fiber/v2"
 )
@@ -29,34 +28,25 @@ type UserHandlerInterface interface {
 // @Tags users
 // @Accept json
 // @Produce json
-// @Param user body models.User true "User object"
+// @Param user body models.RegisterRequest true "User details"
 // @Success 200 {object} models.User
-// @Failure 400 {object} map[string]string
-// @Failure 500 {object} map[string]string
+// @Failure 400 {object} Problem
+// @Failure 409 {object} Problem "Username or email already exists"
+// @Failure 500 {object} Problem
 // @Router /api/auth/register [post]
 func (h *UserHandler) Register(c *fiber.Ctx) error {
-	var user models.User
-	if err := c.BodyParser(&user); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Invalid request body",
-		})
+	var req models.RegisterRequest
+	if err := parseBody(c, &req); err != nil {
+		return err
 	}
 
-	// Validate required fields
-	if user.Username == "" || user.Email == "" || user.Password == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Invalid request body",
-		})
-	}
-
-	if err := h.userService.Register(&user); err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": "Failed to register user, " + err.Error(),
-		})
+	user := req.User()
+	if err := h.userService.Register(c.UserContext(), user); err != nil {
+		return err
 	}
 
 	// Clear password before sending response
-	responseUser := user
+	responseUser := *user
 	responseUser.Password = ""
 
 	return c.Status(fiber.StatusCreated).JSON(responseUser)
@@ -68,34 +58,21 @@ func (h *UserHandler) Register(c *fiber.Ctx) error {
 // @Tags users
 // @Accept json
 // @Produce json
-// @Param credentials body models.User true "User credentials"
+// @Param credentials body models.LoginRequest true "User credentials"
 // @Success 200 {object} models.User
-// @Failure 400 {object} map[string]string
-// @Failure 500 {object} map[string]string
+// @Failure 400 {object} Problem
+// @Failure 401 {object} Problem
+// @Failure 500 {object} Problem
 // @Router /api/auth/login [post]
 func (h *UserHandler) Login(c *fiber.Ctx) error {
-	var credentials struct {
-		Email    string `json:"email"`
-		Password string `json:"password"`
-	}
-
-	if err := c.BodyParser(&credentials); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Invalid request body",
-		})
-	}
-
-	if credentials.Email == "" || credentials.Password == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Invalid request body",
-		})
+	var credentials models.LoginRequest
+	if err := parseBody(c, &credentials); err != nil {
+		return err
 	}
 
-	token, err := h.userService.Login(credentials.Email, credentials.Password)
+	token, err := h.userService.Login(c.UserContext(), credentials.Email, credentials.Password)
 	if err != nil {
-		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-			"error": "Invalid credentials",
-		})
+		return err
 	}
 
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
@@ -112,31 +89,24 @@ func (h *UserHandler) Login(c *fiber.Ctx) error {
 // @Security BearerAuth
 // @Param Authorization header string true "Bearer {token}"
 // @Success 200 {object} models.User
-// @Failure 401 {object} map[string]string "Unauthorized"
-// @Failure 500 {object} map[string]string "Internal Server Error"
+// @Failure 401 {object} Problem "Unauthorized"
+// @Failure 404 {object} Problem "Not Found"
+// @Failure 500 {object} Problem "Internal Server Error"
 // @Router /api/auth/profile [get]
 func (h *UserHandler) GetProfile(c *fiber.Ctx) error {
-	// Debug print all locals
 	userIDInterface := c.Locals("userId")
 	if userIDInterface == nil {
-		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-			"error": "User ID not found in context",
-		})
+		return fiber.NewError(fiber.StatusUnauthorized, "User ID not found in context")
 	}
 
 	userID, ok := userIDInterface.(int64)
 	if !ok {
-		fmt.Printf("Type of userIDInterface: %T\n", userIDInterface)
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": "Invalid user ID format",
-		})
+		return fiber.NewError(fiber.StatusInternalServerError, "Invalid user ID format")
 	}
 
-	user, err := h.userService.GetUserById(userID)
+	user, err := h.userService.GetUserById(c.UserContext(), userID)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": err.Error(),
-		})
+		return err
 	}
 
 	return c.JSON(user)
@@ -2,8 +2,10 @@ package handlers
 
 import (
 	"backend/models"
+	"backend/services"
 	"backend/services/mocks"
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"io/ioutil"
@@ -33,8 +35,8 @@ func TestUserHandler_Register(t *testing.T) {
 			},
 			setupMocks: func(ms *mocks.MockUserServiceInterface) {
 				ms.EXPECT().
-					Register(gomock.Any()).
-					DoAndReturn(func(user *models.User) error {
+					Register(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, user *models.User) error {
 						// Simulate the service saving the user
 						user.ID = 0
 						user.CreatedAt = time.Time{}
@@ -50,7 +52,7 @@ func TestUserHandler_Register(t *testing.T) {
 			requestBody:    "invalid json",
 			setupMocks:     func(ms *mocks.MockUserServiceInterface) {},
 			expectedStatus: fiber.StatusBadRequest,
-			expectedBody:   `{"error":"Invalid request body"}`,
+			expectedBody:   `{"type":"about:blank","title":"Bad Request","status":400,"detail":"Invalid request body","instance":"/api/auth/register"}`,
 		},
 		{
 			name: "Missing required fields",
@@ -60,7 +62,25 @@ func TestUserHandler_Register(t *testing.T) {
 			},
 			setupMocks:     func(ms *mocks.MockUserServiceInterface) {},
 			expectedStatus: fiber.StatusBadRequest,
-			expectedBody:   `{"error":"Invalid request body"}`,
+			expectedBody: `{"type":"about:blank","title":"Bad Request","status":400,"detail":"email is required; password is required","instance":"/api/auth/register",
+				"errors":[{"field":"email","message":"is required"},{"field":"password","message":"is required"}]}`,
+		},
+		{
+			name: "Invalid fields",
+			requestBody: fiber.Map{
+				"username": "-testuser",
+				"email":    "test.example.com",
+				"password": "password",
+			},
+			setupMocks:     func(ms *mocks.MockUserServiceInterface) {},
+			expectedStatus: fiber.StatusBadRequest,
+			expectedBody: `{"type":"about:blank","title":"Bad Request","status":400,"instance":"/api/auth/register",
+				"detail":"username may only contain letters, digits, '_', '.' and '-', and must not start or end with '.' or '-'; email must be a valid email address; password must be 8 to 72 characters and contain a letter and a digit",
+				"errors":[
+					{"field":"username","message":"may only contain letters, digits, '_', '.' and '-', and must not start or end with '.' or '-'"},
+					{"field":"email","message":"must be a valid email address"},
+					{"field":"password","message":"must be 8 to 72 characters and contain a letter and a digit"}
+				]}`,
 		},
 		{
 			name: "Service returns error",
@@ -71,24 +91,33 @@ func TestUserHandler_Register(t *testing.T) {
 			},
 			setupMocks: func(ms *mocks.MockUserServiceInterface) {
 				ms.EXPECT().
-					Register(gomock.Any()).
+					Register(gomock.Any(), gomock.Any()).
 					Return(errors.New("service error"))
 			},
 			expectedStatus: fiber.StatusInternalServerError,
-			expectedBody:   `{"error":"Failed to register user, service error"}`,
+			expectedBody:   `{"type":"about:blank","title":"Internal Server Error","status":500,"instance":"/api/auth/register"}`,
+		},
+		{
+			name: "Email already exists",
+			requestBody: fiber.Map{
+				"username": "testuser",
+				"email":    "test@example.com",
+				"password": "password123",
+			},
+			setupMocks: func(ms *mocks.MockUserServiceInterface) {
+				ms.EXPECT().
+					Register(gomock.Any(), gomock.Any()).
+					Return(services.ErrEmailTaken)
+			},
+			expectedStatus: fiber.StatusConflict,
+			expectedBody:   `{"type":"about:blank","title":"Conflict","status":409,"detail":"email already exists","instance":"/api/auth/register"}`,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			// Setup
-			app := fiber.New(fiber.Config{
-				ErrorHandler: func(c *fiber.Ctx, err error) error {
-					return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-						"error": "Invalid request body",
-					})
-				},
-			})
+			app := fiber.New(fiber.Config{ErrorHandler: ErrorHandler})
 			ctrl := gomock.NewController(t)
 			defer ctrl.Finish()
 
@@ -136,7 +165,7 @@ func TestUserHandler_Login(t *testing.T) {
 			},
 			setupMocks: func(ms *mocks.MockUserServiceInterface) {
 				ms.EXPECT().
-					Login("testuser@email.com", "password123").
+					Login(gomock.Any(), "testuser@email.com", "password123").
 					Return("jwt-token", nil)
 			},
 			expectedStatus: fiber.StatusOK,
@@ -150,11 +179,25 @@ func TestUserHandler_Login(t *testing.T) {
 			},
 			setupMocks: func(ms *mocks.MockUserServiceInterface) {
 				ms.EXPECT().
-					Login("testuser@email.com", "wrongpassword").
-					Return("", errors.New("invalid credentials"))
+					Login(gomock.Any(), "testuser@email.com", "wrongpassword").
+					Return("", services.ErrInvalidCredentials)
 			},
 			expectedStatus: fiber.StatusUnauthorized,
-			expectedBody:   `{"error":"Invalid credentials"}`,
+			expectedBody:   `{"type":"about:blank","title":"Unauthorized","status":401,"detail":"invalid credentials","instance":"/api/auth/login"}`,
+		},
+		{
+			name: "Login could not be checked",
+			requestBody: map[string]string{
+				"email":    "testuser@email.com",
+				"password": "password123",
+			},
+			setupMocks: func(ms *mocks.MockUserServiceInterface) {
+				ms.EXPECT().
+					Login(gomock.Any(), "testuser@email.com", "password123").
+					Return("", errors.New("dial tcp: connection refused"))
+			},
+			expectedStatus: fiber.StatusInternalServerError,
+			expectedBody:   `{"type":"about:blank","title":"Internal Server Error","status":500,"instance":"/api/auth/login"}`,
 		},
 		{
 			name: "Missing credentials",
@@ -163,14 +206,15 @@ func TestUserHandler_Login(t *testing.T) {
 			},
 			setupMocks:     func(ms *mocks.MockUserServiceInterface) {},
 			expectedStatus: fiber.StatusBadRequest,
-			expectedBody:   `{"error":"Invalid request body"}`,
+			expectedBody: `{"type":"about:blank","title":"Bad Request","status":400,"detail":"password is required","instance":"/api/auth/login",
+				"errors":[{"field":"password","message":"is required"}]}`,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			// Setup
-			app := fiber.New()
+			app := fiber.New(fiber.Config{ErrorHandler: ErrorHandler})
 			ctrl := gomock.NewController(t)
 			defer ctrl.Finish()
 
@@ -0,0 +1,137 @@
+package handlers
+
+import (
+	"backend/services"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type WebhookHandler struct {
+	webhookService services.WebhookServiceInterface
+}
+
+func NewWebhookHandler(webhookService services.WebhookServiceInterface) *WebhookHandler {
+	return &WebhookHandler{webhookService: webhookService}
+}
+
+type createWebhookRequest struct {
+	URL        string   `json:"url"`
+	EventTypes []string `json:"event_types"`
+}
+
+// CreateWebhook godoc
+// @Summary Create a webhook subscription
+// @Description Subscribe a URL to task events. The signing secret is only returned in this response.
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer {token}"
+// @Param webhook body createWebhookRequest true "Webhook subscription"
+// @Success 201 {object} models.WebhookSubscription
+// @Failure 400 {object} Problem
+// @Failure 500 {object} Problem
+// @Router /api/webhooks [post]
+func (h *WebhookHandler) CreateWebhook(c *fiber.Ctx) error {
+	userID, err := parseUserID(c)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	var req createWebhookRequest
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	sub, err := h.webhookService.CreateSubscription(c.UserContext(), userID, req.URL, req.EventTypes)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(sub)
+}
+
+// ListWebhooks godoc
+// @Summary List webhook subscriptions
+// @Description List the webhook subscriptions of the authenticated user
+// @Tags webhooks
+// @Produce json
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer {token}"
+// @Success 200 {object} []models.WebhookSubscription
+// @Failure 500 {object} Problem
+// @Router /api/webhooks [get]
+func (h *WebhookHandler) ListWebhooks(c *fiber.Ctx) error {
+	userID, err := parseUserID(c)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	subs, err := h.webhookService.ListSubscriptions(c.UserContext(), userID)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(subs)
+}
+
+// DeleteWebhook godoc
+// @Summary Delete a webhook subscription
+// @Tags webhooks
+// @Produce json
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer {token}"
+// @Param id path int true "Webhook ID"
+// @Success 200 {object} map[string]string
+// @Failure 404 {object} Problem
+// @Failure 500 {object} Problem
+// @Router /api/webhooks/{id} [delete]
+func (h *WebhookHandler) DeleteWebhook(c *fiber.Ctx) error {
+	userID, err := parseUserID(c)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	if err := h.webhookService.DeleteSubscription(c.UserContext(), userID, int64(id)); err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Webhook deleted successfully",
+	})
+}
+
+// ListWebhookDeliveries godoc
+// @Summary List recent webhook deliveries
+// @Description List the most recent deliveries of a subscription with their response codes
+// @Tags webhooks
+// @Produce json
+// @Security BearerAuth
+// @Param Authorization header string true "Bearer {token}"
+// @Param id path int true "Webhook ID"
+// @Param limit query int false "Maximum number of deliveries (default 50, max 100)"
+// @Success 200 {object} []models.WebhookDelivery
+// @Failure 404 {object} Problem
+// @Failure 500 {object} Problem
+// @Router /api/webhooks/{id}/deliveries [get]
+func (h *WebhookHandler) ListWebhookDeliveries(c *fiber.Ctx) error {
+	userID, err := parseUserID(c)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	deliveries, err := h.webhookService.ListDeliveries(c.UserContext(), userID, int64(id), c.QueryInt("limit", 50))
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(deliveries)
+}
@@ -0,0 +1,160 @@
+package jobs
+
+import (
+	"backend/models"
+	"backend/pkg/logging"
+	"backend/repositories"
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// Handler executes a single job. Returning an error schedules a retry.
+type Handler func(ctx context.Context, job *models.Job) error
+
+// Pool polls the jobs table and runs leased jobs on a fixed number of workers.
+type Pool struct {
+	repo         repositories.JobRepositoryInterface
+	handlers     map[string]Handler
+	concurrency  int
+	pollInterval time.Duration
+	leaseTimeout time.Duration
+	workerID     string
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func NewPool(repo repositories.JobRepositoryInterface, concurrency int) *Pool {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	hostname, _ := os.Hostname()
+
+	return &Pool{
+		repo:         repo,
+		handlers:     make(map[string]Handler),
+		concurrency:  concurrency,
+		pollInterval: time.Second,
+		leaseTimeout: 5 * time.Minute,
+		workerID:     fmt.Sprintf("%s-%d", hostname, os.Getpid()),
+	}
+}
+
+// Register binds a handler to a job type. It must be called before Start.
+func (p *Pool) Register(jobType string, handler Handler) {
+	p.handlers[jobType] = handler
+}
+
+// SetPollInterval changes how long idle workers wait before polling again.
+func (p *Pool) SetPollInterval(interval time.Duration) {
+	p.pollInterval = interval
+}
+
+func (p *Pool) Start(ctx context.Context) {
+	ctx, p.cancel = context.WithCancel(ctx)
+
+	for i := 0; i < p.concurrency; i++ {
+		p.wg.Add(1)
+		go p.work(ctx, fmt.Sprintf("%s-%d", p.workerID, i))
+	}
+}
+
+// Stop signals the workers to exit and waits for in-flight jobs to finish.
+func (p *Pool) Stop() {
+	if p.cancel != nil {
+		p.cancel()
+	}
+	p.wg.Wait()
+}
+
+func (p *Pool) work(ctx context.Context, workerID string) {
+	defer p.wg.Done()
+
+	for {
+		ran, err := p.runNext(ctx, workerID)
+		if err != nil {
+			logging.FromContext(ctx).Error("job worker failed", "worker", workerID, "error", err)
+		}
+		if ran {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(p.pollInterval):
+		}
+	}
+}
+
+// runNext leases and runs a single job. It reports whether a job was found.
+func (p *Pool) runNext(ctx context.Context, workerID string) (bool, error) {
+	if ctx.Err() != nil {
+		return false, nil
+	}
+
+	leased, err := p.repo.Lease(ctx, workerID, 1, p.leaseTimeout)
+	if err != nil {
+		return false, fmt.Errorf("lease: %w", err)
+	}
+	if len(leased) == 0 {
+		return false, nil
+	}
+
+	job := leased[0]
+	logger := logging.FromContext(ctx).With("job_id", job.ID, "job_type", job.Type)
+	err = p.run(logging.WithLogger(ctx, logger), &job)
+	// Stop cancels ctx, and a job that finished meanwhile must still be
+	// recorded, or it runs again once its lease expires
+	ctx = context.WithoutCancel(ctx)
+	if err != nil {
+		retryAt := time.Now().Add(Backoff(job.Attempts))
+		logger.Warn("job failed", "attempt", job.Attempts, "retry_at", retryAt, "error", err)
+		if ferr := p.repo.Fail(ctx, job.ID, err.Error(), retryAt); ferr != nil {
+			return true, fmt.Errorf("fail job %d: %w", job.ID, ferr)
+		}
+		return true, nil
+	}
+
+	if err := p.repo.Complete(ctx, job.ID); err != nil {
+		return true, fmt.Errorf("complete job %d: %w", job.ID, err)
+	}
+	return true, nil
+}
+
+func (p *Pool) run(ctx context.Context, job *models.Job) (err error) {
+	handler, ok := p.handlers[job.Type]
+	if !ok {
+		return fmt.Errorf("no handler registered for job type %q", job.Type)
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+
+	ctx, cancel := context.WithTimeout(ctx, p.leaseTimeout)
+	defer cancel()
+
+	return handler(ctx, job)
+}
+
+// Backoff returns the delay before retrying a job that has failed attempt
+// times: 30s, 1m, 2m, ... capped at one hour.
+func Backoff(attempt int) time.Duration {
+	if attempt < 1 {
+		attempt = 1
+	}
+	delay := 30 * time.Second
+	for i := 1; i < attempt; i++ {
+		delay *= 2
+		if delay >= time.Hour {
+			return time.Hour
+		}
+	}
+	return delay
+}
@@ -0,0 +1,106 @@
+package jobs
+
+import (
+	"backend/models"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"backend/repositories/mocks"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPool_RunNext(t *testing.T) {
+	tests := []struct {
+		name        string
+		leased      []models.Job
+		handler     Handler
+		setupMocks  func(*mocks.MockJobRepositoryInterface)
+		expectedRan bool
+	}{
+		{
+			name:        "No runnable jobs",
+			leased:      nil,
+			setupMocks:  func(m *mocks.MockJobRepositoryInterface) {},
+			expectedRan: false,
+		},
+		{
+			name:   "Successful job is completed",
+			leased: []models.Job{{ID: 1, Type: "test", Attempts: 1}},
+			handler: func(ctx context.Context, job *models.Job) error {
+				return nil
+			},
+			setupMocks: func(m *mocks.MockJobRepositoryInterface) {
+				m.EXPECT().Complete(gomock.Any(), int64(1)).Return(nil)
+			},
+			expectedRan: true,
+		},
+		{
+			name:   "Failed job is rescheduled with backoff",
+			leased: []models.Job{{ID: 2, Type: "test", Attempts: 3}},
+			handler: func(ctx context.Context, job *models.Job) error {
+				return errors.New("boom")
+			},
+			setupMocks: func(m *mocks.MockJobRepositoryInterface) {
+				m.EXPECT().
+					Fail(gomock.Any(), int64(2), "boom", gomock.Any()).
+					DoAndReturn(func(_ context.Context, id int64, lastError string, retryAt time.Time) error {
+						assert.WithinDuration(t, time.Now().Add(2*time.Minute), retryAt, 5*time.Second)
+						return nil
+					})
+			},
+			expectedRan: true,
+		},
+		{
+			name:   "Panicking handler counts as a failure",
+			leased: []models.Job{{ID: 3, Type: "test", Attempts: 1}},
+			handler: func(ctx context.Context, job *models.Job) error {
+				panic("oops")
+			},
+			setupMocks: func(m *mocks.MockJobRepositoryInterface) {
+				m.EXPECT().Fail(gomock.Any(), int64(3), "panic: oops", gomock.Any()).Return(nil)
+			},
+			expectedRan: true,
+		},
+		{
+			name:   "Unknown job type fails",
+			leased: []models.Job{{ID: 4, Type: "unknown", Attempts: 1}},
+			setupMocks: func(m *mocks.MockJobRepositoryInterface) {
+				m.EXPECT().Fail(gomock.Any(), int64(4), `no handler registered for job type "unknown"`, gomock.Any()).Return(nil)
+			},
+			expectedRan: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mocks.NewMockJobRepositoryInterface(ctrl)
+			repo.EXPECT().Lease(gomock.Any(), "worker", 1, gomock.Any()).Return(tt.leased, nil)
+			tt.setupMocks(repo)
+
+			pool := NewPool(repo, 1)
+			if tt.handler != nil {
+				pool.Register("test", tt.handler)
+			}
+
+			ran, err := pool.runNext(context.Background(), "worker")
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedRan, ran)
+		})
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	assert.Equal(t, 30*time.Second, Backoff(0))
+	assert.Equal(t, 30*time.Second, Backoff(1))
+	assert.Equal(t, time.Minute, Backoff(2))
+	assert.Equal(t, 2*time.Minute, Backoff(3))
+	assert.Equal(t, time.Hour, Backoff(20))
+}
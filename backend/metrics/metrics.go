@@ -0,0 +1,78 @@
+// Package metrics holds the server's Prometheus metrics and serves them at
+// /metrics. HTTP routes are labelled with their template, such as
+// /api/task/:id, so the number of series stays bounded.
+package metrics
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/adaptor"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Registry holds every metric of the server, with the Go runtime and process
+// metrics
+var Registry = prometheus.NewRegistry()
+
+var factory = promauto.With(Registry)
+
+var (
+	HTTPRequests = factory.NewCounterVec(prometheus.CounterOpts{
+		Name: "http_requests_total",
+		Help: "HTTP requests handled, by method, route template and status.",
+	}, []string{"method", "route", "status"})
+
+	HTTPRequestDuration = factory.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "http_request_duration_seconds",
+		Help:    "Time taken to handle HTTP requests, by method, route template and status.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+
+	QueryDuration = factory.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "db_query_duration_seconds",
+		Help:    "Time taken by repository methods, by repository and method.",
+		Buckets: []float64{.0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5},
+	}, []string{"repository", "method"})
+
+	Logins = factory.NewCounterVec(prometheus.CounterOpts{
+		Name: "auth_logins_total",
+		Help: "Login attempts, by result: success, failure for wrong credentials or error.",
+	}, []string{"result"})
+
+	TasksCreated = factory.NewCounter(prometheus.CounterOpts{
+		Name: "tasks_created_total",
+		Help: "Tasks created.",
+	})
+
+	TasksCompleted = factory.NewCounter(prometheus.CounterOpts{
+		Name: "tasks_completed_total",
+		Help: "Tasks moved to DONE.",
+	})
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+}
+
+// RegisterDB exports the connection pool stats of db, labelled with name
+func RegisterDB(db *sql.DB, name string) {
+	Registry.MustRegister(collectors.NewDBStatsCollector(db, name))
+}
+
+// ObserveQuery records that method of repository took since start
+func ObserveQuery(repository, method string, start time.Time) {
+	QueryDuration.WithLabelValues(repository, method).Observe(time.Since(start).Seconds())
+}
+
+// Handler serves the metrics in the Prometheus text format
+func Handler() fiber.Handler {
+	return adaptor.HTTPHandler(promhttp.HandlerFor(Registry, promhttp.HandlerOpts{}))
+}
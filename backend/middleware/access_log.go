@@ -0,0 +1,55 @@
+package middleware
+
+import (
+	"backend/pkg/logging"
+	"log/slog"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// AccessLog logs every request once it has been handled, with the logger of
+// its context. The query string is left out since it can carry tokens.
+func AccessLog() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		start := time.Now()
+		handleError(c, c.Next())
+
+		status := c.Response().StatusCode()
+		level := slog.LevelInfo
+		if status >= fiber.StatusInternalServerError {
+			level = slog.LevelError
+		}
+		attrs := []slog.Attr{
+			slog.String("method", c.Method()),
+			slog.String("path", c.Path()),
+			slog.String("route", c.Route().Path),
+			slog.Int("status", status),
+			slog.Float64("latency_ms", float64(time.Since(start).Microseconds())/1000),
+			slog.String("ip", c.IP()),
+		}
+		// Reading the body of a stream would consume it
+		if !c.Response().IsBodyStream() {
+			attrs = append(attrs, slog.Int("bytes", len(c.Response().Body())))
+		}
+		if userID, ok := c.Locals("userId").(int64); ok {
+			attrs = append(attrs, slog.Int64("user_id", userID))
+		}
+
+		ctx := c.UserContext()
+		logging.FromContext(ctx).LogAttrs(ctx, level, "request", attrs...)
+		return nil
+	}
+}
+
+// handleError hands err to the app's ErrorHandler right away, rather than
+// once the middleware chain returns, so the status recorded by middleware
+// is the one sent
+func handleError(c *fiber.Ctx, err error) {
+	if err == nil {
+		return
+	}
+	if err := c.App().ErrorHandler(c, err); err != nil {
+		_ = c.SendStatus(fiber.StatusInternalServerError)
+	}
+}
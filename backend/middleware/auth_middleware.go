@@ -2,36 +2,38 @@ package middleware
 
 import (
 	"backend/pkg/jwt"
-	"fmt"
+	"backend/pkg/logging"
 	"strings"
 
 	"github.com/gofiber/fiber/v2"
 )
 
-func AuthMiddleware() fiber.Handler {
+// AuthMiddleware requires a bearer token signed with secret and stores its
+// user ID in Locals "userId"
+func AuthMiddleware(secret string) fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		authHeader := c.Get("Authorization")
 		if authHeader == "" {
-			return c.Status(401).JSON(fiber.Map{"error": "Authorization header required"})
+			return fiber.NewError(fiber.StatusUnauthorized, "Authorization header required")
 		}
 
 		parts := strings.Split(authHeader, " ")
 		if len(parts) != 2 || parts[0] != "Bearer" {
-			return c.Status(401).JSON(fiber.Map{"error": "Invalid authorization header format"})
+			return fiber.NewError(fiber.StatusUnauthorized, "Invalid authorization header format")
 		}
 
 		token := parts[1]
 
-		claims, err := jwt.ValidateToken(token, "your-secret-key")
+		claims, err := jwt.ValidateToken(token, secret)
 		if err != nil {
-			fmt.Printf("Token validation error: %v\n", err)
-			return c.Status(401).JSON(fiber.Map{"error": "Invalid token"})
+			logging.FromContext(c.UserContext()).Info("invalid token", "error", err)
+			return fiber.NewError(fiber.StatusUnauthorized, "Invalid token")
 		}
 
 		// Make sure we're accessing the correct claim key
 		userID, ok := claims["user_id"]
 		if !ok {
-			return c.Status(401).JSON(fiber.Map{"error": "User ID not found in token"})
+			return fiber.NewError(fiber.StatusUnauthorized, "User ID not found in token")
 		}
 
 		// Set the user ID in context
@@ -0,0 +1,52 @@
+package middleware
+
+import (
+	"io"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// BodyLimit rejects request bodies larger than limit bytes with 413 and
+// buffers the others, unless skip reports that the route sets its own limit.
+// The app must stream request bodies (fiber.Config.StreamRequestBody), so
+// that the server does not read them with one limit for every route.
+func BodyLimit(limit int, skip func(c *fiber.Ctx) bool) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if skip == nil || !skip(c) {
+			if err := readBody(c, limit); err != nil {
+				// What is left of the body can't be told apart from the next
+				// request on the connection
+				c.Context().SetConnectionClose()
+				return err
+			}
+		}
+
+		err := c.Next()
+		if c.Request().IsBodyStream() {
+			// The body was not read, for example by a route that was not found
+			c.Context().SetConnectionClose()
+		}
+		return err
+	}
+}
+
+func readBody(c *fiber.Ctx, limit int) error {
+	req := c.Request()
+	if req.Header.ContentLength() > limit {
+		return fiber.ErrRequestEntityTooLarge
+	}
+	if !req.IsBodyStream() {
+		return nil
+	}
+
+	// Chunked bodies have no length up front
+	body, err := io.ReadAll(io.LimitReader(req.BodyStream(), int64(limit)+1))
+	if err != nil {
+		return fiber.ErrBadRequest
+	}
+	if len(body) > limit {
+		return fiber.ErrRequestEntityTooLarge
+	}
+	req.SetBodyRaw(body)
+	return nil
+}
@@ -0,0 +1,66 @@
+package middleware
+
+import (
+	"io"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBodyLimit(t *testing.T) {
+	app := fiber.New(fiber.Config{StreamRequestBody: true, DisablePreParseMultipartForm: true})
+	app.Use(BodyLimit(16, func(c *fiber.Ctx) bool { return c.Path() == "/upload" }))
+	echo := func(c *fiber.Ctx) error {
+		return c.SendString(strconv.Itoa(len(c.Body())))
+	}
+	app.Post("/json", echo)
+	app.Post("/upload", BodyLimit(64, nil), echo)
+
+	tests := []struct {
+		name           string
+		method         string
+		path           string
+		size           int
+		chunked        bool
+		expectedStatus int
+		expectClose    bool
+	}{
+		{name: "Within the limit", path: "/json", size: 16, expectedStatus: fiber.StatusOK},
+		{name: "Over the limit", path: "/json", size: 17, expectedStatus: fiber.StatusRequestEntityTooLarge, expectClose: true},
+		{name: "Chunked within the limit", path: "/json", size: 16, chunked: true, expectedStatus: fiber.StatusOK},
+		{name: "Chunked over the limit", path: "/json", size: 17, chunked: true, expectedStatus: fiber.StatusRequestEntityTooLarge, expectClose: true},
+		{name: "Unknown route", path: "/missing", size: 8, expectedStatus: fiber.StatusNotFound},
+		{name: "Route with its own limit", path: "/upload", size: 64, expectedStatus: fiber.StatusOK},
+		{name: "Over the limit of the route", path: "/upload", size: 65, expectedStatus: fiber.StatusRequestEntityTooLarge, expectClose: true},
+		{name: "Skipped and never read", method: "PUT", path: "/upload", size: 8, expectedStatus: fiber.StatusMethodNotAllowed, expectClose: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body := strings.NewReader(strings.Repeat("x", tt.size))
+			method := tt.method
+			if method == "" {
+				method = "POST"
+			}
+			req := httptest.NewRequest(method, tt.path, body)
+			if tt.chunked {
+				req.ContentLength = -1
+				req.TransferEncoding = []string{"chunked"}
+			}
+
+			resp, err := app.Test(req)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+			if tt.expectedStatus == fiber.StatusOK {
+				got, _ := io.ReadAll(resp.Body)
+				assert.Equal(t, strconv.Itoa(tt.size), string(got))
+			}
+			assert.Equal(t, tt.expectClose, resp.Close)
+		})
+	}
+}
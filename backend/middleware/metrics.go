@@ -0,0 +1,26 @@
+package middleware
+
+import (
+	"backend/metrics"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Metrics counts and times every request by method, route template and
+// status
+func Metrics() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		start := time.Now()
+		handleError(c, c.Next())
+
+		// Fiber's strings point into buffers reused by later requests, and
+		// label values are kept
+		labels := []string{strings.Clone(c.Method()), c.Route().Path, strconv.Itoa(c.Response().StatusCode())}
+		metrics.HTTPRequests.WithLabelValues(labels...).Inc()
+		metrics.HTTPRequestDuration.WithLabelValues(labels...).Observe(time.Since(start).Seconds())
+		return nil
+	}
+}
@@ -0,0 +1,36 @@
+package middleware
+
+import (
+	"backend/metrics"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetrics(t *testing.T) {
+	app := fiber.New()
+	app.Use(Metrics())
+	app.Get("/api/task/:id", func(c *fiber.Ctx) error {
+		if c.Params("id") == "0" {
+			return fiber.ErrNotFound
+		}
+		return c.SendString("ok")
+	})
+
+	ok := metrics.HTTPRequests.WithLabelValues("GET", "/api/task/:id", "200")
+	notFound := metrics.HTTPRequests.WithLabelValues("GET", "/api/task/:id", "404")
+	okBefore, notFoundBefore := testutil.ToFloat64(ok), testutil.ToFloat64(notFound)
+
+	for _, path := range []string{"/api/task/7", "/api/task/8", "/api/task/0"} {
+		_, err := app.Test(httptest.NewRequest("GET", path, nil))
+		require.NoError(t, err)
+	}
+
+	// Requests are labelled with the route template, not the path
+	assert.Equal(t, okBefore+2, testutil.ToFloat64(ok))
+	assert.Equal(t, notFoundBefore+1, testutil.ToFloat64(notFound))
+}
@@ -0,0 +1,20 @@
+package middleware
+
+import (
+	"github.com/gofiber/fiber/v2"
+)
+
+// QueryTokenMiddleware lets clients that cannot set request headers, such as
+// the browser EventSource API, pass their JWT as the access_token query
+// parameter. It must run before AuthMiddleware and should only be mounted on
+// routes that need it, since URLs end up in logs.
+func QueryTokenMiddleware() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if c.Get("Authorization") == "" {
+			if token := c.Query("access_token"); token != "" {
+				c.Request().Header.Set("Authorization", "Bearer "+token)
+			}
+		}
+		return c.Next()
+	}
+}
@@ -0,0 +1,28 @@
+package middleware
+
+import (
+	"context"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// RequestContext sets the user context handlers pass down to services and
+// repositories. It is cancelled when the request takes longer than timeout,
+// when the handler returns or when the server shuts down, so database work
+// for an abandoned request stops. Zero means no timeout.
+func RequestContext(timeout time.Duration) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		var ctx context.Context = c.Context()
+		var cancel context.CancelFunc
+		if timeout > 0 {
+			ctx, cancel = context.WithTimeout(ctx, timeout)
+		} else {
+			ctx, cancel = context.WithCancel(ctx)
+		}
+		defer cancel()
+
+		c.SetUserContext(ctx)
+		return c.Next()
+	}
+}
@@ -0,0 +1,55 @@
+package middleware
+
+import (
+	"backend/pkg/logging"
+	"log/slog"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// RequestIDHeader carries the ID of a request in both directions
+const RequestIDHeader = "X-Request-ID"
+
+// maxRequestIDLength bounds the client IDs accepted, which end up in every
+// log line of the request
+const maxRequestIDLength = 128
+
+// RequestID takes the ID of the request from X-Request-ID, or generates one
+// when it is missing or unusable, and echoes it in the response. The user
+// context carries logger tagged with the ID, and the trace ID when Tracing
+// runs first, for handlers, services and repositories to log with. It must
+// run after RequestContext.
+func RequestID(logger *slog.Logger) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		// The logger outlives the request's buffers, for streamed responses
+		id := strings.Clone(c.Get(RequestIDHeader))
+		if !validRequestID(id) {
+			id = uuid.NewString()
+		}
+		c.Set(RequestIDHeader, id)
+		c.Locals("requestId", id)
+		requestLogger := logger.With("request_id", id)
+		if span := trace.SpanContextFromContext(c.UserContext()); span.IsValid() {
+			requestLogger = requestLogger.With("trace_id", span.TraceID().String())
+		}
+		c.SetUserContext(logging.WithLogger(c.UserContext(), requestLogger))
+		return c.Next()
+	}
+}
+
+// validRequestID accepts printable ASCII without spaces, so IDs can't forge
+// log lines or headers
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] <= ' ' || id[i] > '~' {
+			return false
+		}
+	}
+	return true
+}
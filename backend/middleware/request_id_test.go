@@ -0,0 +1,97 @@
+package middleware
+
+import (
+	"backend/pkg/logging"
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newLoggedApp(buf *bytes.Buffer) *fiber.App {
+	app := fiber.New()
+	app.Use(RequestContext(0), RequestID(logging.New(buf, slog.LevelInfo)), AccessLog())
+	app.Get("/api/task/:id", func(c *fiber.Ctx) error {
+		c.Locals("userId", int64(3))
+		logging.FromContext(c.UserContext()).Info("handled")
+		if c.Params("id") == "0" {
+			return fiber.ErrNotFound
+		}
+		return c.SendString("ok")
+	})
+	return app
+}
+
+func logLines(t *testing.T, buf *bytes.Buffer) []map[string]interface{} {
+	var lines []map[string]interface{}
+	for _, raw := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		var line map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(raw), &line))
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+func TestRequestID(t *testing.T) {
+	tests := []struct {
+		name      string
+		header    string
+		generated bool
+	}{
+		{"Accepts the client's ID", "abc-123", false},
+		{"Generates a missing ID", "", true},
+		{"Replaces an ID with spaces", "a b", true},
+		{"Replaces a long ID", strings.Repeat("a", maxRequestIDLength+1), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			req := httptest.NewRequest("GET", "/api/task/7", nil)
+			if tt.header != "" {
+				req.Header.Set(RequestIDHeader, tt.header)
+			}
+			resp, err := newLoggedApp(&buf).Test(req)
+			require.NoError(t, err)
+
+			id := resp.Header.Get(RequestIDHeader)
+			if tt.generated {
+				assert.Len(t, id, 36)
+			} else {
+				assert.Equal(t, tt.header, id)
+			}
+			for _, line := range logLines(t, &buf) {
+				assert.Equal(t, id, line["request_id"])
+			}
+		})
+	}
+}
+
+func TestAccessLog(t *testing.T) {
+	var buf bytes.Buffer
+	app := newLoggedApp(&buf)
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/api/task/0?access_token=secret", nil))
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+
+	lines := logLines(t, &buf)
+	require.Len(t, lines, 2)
+	assert.Equal(t, "handled", lines[0]["msg"])
+
+	access := lines[1]
+	assert.Equal(t, "request", access["msg"])
+	assert.Equal(t, "GET", access["method"])
+	assert.Equal(t, "/api/task/0", access["path"])
+	assert.Equal(t, "/api/task/:id", access["route"])
+	assert.Equal(t, float64(fiber.StatusNotFound), access["status"])
+	assert.Equal(t, float64(3), access["user_id"])
+	assert.Contains(t, access, "latency_ms")
+	assert.NotContains(t, buf.String(), "secret")
+}
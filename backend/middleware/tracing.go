@@ -0,0 +1,68 @@
+package middleware
+
+import (
+	"backend/tracing"
+	"net/http"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Tracing starts a server span for every request, continuing the trace of
+// its traceparent header, and puts it in the user context for services and
+// queries to add their spans to. It must run after RequestContext.
+func Tracing() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		// Fiber's strings point into buffers reused by later requests, and
+		// spans are exported after the request
+		method := strings.Clone(c.Method())
+		ctx := otel.GetTextMapPropagator().Extract(c.UserContext(), headerCarrier{c})
+		ctx, span := tracing.Tracer().Start(ctx, method,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				attribute.String("http.request.method", method),
+				attribute.String("url.path", strings.Clone(c.Path())),
+			),
+		)
+		defer span.End()
+		c.SetUserContext(ctx)
+
+		handleError(c, c.Next())
+
+		route, status := c.Route().Path, c.Response().StatusCode()
+		span.SetName(method + " " + route)
+		span.SetAttributes(
+			attribute.String("http.route", route),
+			attribute.Int("http.response.status_code", status),
+		)
+		if status >= fiber.StatusInternalServerError {
+			span.SetStatus(codes.Error, http.StatusText(status))
+		}
+		return nil
+	}
+}
+
+// headerCarrier reads and writes the request headers for propagators
+type headerCarrier struct {
+	c *fiber.Ctx
+}
+
+func (h headerCarrier) Get(key string) string {
+	return strings.Clone(h.c.Get(key))
+}
+
+func (h headerCarrier) Set(key, value string) {
+	h.c.Request().Header.Set(key, value)
+}
+
+func (h headerCarrier) Keys() []string {
+	var keys []string
+	for key := range h.c.GetReqHeaders() {
+		keys = append(keys, key)
+	}
+	return keys
+}
@@ -0,0 +1,53 @@
+package middleware
+
+import (
+	"backend/tracing"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestTracing(t *testing.T) {
+	exporter := tracing.InMemory()
+
+	app := fiber.New()
+	app.Use(RequestContext(0), Tracing())
+	app.Get("/api/task/:id", func(c *fiber.Ctx) error {
+		_, span := tracing.Tracer().Start(c.UserContext(), "TaskService.Get")
+		span.End()
+		if c.Params("id") == "0" {
+			return fiber.ErrInternalServerError
+		}
+		return c.SendString("ok")
+	})
+
+	req := httptest.NewRequest("GET", "/api/task/7", nil)
+	req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	_, err := app.Test(req)
+	require.NoError(t, err)
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 2)
+	child, server := spans[0], spans[1]
+	assert.Equal(t, "GET /api/task/:id", server.Name)
+	assert.Equal(t, trace.SpanKindServer, server.SpanKind)
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", server.SpanContext.TraceID().String())
+	assert.Equal(t, "00f067aa0ba902b7", server.Parent.SpanID().String())
+	assert.Contains(t, server.Attributes, attribute.String("http.route", "/api/task/:id"))
+	assert.Contains(t, server.Attributes, attribute.Int("http.response.status_code", fiber.StatusOK))
+	assert.Equal(t, server.SpanContext.SpanID(), child.Parent.SpanID())
+
+	exporter.Reset()
+	_, err = app.Test(httptest.NewRequest("GET", "/api/task/0", nil))
+	require.NoError(t, err)
+	spans = exporter.GetSpans()
+	require.Len(t, spans, 2)
+	assert.False(t, spans[1].Parent.IsValid())
+	assert.Equal(t, codes.Error, spans[1].Status.Code)
+}
@@ -0,0 +1,17 @@
+package models
+
+import "time"
+
+// Attachment is a file uploaded to a task
+// @Description Attachment object
+type Attachment struct {
+	ID             int64     `db:"id" json:"id"`
+	TaskID         int64     `db:"task_id" json:"task_id"`
+	UploaderID     *int64    `db:"uploader_id" json:"uploader_id"`
+	Filename       string    `db:"filename" json:"filename"`
+	ContentType    string    `db:"content_type" json:"content_type"`
+	SizeBytes      int64     `db:"size_bytes" json:"size_bytes"`
+	ChecksumSHA256 string    `db:"checksum_sha256" json:"checksum_sha256"`
+	StorageKey     string    `db:"storage_key" json:"-"`
+	CreatedAt      time.Time `db:"created_at" json:"created_at"`
+}
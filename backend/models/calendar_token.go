@@ -0,0 +1,10 @@
+package models
+
+import "time"
+
+// CalendarToken is a user's iCalendar feed token. Only its hash is stored.
+type CalendarToken struct {
+	UserID    int64     `db:"user_id" json:"user_id"`
+	TokenHash string    `db:"token_hash" json:"-"`
+	CreatedAt time.Time `db:"created_at" json:"created_at"`
+}
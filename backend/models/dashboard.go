@@ -0,0 +1,10 @@
+package models
+
+// TaskCount is the number of tasks a user has in a role ("assigned" or
+// "created") with a status and priority
+type TaskCount struct {
+	Role     string     `db:"role" json:"role"`
+	Status   TaskStatus `db:"status" json:"status"`
+	Priority int        `db:"priority" json:"priority"`
+	Count    int        `db:"count" json:"count"`
+}
@@ -0,0 +1,28 @@
+package models
+
+import "time"
+
+type JobStatus string
+
+const (
+	JobPending JobStatus = "PENDING"
+	JobRunning JobStatus = "RUNNING"
+	JobDone    JobStatus = "DONE"
+	JobFailed  JobStatus = "FAILED"
+)
+
+// Job is a unit of background work stored in the jobs table
+type Job struct {
+	ID          int64      `db:"id" json:"id"`
+	Type        string     `db:"type" json:"type"`
+	Payload     string     `db:"payload" json:"payload"`
+	Status      JobStatus  `db:"status" json:"status"`
+	Attempts    int        `db:"attempts" json:"attempts"`
+	MaxAttempts int        `db:"max_attempts" json:"max_attempts"`
+	RunAt       time.Time  `db:"run_at" json:"run_at"`
+	LockedBy    *string    `db:"locked_by" json:"locked_by"`
+	LockedUntil *time.Time `db:"locked_until" json:"locked_until"`
+	LastError   *string    `db:"last_error" json:"last_error"`
+	CreatedAt   time.Time  `db:"created_at" json:"created_at"`
+	UpdatedAt   time.Time  `db:"updated_at" json:"updated_at"`
+}
@@ -0,0 +1,23 @@
+package models
+
+import "time"
+
+type MentionSource string
+
+const (
+	MentionSourceTaskDescription MentionSource = "task_description"
+)
+
+// Mention records a user mentioned in a task. Flagged mentions name a user
+// who cannot see the task, so they were not notified.
+// @Description Mention object
+type Mention struct {
+	ID                int64         `db:"id" json:"id"`
+	TaskID            int64         `db:"task_id" json:"task_id"`
+	Source            MentionSource `db:"source" json:"source"`
+	MentionedUserID   int64         `db:"mentioned_user_id" json:"mentioned_user_id"`
+	MentionedUsername string        `db:"mentioned_username" json:"mentioned_username"`
+	ActorID           *int64        `db:"actor_id" json:"actor_id"`
+	Flagged           bool          `db:"flagged" json:"flagged"`
+	CreatedAt         time.Time     `db:"created_at" json:"created_at"`
+}
@@ -0,0 +1,39 @@
+package models
+
+import "time"
+
+type NotificationType string
+
+const (
+	NotificationTaskAssigned  NotificationType = "task.assigned"
+	NotificationStatusChanged NotificationType = "task.status_changed"
+	NotificationMention       NotificationType = "mention"
+)
+
+// NotificationTypes lists every type a user can opt in or out of
+var NotificationTypes = []NotificationType{
+	NotificationTaskAssigned,
+	NotificationStatusChanged,
+	NotificationMention,
+}
+
+func (t NotificationType) IsValid() bool {
+	switch t {
+	case NotificationTaskAssigned, NotificationStatusChanged, NotificationMention:
+		return true
+	}
+	return false
+}
+
+// Notification is an entry in a user's inbox
+// @Description Notification object
+type Notification struct {
+	ID        int64            `db:"id" json:"id"`
+	UserID    int64            `db:"user_id" json:"user_id"`
+	Type      NotificationType `db:"type" json:"type"`
+	TaskID    *int64           `db:"task_id" json:"task_id"`
+	ActorID   *int64           `db:"actor_id" json:"actor_id"`
+	Message   string           `db:"message" json:"message"`
+	ReadAt    *time.Time       `db:"read_at" json:"read_at"`
+	CreatedAt time.Time        `db:"created_at" json:"created_at"`
+}
@@ -0,0 +1,42 @@
+package models
+
+import "time"
+
+// StatusTransition records a task moving between statuses. FromStatus is nil
+// for the status a task was created with.
+// @Description Status transition object
+type StatusTransition struct {
+	ID         int64       `db:"id" json:"id"`
+	TaskID     int64       `db:"task_id" json:"task_id"`
+	FromStatus *TaskStatus `db:"from_status" json:"from_status"`
+	ToStatus   TaskStatus  `db:"to_status" json:"to_status"`
+	ChangedBy  *int64      `db:"changed_by" json:"changed_by"`
+	ChangedAt  time.Time   `db:"changed_at" json:"changed_at"`
+}
+
+// AnalyticsFilter selects the tasks analytics are computed over. Only tasks
+// ViewerID assigned or is assigned to are included.
+type AnalyticsFilter struct {
+	ViewerID   int64
+	AssigneeID *int64
+	From       time.Time
+	To         time.Time
+}
+
+// CompletedTask is a task that reached StatusDone. StartedAt is when it first
+// moved to StatusInProgress and is nil if it skipped that status.
+type CompletedTask struct {
+	TaskID     int64      `db:"task_id" json:"task_id"`
+	Title      string     `db:"title" json:"title"`
+	AssigneeID *int64     `db:"assignee_id" json:"assignee_id"`
+	CreatedAt  time.Time  `db:"created_at" json:"created_at"`
+	StartedAt  *time.Time `db:"started_at" json:"started_at"`
+	DoneAt     time.Time  `db:"done_at" json:"done_at"`
+}
+
+// DailyStatusCount is the number of tasks in a status at the end of a day
+type DailyStatusCount struct {
+	Day    time.Time  `db:"day" json:"day"`
+	Status TaskStatus `db:"status" json:"status"`
+	Count  int        `db:"count" json:"count"`
+}
@@ -28,8 +28,12 @@ type Task struct {
 	AssigneeID  *int64     `db:"assignee_id" json:"assignee_id"` // Add db tag
 	AssignerID  *int64     `db:"assigner_id" json:"assigner_id"` // Add db tag
 	Priority    int        `db:"priority" json:"priority"`
-	CreatedAt   time.Time  `db:"created_at" json:"created_at"`
-	UpdatedAt   time.Time  `db:"updated_at" json:"updated_at"`
+	DueAt       *time.Time `db:"due_at" json:"due_at"`
+	// Estimates in minutes
+	OriginalEstimateMinutes  *int      `db:"original_estimate_minutes" json:"original_estimate_minutes"`
+	RemainingEstimateMinutes *int      `db:"remaining_estimate_minutes" json:"remaining_estimate_minutes"`
+	CreatedAt                time.Time `db:"created_at" json:"created_at"`
+	UpdatedAt                time.Time `db:"updated_at" json:"updated_at"`
 }
 
 type Priority int
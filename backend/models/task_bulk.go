@@ -0,0 +1,46 @@
+package models
+
+// BulkAction is a change applied to several tasks at once. BulkSetLabels is
+// reserved; tasks do not have labels yet.
+type BulkAction string
+
+const (
+	BulkSetStatus   BulkAction = "status"
+	BulkReassign    BulkAction = "reassign"
+	BulkSetPriority BulkAction = "priority"
+	BulkSetLabels   BulkAction = "label"
+	BulkDelete      BulkAction = "delete"
+)
+
+// BulkOperation applies one action to several tasks. Status, AssigneeID and
+// Priority hold the new value for their action; a nil AssigneeID unassigns.
+type BulkOperation struct {
+	Action     BulkAction  `json:"action"`
+	TaskIDs    []int64     `json:"task_ids"`
+	Status     *TaskStatus `json:"status,omitempty"`
+	AssigneeID *int64      `json:"assignee_id,omitempty"`
+	Priority   *int        `json:"priority,omitempty"`
+}
+
+type BulkItemStatus string
+
+const (
+	BulkItemOK        BulkItemStatus = "ok"
+	BulkItemNotFound  BulkItemStatus = "not_found"
+	BulkItemForbidden BulkItemStatus = "forbidden"
+	BulkItemFailed    BulkItemStatus = "failed"
+)
+
+type BulkItemResult struct {
+	TaskID int64          `json:"task_id"`
+	Status BulkItemStatus `json:"status"`
+	Error  string         `json:"error,omitempty"`
+}
+
+// BulkResult reports the outcome of a bulk operation for each task
+type BulkResult struct {
+	Action    BulkAction       `json:"action"`
+	Succeeded int              `json:"succeeded"`
+	Failed    int              `json:"failed"`
+	Items     []BulkItemResult `json:"items"`
+}
@@ -0,0 +1,8 @@
+package models
+
+// TaskFilter narrows a list of tasks. Nil fields match every task.
+type TaskFilter struct {
+	Status     *TaskStatus
+	Priority   *int
+	AssigneeID *int64
+}
@@ -0,0 +1,34 @@
+package models
+
+import "time"
+
+// TaskRequest is the body of creating or replacing a task. It leaves out the
+// fields clients don't set: the ID comes from the path, the assigner from the
+// authenticated user and the timestamps from the database.
+// @Description Task fields a client can set
+type TaskRequest struct {
+	Title       string     `json:"title" validate:"required,notblank,max=255"`
+	Description string     `json:"description"`
+	Status      TaskStatus `json:"status" validate:"oneof=TO_DO IN_PROGRESS DONE"`
+	AssigneeID  *int64     `json:"assignee_id" validate:"omitempty,min=1"`
+	// Priority is PriorityLow to PriorityHigh
+	Priority int        `json:"priority" validate:"min=1,max=3"`
+	DueAt    *time.Time `json:"due_at"`
+	// Estimates in minutes
+	OriginalEstimateMinutes  *int `json:"original_estimate_minutes" validate:"omitempty,min=0"`
+	RemainingEstimateMinutes *int `json:"remaining_estimate_minutes" validate:"omitempty,min=0"`
+}
+
+// Task returns the task the request describes
+func (r TaskRequest) Task() *Task {
+	return &Task{
+		Title:                    r.Title,
+		Description:              r.Description,
+		Status:                   r.Status,
+		AssigneeID:               r.AssigneeID,
+		Priority:                 r.Priority,
+		DueAt:                    r.DueAt,
+		OriginalEstimateMinutes:  r.OriginalEstimateMinutes,
+		RemainingEstimateMinutes: r.RemainingEstimateMinutes,
+	}
+}
@@ -0,0 +1,21 @@
+package models
+
+// RegisterRequest is the body of registering a user
+// @Description New user details
+type RegisterRequest struct {
+	Username string `json:"username" validate:"required,min=3,max=50,username"`
+	Email    string `json:"email" validate:"required,email,max=255"`
+	Password string `json:"password" validate:"required,password"`
+}
+
+// User returns the user to register, with the password still in plain text
+func (r RegisterRequest) User() *User {
+	return &User{Username: r.Username, Email: r.Email, Password: r.Password}
+}
+
+// LoginRequest is the body of logging in
+// @Description User credentials
+type LoginRequest struct {
+	Email    string `json:"email" validate:"required,email"`
+	Password string `json:"password" validate:"required"`
+}
@@ -0,0 +1,45 @@
+package models
+
+import (
+	"time"
+
+	"github.com/lib/pq"
+)
+
+type WebhookDeliveryStatus string
+
+const (
+	DeliveryPending   WebhookDeliveryStatus = "PENDING"
+	DeliverySucceeded WebhookDeliveryStatus = "SUCCEEDED"
+	DeliveryRetrying  WebhookDeliveryStatus = "RETRYING"
+	DeliveryDead      WebhookDeliveryStatus = "DEAD"
+)
+
+// WebhookSubscription receives task events for tasks its owner assigned or
+// is assigned to
+// @Description Webhook subscription object
+type WebhookSubscription struct {
+	ID         int64          `db:"id" json:"id"`
+	UserID     int64          `db:"user_id" json:"user_id"`
+	URL        string         `db:"url" json:"url"`
+	Secret     string         `db:"secret" json:"secret,omitempty"`
+	EventTypes pq.StringArray `db:"event_types" json:"event_types" swaggertype:"array,string"`
+	Active     bool           `db:"active" json:"active"`
+	CreatedAt  time.Time      `db:"created_at" json:"created_at"`
+	UpdatedAt  time.Time      `db:"updated_at" json:"updated_at"`
+}
+
+// WebhookDelivery records a single event sent to a subscription
+// @Description Webhook delivery object
+type WebhookDelivery struct {
+	ID             int64                 `db:"id" json:"id"`
+	SubscriptionID int64                 `db:"subscription_id" json:"subscription_id"`
+	EventType      string                `db:"event_type" json:"event_type"`
+	Payload        string                `db:"payload" json:"-"`
+	Status         WebhookDeliveryStatus `db:"status" json:"status"`
+	Attempts       int                   `db:"attempts" json:"attempts"`
+	ResponseCode   *int                  `db:"response_code" json:"response_code"`
+	LastError      *string               `db:"last_error" json:"last_error"`
+	CreatedAt      time.Time             `db:"created_at" json:"created_at"`
+	UpdatedAt      time.Time             `db:"updated_at" json:"updated_at"`
+}
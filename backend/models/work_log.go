@@ -0,0 +1,31 @@
+package models
+
+import "time"
+
+// WorkLog is time a user spent on a task. EndedAt and DurationSeconds are
+// nil while the entry is a running timer.
+// @Description Work log object
+type WorkLog struct {
+	ID              int64      `db:"id" json:"id"`
+	TaskID          int64      `db:"task_id" json:"task_id"`
+	UserID          int64      `db:"user_id" json:"user_id"`
+	StartedAt       time.Time  `db:"started_at" json:"started_at"`
+	EndedAt         *time.Time `db:"ended_at" json:"ended_at"`
+	DurationSeconds *int64     `db:"duration_seconds" json:"duration_seconds"`
+	Note            string     `db:"note" json:"note"`
+	CreatedAt       time.Time  `db:"created_at" json:"created_at"`
+}
+
+// UserTimeTotal is the time one user logged on a task
+type UserTimeTotal struct {
+	UserID       int64  `db:"user_id" json:"user_id"`
+	Username     string `db:"username" json:"username"`
+	TotalSeconds int64  `db:"total_seconds" json:"total_seconds"`
+}
+
+// TaskTimeTotal is the time logged on one task
+type TaskTimeTotal struct {
+	TaskID       int64  `db:"task_id" json:"task_id"`
+	Title        string `db:"title" json:"title"`
+	TotalSeconds int64  `db:"total_seconds" json:"total_seconds"`
+}
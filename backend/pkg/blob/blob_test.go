@@ -0,0 +1,103 @@
+package blob
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeS3 is a minimal in-memory stand-in for an S3-compatible server
+type fakeS3 struct {
+	mu      sync.Mutex
+	objects map[string][]byte
+	t       *testing.T
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	auth := r.Header.Get("Authorization")
+	assert.True(f.t, strings.HasPrefix(auth, "AWS4-HMAC-SHA256 Credential=AKID/20260102/eu-west-1/s3/aws4_request, SignedHeaders="), auth)
+	assert.Equal(f.t, "20260102T030405Z", r.Header.Get("X-Amz-Date"))
+	assert.Equal(f.t, unsignedPayload, r.Header.Get("X-Amz-Content-Sha256"))
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	switch r.Method {
+	case http.MethodPut:
+		body, _ := io.ReadAll(r.Body)
+		f.objects[r.URL.EscapedPath()] = body
+	case http.MethodGet:
+		body, ok := f.objects[r.URL.EscapedPath()]
+		if !ok {
+			http.Error(w, "<Error><Code>NoSuchKey</Code></Error>", http.StatusNotFound)
+			return
+		}
+		w.Write(body)
+	case http.MethodDelete:
+		delete(f.objects, r.URL.EscapedPath())
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func TestS3Store(t *testing.T) {
+	fake := &fakeS3{objects: make(map[string][]byte), t: t}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	store, err := NewS3Store(S3Config{
+		Endpoint:  server.URL,
+		Region:    "eu-west-1",
+		Bucket:    "attachments",
+		AccessKey: "AKID",
+		SecretKey: "secret",
+	})
+	require.NoError(t, err)
+	store.now = func() time.Time { return time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC) }
+
+	testStore(t, store)
+
+	assert.Empty(t, fake.objects)
+}
+
+func TestLocalStore(t *testing.T) {
+	store, err := NewLocalStore(t.TempDir())
+	require.NoError(t, err)
+
+	testStore(t, store)
+
+	err = store.Put(context.Background(), "../escape", strings.NewReader("x"), 1, "text/plain")
+	assert.NoError(t, err, "keys are confined to the root directory")
+	_, err = store.path("")
+	assert.Error(t, err)
+}
+
+func testStore(t *testing.T, store BlobStore) {
+	ctx := context.Background()
+	key := "tasks/1/report final.txt"
+
+	_, err := store.Get(ctx, key)
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	require.NoError(t, store.Put(ctx, key, strings.NewReader("hello"), 5, "text/plain"))
+
+	r, err := store.Get(ctx, key)
+	require.NoError(t, err)
+	body, err := io.ReadAll(r)
+	r.Close()
+	require.NoError(t, err)
+	assert.Equal(t, "hello", string(body))
+
+	require.NoError(t, store.Delete(ctx, key))
+	require.NoError(t, store.Delete(ctx, key), "deleting a missing blob is not an error")
+
+	_, err = store.Get(ctx, key)
+	assert.ErrorIs(t, err, ErrNotFound)
+}
@@ -0,0 +1,93 @@
+// Package egress keeps requests to user supplied URLs, such as webhooks, from
+// reaching the server's own network.
+package egress
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"syscall"
+	"time"
+)
+
+// ErrForbiddenAddress is returned for addresses that are not public
+var ErrForbiddenAddress = errors.New("address is private, loopback or link-local")
+
+// Special purpose ranges not covered by the netip.Addr predicates
+var reserved = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),     // "this" network
+	netip.MustParsePrefix("100.64.0.0/10"), // carrier-grade NAT
+	netip.MustParsePrefix("192.0.0.0/24"),  // IETF protocol assignments
+	netip.MustParsePrefix("198.18.0.0/15"), // benchmarking
+	netip.MustParsePrefix("240.0.0.0/4"),   // reserved and broadcast
+	netip.MustParsePrefix("64:ff9b::/96"),  // NAT64, can embed any IPv4 address
+}
+
+// Allowed reports whether addr is a public unicast address
+func Allowed(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	if !addr.IsValid() || addr.IsUnspecified() || addr.IsLoopback() || addr.IsPrivate() ||
+		addr.IsLinkLocalUnicast() || addr.IsMulticast() {
+		return false
+	}
+	for _, prefix := range reserved {
+		if prefix.Contains(addr) {
+			return false
+		}
+	}
+	return true
+}
+
+// LookupFunc resolves a host name, like net.Resolver.LookupNetIP
+type LookupFunc func(ctx context.Context, network, host string) ([]netip.Addr, error)
+
+// CheckHost resolves host with lookup and fails with ErrForbiddenAddress if
+// any of its addresses is not Allowed. DNS can change before a connection is
+// made, so connections must be checked too, see Client.
+func CheckHost(ctx context.Context, lookup LookupFunc, host string) error {
+	var addrs []netip.Addr
+	if addr, err := netip.ParseAddr(host); err == nil {
+		addrs = []netip.Addr{addr}
+	} else if addrs, err = lookup(ctx, "ip", host); err != nil {
+		return err
+	}
+
+	for _, addr := range addrs {
+		if !Allowed(addr) {
+			return fmt.Errorf("%w: %s resolves to %s", ErrForbiddenAddress, host, addr)
+		}
+	}
+	return nil
+}
+
+// Control is a net.Dialer Control function that refuses to connect to
+// addresses that are not Allowed. It runs after the host name is resolved.
+func Control(network, address string, _ syscall.RawConn) error {
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return err
+	}
+	if !Allowed(addrPort.Addr()) {
+		return fmt.Errorf("%w: %s", ErrForbiddenAddress, addrPort.Addr())
+	}
+	return nil
+}
+
+// Client returns an HTTP client that only connects to Allowed addresses,
+// redirects included. It never uses a proxy, which would connect on its behalf.
+func Client(timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{Timeout: timeout, Control: Control}
+	return &http.Client{
+		Timeout: timeout,
+		Transport: &http.Transport{
+			DialContext:         dialer.DialContext,
+			ForceAttemptHTTP2:   true,
+			MaxIdleConns:        100,
+			IdleConnTimeout:     90 * time.Second,
+			TLSHandshakeTimeout: 10 * time.Second,
+		},
+	}
+}
@@ -0,0 +1,75 @@
+package egress
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAllowed(t *testing.T) {
+	tests := []struct {
+		addr    string
+		allowed bool
+	}{
+		{"93.184.216.34", true},
+		{"2606:2800:220:1:248:1893:25c8:1946", true},
+		{"127.0.0.1", false},
+		{"::1", false},
+		{"10.1.2.3", false},
+		{"172.16.0.1", false},
+		{"192.168.1.1", false},
+		{"169.254.169.254", false},
+		{"fe80::1", false},
+		{"fd00:ec2::254", false},
+		{"0.0.0.0", false},
+		{"::", false},
+		{"100.64.0.1", false},
+		{"224.0.0.1", false},
+		{"255.255.255.255", false},
+		{"::ffff:127.0.0.1", false},
+		{"::ffff:169.254.169.254", false},
+		{"64:ff9b::a9fe:a9fe", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.addr, func(t *testing.T) {
+			assert.Equal(t, tt.allowed, Allowed(netip.MustParseAddr(tt.addr)))
+		})
+	}
+}
+
+func TestCheckHost(t *testing.T) {
+	lookup := func(ctx context.Context, network, host string) ([]netip.Addr, error) {
+		switch host {
+		case "example.com":
+			return []netip.Addr{netip.MustParseAddr("93.184.216.34")}, nil
+		case "internal.example.com":
+			return []netip.Addr{netip.MustParseAddr("93.184.216.34"), netip.MustParseAddr("10.0.0.5")}, nil
+		}
+		return nil, errors.New("no such host")
+	}
+	ctx := context.Background()
+
+	assert.NoError(t, CheckHost(ctx, lookup, "example.com"))
+	assert.NoError(t, CheckHost(ctx, lookup, "93.184.216.34"))
+	assert.ErrorIs(t, CheckHost(ctx, lookup, "internal.example.com"), ErrForbiddenAddress)
+	assert.ErrorIs(t, CheckHost(ctx, lookup, "169.254.169.254"), ErrForbiddenAddress)
+	assert.ErrorIs(t, CheckHost(ctx, lookup, "::1"), ErrForbiddenAddress)
+	assert.EqualError(t, CheckHost(ctx, lookup, "missing.example.com"), "no such host")
+}
+
+func TestClient_RefusesLoopback(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("request reached the server")
+	}))
+	defer server.Close()
+
+	_, err := Client(time.Second).Get(server.URL)
+	assert.ErrorIs(t, err, ErrForbiddenAddress)
+}
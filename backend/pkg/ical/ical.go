@@ -0,0 +1,96 @@
+// Package ical writes iCalendar (RFC 5545) content
+package ical
+
+import (
+	"io"
+	"strings"
+	"time"
+)
+
+// maxLineOctets is the longest a content line may be before it is folded
+const maxLineOctets = 75
+
+var textEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	";", `\;`,
+	",", `\,`,
+	"\r\n", `\n`,
+	"\n", `\n`,
+	"\r", `\n`,
+)
+
+// Writer writes content lines, folding long ones. The first write error is
+// kept and returned by Err; later writes are skipped.
+type Writer struct {
+	w   io.Writer
+	err error
+}
+
+func NewWriter(w io.Writer) *Writer {
+	return &Writer{w: w}
+}
+
+// Begin starts a component such as VCALENDAR or VTODO
+func (w *Writer) Begin(component string) {
+	w.Property("BEGIN", component)
+}
+
+func (w *Writer) End(component string) {
+	w.Property("END", component)
+}
+
+// Property writes value as is
+func (w *Writer) Property(name, value string) {
+	w.writeLine(name + ":" + value)
+}
+
+// Text writes a TEXT value, escaping the characters RFC 5545 reserves
+func (w *Writer) Text(name, value string) {
+	w.Property(name, textEscaper.Replace(value))
+}
+
+// DateTime writes t as a UTC date-time
+func (w *Writer) DateTime(name string, t time.Time) {
+	w.Property(name, FormatDateTime(t))
+}
+
+func (w *Writer) Err() error {
+	return w.err
+}
+
+func (w *Writer) writeLine(line string) {
+	if w.err != nil {
+		return
+	}
+	_, w.err = io.WriteString(w.w, fold(line)+"\r\n")
+}
+
+// FormatDateTime formats t in the UTC form of an iCalendar DATE-TIME
+func FormatDateTime(t time.Time) string {
+	return t.UTC().Format("20060102T150405Z")
+}
+
+// fold splits line into chunks of at most maxLineOctets octets without
+// breaking UTF-8 sequences. Continuation lines start with a space.
+func fold(line string) string {
+	if len(line) <= maxLineOctets {
+		return line
+	}
+
+	var b strings.Builder
+	limit := maxLineOctets
+	for len(line) > limit {
+		cut := limit
+		// Step back to the start of a UTF-8 sequence
+		for cut > 0 && line[cut]&0xC0 == 0x80 {
+			cut--
+		}
+		b.WriteString(line[:cut])
+		b.WriteString("\r\n ")
+		line = line[cut:]
+		// The leading space counts towards the continuation line's length
+		limit = maxLineOctets - 1
+	}
+	b.WriteString(line)
+	return b.String()
+}
@@ -0,0 +1,51 @@
+package ical
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewWriter(&buf)
+	w.Begin("VTODO")
+	w.Text("SUMMARY", "Fix login; then deploy, maybe\nsecond line \\ done")
+	w.DateTime("DUE", time.Date(2026, 3, 2, 10, 30, 0, 0, time.FixedZone("CET", 3600)))
+	w.End("VTODO")
+	require.NoError(t, w.Err())
+
+	assert.Equal(t, "BEGIN:VTODO\r\n"+
+		`SUMMARY:Fix login\; then deploy\, maybe\nsecond line \\ done`+"\r\n"+
+		"DUE:20260302T093000Z\r\n"+
+		"END:VTODO\r\n", buf.String())
+}
+
+func TestFold(t *testing.T) {
+	tests := []struct {
+		name string
+		line string
+	}{
+		{name: "ASCII", line: "DESCRIPTION:" + strings.Repeat("a", 200)},
+		{name: "Multi-byte characters are not split", line: "SUMMARY:" + strings.Repeat("é", 100)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			folded := fold(tt.line)
+			lines := strings.Split(folded, "\r\n")
+			require.Greater(t, len(lines), 1)
+			for i, line := range lines {
+				assert.LessOrEqual(t, len(line), maxLineOctets)
+				if i > 0 {
+					assert.True(t, strings.HasPrefix(line, " "))
+				}
+			}
+			assert.Equal(t, tt.line, strings.ReplaceAll(folded, "\r\n ", ""))
+		})
+	}
+}
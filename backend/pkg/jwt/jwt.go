@@ -5,12 +5,13 @@ import (
 	"time"
 )
 
-func GenerateToken(userId uint, secret string) (string, error) {
+// GenerateToken signs a token for userId that expires after lifetime
+func GenerateToken(userId uint, secret string, lifetime time.Duration) (string, error) {
 	token := jwt.New(jwt.SigningMethodHS256)
 
 	claims := token.Claims.(jwt.MapClaims)
 	claims["user_id"] = userId
-	claims["exp"] = time.Now().Add(time.Hour * 72).Unix()
+	claims["exp"] = time.Now().Add(lifetime).Unix()
 
 	return token.SignedString([]byte(secret))
 }
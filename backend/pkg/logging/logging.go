@@ -0,0 +1,65 @@
+// Package logging creates the server's structured logger and carries the
+// logger of a request, tagged with its ID, through its context
+package logging
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"strings"
+)
+
+// Redacted replaces the values of sensitive attributes
+const Redacted = "[REDACTED]"
+
+// sensitiveKeys are parts of attribute keys whose values are never logged
+var sensitiveKeys = []string{"password", "token", "secret", "authorization", "cookie", "api_key"}
+
+// New returns a logger writing JSON lines to w, from level up. Attributes
+// named like passwords, tokens or secrets are redacted.
+func New(w io.Writer, level slog.Level) *slog.Logger {
+	return slog.New(slog.NewJSONHandler(w, &slog.HandlerOptions{
+		Level:       level,
+		ReplaceAttr: redact,
+	}))
+}
+
+// ParseLevel parses debug, info, warn or error
+func ParseLevel(level string) (slog.Level, error) {
+	var l slog.Level
+	err := l.UnmarshalText([]byte(level))
+	return l, err
+}
+
+func redact(_ []string, attr slog.Attr) slog.Attr {
+	if attr.Value.Kind() != slog.KindGroup && Sensitive(attr.Key) {
+		return slog.String(attr.Key, Redacted)
+	}
+	return attr
+}
+
+// Sensitive reports whether values named key must not be logged
+func Sensitive(key string) bool {
+	key = strings.ToLower(key)
+	for _, sensitive := range sensitiveKeys {
+		if strings.Contains(key, sensitive) {
+			return true
+		}
+	}
+	return false
+}
+
+type loggerKey struct{}
+
+// WithLogger returns a copy of ctx carrying logger
+func WithLogger(ctx context.Context, logger *slog.Logger) context.Context {
+	return context.WithValue(ctx, loggerKey{}, logger)
+}
+
+// FromContext returns the logger carried by ctx, or the default logger
+func FromContext(ctx context.Context) *slog.Logger {
+	if logger, ok := ctx.Value(loggerKey{}).(*slog.Logger); ok {
+		return logger
+	}
+	return slog.Default()
+}
@@ -0,0 +1,50 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew_Redacts(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New(&buf, slog.LevelInfo)
+
+	logger.Info("login",
+		"email", "a@example.com",
+		"password", "hunter22",
+		slog.Group("request", "Authorization", "Bearer abc", "access_token", "abc"),
+		"webhook_secret", "s3cret",
+	)
+	logger.Debug("hidden")
+
+	var line map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &line))
+	assert.Equal(t, "login", line["msg"])
+	assert.Equal(t, "a@example.com", line["email"])
+	assert.Equal(t, Redacted, line["password"])
+	assert.Equal(t, map[string]interface{}{"Authorization": Redacted, "access_token": Redacted}, line["request"])
+	assert.Equal(t, Redacted, line["webhook_secret"])
+	assert.NotContains(t, buf.String(), "hidden")
+}
+
+func TestFromContext(t *testing.T) {
+	assert.Same(t, slog.Default(), FromContext(context.Background()))
+
+	logger := New(&bytes.Buffer{}, slog.LevelInfo)
+	assert.Same(t, logger, FromContext(WithLogger(context.Background(), logger)))
+}
+
+func TestParseLevel(t *testing.T) {
+	level, err := ParseLevel("warn")
+	require.NoError(t, err)
+	assert.Equal(t, slog.LevelWarn, level)
+
+	_, err = ParseLevel("loud")
+	assert.Error(t, err)
+}
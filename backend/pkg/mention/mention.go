@@ -0,0 +1,25 @@
+package mention
+
+import "regexp"
+
+// mentionPattern matches @username where the @ is not part of a word or an
+// email address
+var mentionPattern = regexp.MustCompile(`(?:^|[^\w@.])@([A-Za-z0-9_][A-Za-z0-9_.-]*[A-Za-z0-9_]|[A-Za-z0-9_])`)
+
+// Parse returns the distinct usernames mentioned in text, in order of first
+// appearance
+func Parse(text string) []string {
+	var usernames []string
+	seen := make(map[string]bool)
+
+	for _, match := range mentionPattern.FindAllStringSubmatch(text, -1) {
+		username := match[1]
+		if seen[username] {
+			continue
+		}
+		seen[username] = true
+		usernames = append(usernames, username)
+	}
+
+	return usernames
+}
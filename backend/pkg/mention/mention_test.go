@@ -0,0 +1,29 @@
+package mention
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		expected []string
+	}{
+		{name: "No mentions", text: "Fix the login page", expected: nil},
+		{name: "Single mention", text: "@alice please review", expected: []string{"alice"}},
+		{name: "Trailing punctuation", text: "Thanks @bob. And @carol_1, too!", expected: []string{"bob", "carol_1"}},
+		{name: "Duplicates are collapsed", text: "@dave @dave @eve", expected: []string{"dave", "eve"}},
+		{name: "Email addresses are ignored", text: "mail ops@example.com", expected: nil},
+		{name: "Double at is ignored", text: "@@frank", expected: nil},
+		{name: "Dots inside usernames", text: "cc (@first.last)", expected: []string{"first.last"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Parse(tt.text))
+		})
+	}
+}
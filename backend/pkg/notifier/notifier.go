@@ -0,0 +1,31 @@
+package notifier
+
+import (
+	"backend/pkg/logging"
+	"context"
+)
+
+// Message is a notification addressed to a single recipient
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Notifier delivers messages over some channel (email, chat, ...)
+type Notifier interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+// LogNotifier writes messages to the logger of the context. It is meant for local
+// development where no real delivery channel is configured.
+type LogNotifier struct{}
+
+func NewLogNotifier() *LogNotifier {
+	return &LogNotifier{}
+}
+
+func (n *LogNotifier) Send(ctx context.Context, msg Message) error {
+	logging.FromContext(ctx).Info("notify", "to", msg.To, "subject", msg.Subject, "body", msg.Body)
+	return nil
+}
@@ -0,0 +1,26 @@
+package signature
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+)
+
+const prefix = "sha256="
+
+// Sign returns the HMAC-SHA256 of payload keyed by secret, formatted as
+// "sha256=<hex>"
+func Sign(secret string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return prefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify reports whether signature is a valid Sign result for payload
+func Verify(secret string, payload []byte, signature string) bool {
+	if !strings.HasPrefix(signature, prefix) {
+		return false
+	}
+	return hmac.Equal([]byte(Sign(secret, payload)), []byte(signature))
+}
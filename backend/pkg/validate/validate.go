@@ -0,0 +1,138 @@
+// Package validate checks request bodies against their `validate` struct tags
+// and reports every invalid field at once
+package validate
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// FieldError is an invalid field of a request body, named by its JSON key
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// Errors lists the invalid fields of a request body
+type Errors []FieldError
+
+func (e Errors) Error() string {
+	messages := make([]string, len(e))
+	for i, fieldErr := range e {
+		messages[i] = fieldErr.Field + " " + fieldErr.Message
+	}
+	return strings.Join(messages, "; ")
+}
+
+// usernamePattern is the username grammar of @mentions, so every user can be
+// mentioned
+var usernamePattern = regexp.MustCompile(`^[A-Za-z0-9_]([A-Za-z0-9_.-]*[A-Za-z0-9_])?$`)
+
+// Password limits. bcrypt ignores anything after 72 bytes.
+const (
+	minPasswordLength = 8
+	maxPasswordLength = 72
+)
+
+var validate = newValidator()
+
+func newValidator() *validator.Validate {
+	v := validator.New(validator.WithRequiredStructEnabled())
+	v.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			return ""
+		}
+		return name
+	})
+	v.RegisterValidation("notblank", func(fl validator.FieldLevel) bool {
+		return strings.TrimSpace(fl.Field().String()) != ""
+	})
+	v.RegisterValidation("username", func(fl validator.FieldLevel) bool {
+		return usernamePattern.MatchString(fl.Field().String())
+	})
+	v.RegisterValidation("password", func(fl validator.FieldLevel) bool {
+		return strongPassword(fl.Field().String())
+	})
+	return v
+}
+
+// strongPassword reports whether password is long enough and mixes letters
+// and digits
+func strongPassword(password string) bool {
+	if len(password) < minPasswordLength || len(password) > maxPasswordLength {
+		return false
+	}
+	var letter, digit bool
+	for _, r := range password {
+		letter = letter || unicode.IsLetter(r)
+		digit = digit || unicode.IsDigit(r)
+	}
+	return letter && digit
+}
+
+// Struct validates s, which must be a struct or a pointer to one. It returns
+// Errors when fields are invalid.
+//
+// Supported tags besides the validator package's own:
+//
+//	notblank  not empty or only white space
+//	username  letters, digits, '_', '.' and '-', as in @mentions
+//	password  8 to 72 bytes with at least one letter and one digit
+func Struct(s interface{}) error {
+	err := validate.Struct(s)
+	var invalid validator.ValidationErrors
+	if !errors.As(err, &invalid) {
+		return err
+	}
+
+	errs := make(Errors, len(invalid))
+	for i, fieldErr := range invalid {
+		errs[i] = FieldError{Field: fieldName(fieldErr), Message: message(fieldErr)}
+	}
+	return errs
+}
+
+// fieldName is the JSON path of the field, without the struct's own name
+func fieldName(fieldErr validator.FieldError) string {
+	_, name, found := strings.Cut(fieldErr.Namespace(), ".")
+	if !found {
+		return fieldErr.Field()
+	}
+	return name
+}
+
+func message(fieldErr validator.FieldError) string {
+	param := fieldErr.Param()
+	switch fieldErr.Tag() {
+	case "required":
+		return "is required"
+	case "notblank":
+		return "must not be blank"
+	case "email":
+		return "must be a valid email address"
+	case "username":
+		return "may only contain letters, digits, '_', '.' and '-', and must not start or end with '.' or '-'"
+	case "password":
+		return fmt.Sprintf("must be %d to %d characters and contain a letter and a digit", minPasswordLength, maxPasswordLength)
+	case "oneof":
+		return "must be one of " + strings.Join(strings.Fields(param), ", ")
+	case "min":
+		if fieldErr.Kind() == reflect.String {
+			return "must be at least " + param + " characters"
+		}
+		return "must be at least " + param
+	case "max":
+		if fieldErr.Kind() == reflect.String {
+			return "must be at most " + param + " characters"
+		}
+		return "must be at most " + param
+	}
+	return "is invalid"
+}
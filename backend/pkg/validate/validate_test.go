@@ -0,0 +1,68 @@
+package validate
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testRequest struct {
+	Name     string         `json:"name" validate:"required,notblank,max=5"`
+	Username string         `json:"username" validate:"omitempty,username"`
+	Password string         `json:"password" validate:"omitempty,password"`
+	Count    int            `json:"count" validate:"min=1,max=3"`
+	Kind     string         `json:"kind" validate:"omitempty,oneof=a b"`
+	Inner    *testInnerBody `json:"inner" validate:"omitempty"`
+}
+
+type testInnerBody struct {
+	Email string `json:"email" validate:"email"`
+}
+
+func TestStruct(t *testing.T) {
+	assert.NoError(t, Struct(&testRequest{Name: "x", Username: "first.last", Password: "hunter22", Count: 2}))
+
+	err := Struct(&testRequest{Name: "too long", Count: 4, Kind: "c", Inner: &testInnerBody{Email: "nope"}})
+	var errs Errors
+	require.ErrorAs(t, err, &errs)
+	assert.Equal(t, Errors{
+		{Field: "name", Message: "must be at most 5 characters"},
+		{Field: "count", Message: "must be at most 3"},
+		{Field: "kind", Message: "must be one of a, b"},
+		{Field: "inner.email", Message: "must be a valid email address"},
+	}, errs)
+	assert.Equal(t, "name must be at most 5 characters; count must be at most 3; kind must be one of a, b; inner.email must be a valid email address", err.Error())
+}
+
+func TestStruct_Username(t *testing.T) {
+	for username, valid := range map[string]bool{
+		"alice":   true,
+		"carol_1": true,
+		"a.b-c":   true,
+		"_x":      true,
+		".alice":  false,
+		"alice-":  false,
+		"al ice":  false,
+		"al@ice":  false,
+	} {
+		err := Struct(testRequest{Name: "x", Count: 1, Username: username})
+		assert.Equal(t, valid, err == nil, username)
+	}
+}
+
+func TestStruct_Password(t *testing.T) {
+	for password, valid := range map[string]bool{
+		"password123":                 true,
+		"pässwört1":                   true,
+		"short1":                      false,
+		"password":                    false,
+		"12345678":                    false,
+		strings.Repeat("a", 71) + "1": true,
+		strings.Repeat("a", 72) + "1": false,
+	} {
+		err := Struct(testRequest{Name: "x", Count: 1, Password: password})
+		assert.Equal(t, valid, err == nil, password)
+	}
+}
@@ -0,0 +1,106 @@
+package realtime
+
+import (
+	"encoding/json"
+	"sync"
+)
+
+// Message is an event addressed to the users allowed to see it
+type Message struct {
+	Event   string          `json:"event"`
+	Data    json.RawMessage `json:"data"`
+	UserIDs []int64         `json:"user_ids"`
+}
+
+// Publisher accepts messages for delivery to subscribers
+type Publisher interface {
+	Publish(msg Message)
+}
+
+// subscriberBuffer is how many messages a slow subscriber may fall behind
+// before further messages to it are dropped
+const subscriberBuffer = 32
+
+type subscriber struct {
+	ch chan Message
+}
+
+// Hub fans messages out to the in-process subscribers of each user.
+type Hub struct {
+	mu          sync.RWMutex
+	subscribers map[int64]map[*subscriber]struct{}
+	closed      bool
+}
+
+func NewHub() *Hub {
+	return &Hub{subscribers: make(map[int64]map[*subscriber]struct{})}
+}
+
+// Subscribe registers a subscriber for userID. The returned function removes
+// it and closes the channel. The channel of a closed hub is closed already.
+func (h *Hub) Subscribe(userID int64) (<-chan Message, func()) {
+	sub := &subscriber{ch: make(chan Message, subscriberBuffer)}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.closed {
+		close(sub.ch)
+		return sub.ch, func() {}
+	}
+	if h.subscribers[userID] == nil {
+		h.subscribers[userID] = make(map[*subscriber]struct{})
+	}
+	h.subscribers[userID][sub] = struct{}{}
+
+	unsubscribe := func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		// Already removed by an earlier call or by Close
+		if _, ok := h.subscribers[userID][sub]; !ok {
+			return
+		}
+		delete(h.subscribers[userID], sub)
+		if len(h.subscribers[userID]) == 0 {
+			delete(h.subscribers, userID)
+		}
+		close(sub.ch)
+	}
+
+	return sub.ch, unsubscribe
+}
+
+// Close closes the channel of every subscriber, which ends their streams so
+// the server can shut down
+func (h *Hub) Close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.closed = true
+	for userID, subs := range h.subscribers {
+		for sub := range subs {
+			close(sub.ch)
+		}
+		delete(h.subscribers, userID)
+	}
+}
+
+// Publish delivers msg to every subscriber of the addressed users without
+// blocking.
+func (h *Hub) Publish(msg Message) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	seen := make(map[int64]bool, len(msg.UserIDs))
+	for _, userID := range msg.UserIDs {
+		if seen[userID] {
+			continue
+		}
+		seen[userID] = true
+
+		for sub := range h.subscribers[userID] {
+			select {
+			case sub.ch <- msg:
+			default:
+			}
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package realtime
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHub_PublishRoutesByUser(t *testing.T) {
+	hub := NewHub()
+
+	alice, unsubscribeAlice := hub.Subscribe(1)
+	defer unsubscribeAlice()
+	bob, unsubscribeBob := hub.Subscribe(2)
+	defer unsubscribeBob()
+
+	hub.Publish(Message{Event: "task.created", Data: []byte(`{"id":1}`), UserIDs: []int64{1, 1}})
+
+	assert.Len(t, alice, 1, "duplicate user IDs must not deliver twice")
+	assert.Len(t, bob, 0)
+
+	msg := <-alice
+	assert.Equal(t, "task.created", msg.Event)
+	assert.JSONEq(t, `{"id":1}`, string(msg.Data))
+}
+
+func TestHub_UnsubscribeClosesChannel(t *testing.T) {
+	hub := NewHub()
+
+	messages, unsubscribe := hub.Subscribe(1)
+	unsubscribe()
+	unsubscribe()
+
+	_, ok := <-messages
+	assert.False(t, ok)
+
+	// Publishing to a user without subscribers is a no-op
+	hub.Publish(Message{Event: "task.deleted", UserIDs: []int64{1}})
+}
+
+func TestHub_SlowSubscriberDoesNotBlock(t *testing.T) {
+	hub := NewHub()
+
+	messages, unsubscribe := hub.Subscribe(1)
+	defer unsubscribe()
+
+	for i := 0; i < subscriberBuffer+10; i++ {
+		hub.Publish(Message{Event: "task.updated", UserIDs: []int64{1}})
+	}
+
+	assert.Len(t, messages, subscriberBuffer)
+}
+
+func TestHub_Close(t *testing.T) {
+	hub := NewHub()
+
+	messages, unsubscribe := hub.Subscribe(1)
+	hub.Close()
+	_, ok := <-messages
+	assert.False(t, ok)
+	unsubscribe()
+
+	// Subscribing afterwards ends right away
+	messages, unsubscribe = hub.Subscribe(2)
+	defer unsubscribe()
+	_, ok = <-messages
+	assert.False(t, ok)
+	hub.Publish(Message{Event: "task.deleted", UserIDs: []int64{1, 2}})
+}
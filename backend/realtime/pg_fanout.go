@@ -0,0 +1,108 @@
+package realtime
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"log/slog"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+// notifyChannel is the PostgreSQL channel shared by all server instances
+const notifyChannel = "task_events"
+
+// maxNotifyPayload stays under PostgreSQL's 8000 byte NOTIFY payload limit
+const maxNotifyPayload = 7900
+
+type envelope struct {
+	Origin  string  `json:"origin"`
+	Message Message `json:"message"`
+}
+
+// PGFanout publishes messages to the local hub and, through LISTEN/NOTIFY, to
+// the hubs of every other server instance connected to the same database.
+type PGFanout struct {
+	hub      *Hub
+	db       sqlx.DB
+	listener *pq.Listener
+	origin   string
+	done     chan struct{}
+}
+
+func NewPGFanout(hub *Hub, db sqlx.DB, dsn string) (*PGFanout, error) {
+	origin := make([]byte, 8)
+	if _, err := rand.Read(origin); err != nil {
+		return nil, err
+	}
+
+	listener := pq.NewListener(dsn, time.Second, time.Minute, func(event pq.ListenerEventType, err error) {
+		if err != nil {
+			slog.Error("task event listener failed", "error", err)
+		}
+	})
+	if err := listener.Listen(notifyChannel); err != nil {
+		listener.Close()
+		return nil, err
+	}
+
+	f := &PGFanout{
+		hub:      hub,
+		db:       db,
+		listener: listener,
+		origin:   hex.EncodeToString(origin),
+		done:     make(chan struct{}),
+	}
+	go f.receive()
+
+	return f, nil
+}
+
+func (f *PGFanout) Publish(msg Message) {
+	f.hub.Publish(msg)
+
+	payload, err := json.Marshal(envelope{Origin: f.origin, Message: msg})
+	if err != nil {
+		slog.Error("encode task event notification failed", "event", msg.Event, "error", err)
+		return
+	}
+	if len(payload) > maxNotifyPayload {
+		slog.Warn("task event notification too large for NOTIFY, delivered locally only", "event", msg.Event, "bytes", len(payload))
+		return
+	}
+
+	if _, err := f.db.Exec(`SELECT pg_notify($1, $2)`, notifyChannel, string(payload)); err != nil {
+		slog.Error("notify task event failed", "event", msg.Event, "error", err)
+	}
+}
+
+func (f *PGFanout) receive() {
+	defer close(f.done)
+
+	for notification := range f.listener.NotificationChannel() {
+		// A nil notification means the connection was re-established and
+		// some notifications may have been missed
+		if notification == nil {
+			continue
+		}
+
+		var env envelope
+		if err := json.Unmarshal([]byte(notification.Extra), &env); err != nil {
+			slog.Error("decode task event notification failed", "error", err)
+			continue
+		}
+		if env.Origin == f.origin {
+			continue
+		}
+		f.hub.Publish(env.Message)
+	}
+}
+
+// Close stops listening for notifications from other instances
+func (f *PGFanout) Close() error {
+	err := f.listener.Close()
+	<-f.done
+	return err
+}
@@ -0,0 +1,71 @@
+//go:generate mockgen -destination=mocks/mock_attachment_repository.go -package=mocks backend/repositories AttachmentRepositoryInterface
+
+package repositories
+
+import (
+	"backend/models"
+	"context"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type AttachmentRepository struct {
+	db sqlx.DB
+}
+
+func NewAttachmentRepository(db sqlx.DB) AttachmentRepositoryInterface {
+	return &AttachmentRepository{db: db}
+}
+
+// Interface
+type AttachmentRepositoryInterface interface {
+	Create(ctx context.Context, attachment *models.Attachment) error
+	Get(ctx context.Context, id int64) (*models.Attachment, error)
+	ListByTaskID(ctx context.Context, taskID int64) ([]models.Attachment, error)
+	Delete(ctx context.Context, id int64) error
+}
+
+const attachmentColumns = `id, task_id, uploader_id, filename, content_type, size_bytes, checksum_sha256, storage_key, created_at`
+
+func (r *AttachmentRepository) Create(ctx context.Context, attachment *models.Attachment) error {
+	defer observeQuery("AttachmentRepository", "Create")()
+	return r.db.QueryRowxContext(ctx, `
+		INSERT INTO attachments (task_id, uploader_id, filename, content_type, size_bytes, checksum_sha256, storage_key, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, NOW())
+		RETURNING id, created_at
+	`,
+		attachment.TaskID,
+		attachment.UploaderID,
+		attachment.Filename,
+		attachment.ContentType,
+		attachment.SizeBytes,
+		attachment.ChecksumSHA256,
+		attachment.StorageKey,
+	).StructScan(attachment)
+}
+
+func (r *AttachmentRepository) Get(ctx context.Context, id int64) (*models.Attachment, error) {
+	defer observeQuery("AttachmentRepository", "Get")()
+	var attachment models.Attachment
+	err := r.db.GetContext(ctx, &attachment, "SELECT "+attachmentColumns+" FROM attachments WHERE id = $1", id)
+	if err != nil {
+		return nil, err
+	}
+	return &attachment, nil
+}
+
+func (r *AttachmentRepository) ListByTaskID(ctx context.Context, taskID int64) ([]models.Attachment, error) {
+	defer observeQuery("AttachmentRepository", "ListByTaskID")()
+	attachments := []models.Attachment{}
+	err := r.db.SelectContext(ctx, &attachments, "SELECT "+attachmentColumns+" FROM attachments WHERE task_id = $1 ORDER BY created_at, id", taskID)
+	if err != nil {
+		return nil, err
+	}
+	return attachments, nil
+}
+
+func (r *AttachmentRepository) Delete(ctx context.Context, id int64) error {
+	defer observeQuery("AttachmentRepository", "Delete")()
+	_, err := r.db.ExecContext(ctx, `DELETE FROM attachments WHERE id = $1`, id)
+	return err
+}
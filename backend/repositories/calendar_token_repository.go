@@ -0,0 +1,75 @@
+//go:generate mockgen -destination=mocks/mock_calendar_token_repository.go -package=mocks backend/repositories CalendarTokenRepositoryInterface
+
+package repositories
+
+import (
+	"backend/models"
+	"context"
+	"database/sql"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type CalendarTokenRepository struct {
+	db sqlx.DB
+}
+
+func NewCalendarTokenRepository(db sqlx.DB) CalendarTokenRepositoryInterface {
+	return &CalendarTokenRepository{db: db}
+}
+
+// Interface
+type CalendarTokenRepositoryInterface interface {
+	Upsert(ctx context.Context, token *models.CalendarToken) error
+	GetByUserID(ctx context.Context, userID int64) (*models.CalendarToken, error)
+	GetByHash(ctx context.Context, tokenHash string) (*models.CalendarToken, error)
+	Delete(ctx context.Context, userID int64) error
+}
+
+// Upsert stores the user's token, replacing any previous one
+func (r *CalendarTokenRepository) Upsert(ctx context.Context, token *models.CalendarToken) error {
+	defer observeQuery("CalendarTokenRepository", "Upsert")()
+	return r.db.QueryRowxContext(ctx, `
+		INSERT INTO calendar_tokens (user_id, token_hash, created_at)
+		VALUES ($1, $2, NOW())
+		ON CONFLICT (user_id) DO UPDATE SET token_hash = EXCLUDED.token_hash, created_at = EXCLUDED.created_at
+		RETURNING created_at
+	`, token.UserID, token.TokenHash).Scan(&token.CreatedAt)
+}
+
+func (r *CalendarTokenRepository) GetByUserID(ctx context.Context, userID int64) (*models.CalendarToken, error) {
+	defer observeQuery("CalendarTokenRepository", "GetByUserID")()
+	var token models.CalendarToken
+	err := r.db.GetContext(ctx, &token, "SELECT user_id, token_hash, created_at FROM calendar_tokens WHERE user_id = $1", userID)
+	if err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+func (r *CalendarTokenRepository) GetByHash(ctx context.Context, tokenHash string) (*models.CalendarToken, error) {
+	defer observeQuery("CalendarTokenRepository", "GetByHash")()
+	var token models.CalendarToken
+	err := r.db.GetContext(ctx, &token, "SELECT user_id, token_hash, created_at FROM calendar_tokens WHERE token_hash = $1", tokenHash)
+	if err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+// Delete returns sql.ErrNoRows if the user has no token
+func (r *CalendarTokenRepository) Delete(ctx context.Context, userID int64) error {
+	defer observeQuery("CalendarTokenRepository", "Delete")()
+	result, err := r.db.ExecContext(ctx, "DELETE FROM calendar_tokens WHERE user_id = $1", userID)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
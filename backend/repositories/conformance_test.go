@@ -0,0 +1,484 @@
+package repositories
+
+import (
+	"backend/models"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testStorage is one implementation of the repositories, empty at the start
+// of each test
+type testStorage struct {
+	Users      UserRepositoryInterface
+	Tasks      TaskRepositoryInterface
+	Transactor Transactor
+}
+
+// runConformanceTests checks that an implementation behaves like every other
+// one. newStorage is called once per test.
+func runConformanceTests(t *testing.T, newStorage func(t *testing.T) testStorage) {
+	tests := []struct {
+		name string
+		run  func(t *testing.T, s testStorage)
+	}{
+		{"Users", testUsers},
+		{"Duplicate users", testDuplicateUsers},
+		{"Task CRUD", testTaskCRUD},
+		{"Tasks by assigner", testTasksByAssigner},
+		{"Dashboard queries", testDashboardQueries},
+		{"ForEachVisible", testForEachVisible},
+		{"Many tasks", testManyTasks},
+		{"Task integrity", testTaskIntegrity},
+		{"Transactions", testTransactions},
+		{"Locking many tasks", testLockingManyTasks},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.run(t, newStorage(t))
+		})
+	}
+}
+
+func createTestUser(t *testing.T, s testStorage, username string) int64 {
+	t.Helper()
+	user := &models.User{Username: username, Email: username + "@example.com", Password: "hash"}
+	require.NoError(t, s.Users.Create(context.Background(), user))
+	return user.ID
+}
+
+func createTestTask(t *testing.T, s testStorage, task models.Task) *models.Task {
+	t.Helper()
+	if task.Status == "" {
+		task.Status = models.StatusToDo
+	}
+	if task.Priority == 0 {
+		task.Priority = int(models.PriorityMedium)
+	}
+	created, err := s.Tasks.Create(context.Background(), &task)
+	require.NoError(t, err)
+	return created
+}
+
+func taskIDs(tasks []models.Task) []int64 {
+	ids := []int64{}
+	for _, task := range tasks {
+		ids = append(ids, task.ID)
+	}
+	return ids
+}
+
+func testUsers(t *testing.T, s testStorage) {
+	ctx := context.Background()
+	user := &models.User{Username: "alice", Email: "alice@example.com", Password: "hash"}
+	require.NoError(t, s.Users.Create(ctx, user))
+	assert.NotZero(t, user.ID)
+	assert.False(t, user.CreatedAt.IsZero())
+
+	byUsername, err := s.Users.FindByUsername(ctx, "alice")
+	require.NoError(t, err)
+	assert.Equal(t, user.ID, byUsername.ID)
+	assert.Equal(t, "hash", byUsername.Password)
+
+	byEmail, err := s.Users.FindByEmail(ctx, "alice@example.com")
+	require.NoError(t, err)
+	assert.Equal(t, user.ID, byEmail.ID)
+
+	byID, err := s.Users.FindById(ctx, user.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "alice", byID.Username)
+
+	_, err = s.Users.FindByUsername(ctx, "bob")
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+	_, err = s.Users.FindByEmail(ctx, "bob@example.com")
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+	_, err = s.Users.FindById(ctx, user.ID+1000)
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func testDuplicateUsers(t *testing.T, s testStorage) {
+	ctx := context.Background()
+	createTestUser(t, s, "alice")
+
+	err := s.Users.Create(ctx, &models.User{Username: "alice", Email: "other@example.com", Password: "hash"})
+	assert.ErrorIs(t, err, ErrDuplicate)
+
+	err = s.Users.Create(ctx, &models.User{Username: "other", Email: "alice@example.com", Password: "hash"})
+	assert.ErrorIs(t, err, ErrDuplicate)
+
+	_, err = s.Users.FindByUsername(ctx, "other")
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func testTaskCRUD(t *testing.T, s testStorage) {
+	ctx := context.Background()
+	alice := createTestUser(t, s, "alice")
+	bob := createTestUser(t, s, "bob")
+	dueAt := time.Date(2030, 1, 2, 15, 4, 5, 0, time.UTC)
+	estimate := 90
+
+	created, err := s.Tasks.Create(ctx, &models.Task{
+		Title:                   "Write report",
+		Description:             "Quarterly",
+		Status:                  models.StatusToDo,
+		AssignerID:              &alice,
+		AssigneeID:              &bob,
+		Priority:                int(models.PriorityHigh),
+		DueAt:                   &dueAt,
+		OriginalEstimateMinutes: &estimate,
+	})
+	require.NoError(t, err)
+	assert.NotZero(t, created.ID)
+	assert.False(t, created.CreatedAt.IsZero())
+
+	task, err := s.Tasks.Get(ctx, created.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Write report", task.Title)
+	assert.Equal(t, "Quarterly", task.Description)
+	assert.Equal(t, &alice, task.AssignerID)
+	assert.Equal(t, &bob, task.AssigneeID)
+	require.NotNil(t, task.DueAt)
+	assert.True(t, dueAt.Equal(*task.DueAt))
+	assert.Equal(t, &estimate, task.OriginalEstimateMinutes)
+	assert.Nil(t, task.RemainingEstimateMinutes)
+
+	task.Status = models.StatusDone
+	task.AssigneeID = nil
+	updated, err := s.Tasks.Update(ctx, task)
+	require.NoError(t, err)
+	assert.Equal(t, task.ID, updated.ID)
+
+	task, err = s.Tasks.GetForUpdate(ctx, created.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.StatusDone, task.Status)
+	assert.Nil(t, task.AssigneeID)
+
+	_, err = s.Tasks.Update(ctx, &models.Task{ID: created.ID + 1000, Title: "Missing", Status: models.StatusToDo, Priority: 1})
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+
+	require.NoError(t, s.Tasks.Delete(ctx, created.ID))
+	_, err = s.Tasks.Get(ctx, created.ID)
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+
+	// Deleting a missing task is not an error
+	assert.NoError(t, s.Tasks.Delete(ctx, created.ID))
+}
+
+func testTasksByAssigner(t *testing.T, s testStorage) {
+	ctx := context.Background()
+	alice := createTestUser(t, s, "alice")
+	bob := createTestUser(t, s, "bob")
+	first := createTestTask(t, s, models.Task{Title: "First", AssignerID: &alice})
+	createTestTask(t, s, models.Task{Title: "Bob's", AssignerID: &bob, AssigneeID: &alice})
+	second := createTestTask(t, s, models.Task{Title: "Second", AssignerID: &alice, AssigneeID: &bob})
+
+	tasks, err := s.Tasks.GetTasksByAssignerID(ctx, alice)
+	require.NoError(t, err)
+	ids := taskIDs(tasks)
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	assert.Equal(t, []int64{first.ID, second.ID}, ids)
+
+	tasks, err = s.Tasks.GetTasksByAssignerID(ctx, bob+1000)
+	require.NoError(t, err)
+	assert.Empty(t, tasks)
+}
+
+func testDashboardQueries(t *testing.T, s testStorage) {
+	ctx := context.Background()
+	alice := createTestUser(t, s, "alice")
+	bob := createTestUser(t, s, "bob")
+	now := time.Date(2030, 6, 5, 12, 0, 0, 0, time.UTC)
+	at := func(d time.Duration) *time.Time {
+		t := now.Add(d)
+		return &t
+	}
+
+	overdue := createTestTask(t, s, models.Task{Title: "Overdue", AssignerID: &alice, DueAt: at(-time.Hour)})
+	soon := createTestTask(t, s, models.Task{Title: "Soon", AssignerID: &bob, AssigneeID: &alice, DueAt: at(time.Hour), Priority: int(models.PriorityHigh)})
+	later := createTestTask(t, s, models.Task{Title: "Later", AssignerID: &alice, AssigneeID: &alice, DueAt: at(48 * time.Hour)})
+	createTestTask(t, s, models.Task{Title: "Done", AssignerID: &alice, DueAt: at(-2 * time.Hour), Status: models.StatusDone})
+	createTestTask(t, s, models.Task{Title: "Next month", AssignerID: &alice, DueAt: at(30 * 24 * time.Hour)})
+	createTestTask(t, s, models.Task{Title: "No due date", AssignerID: &alice})
+	createTestTask(t, s, models.Task{Title: "Not visible", AssignerID: &bob, DueAt: at(-time.Hour)})
+
+	counts, err := s.Tasks.CountForUser(ctx, alice)
+	require.NoError(t, err)
+	sort.Slice(counts, func(i, j int) bool {
+		a, b := counts[i], counts[j]
+		if a.Role != b.Role {
+			return a.Role < b.Role
+		}
+		if a.Status != b.Status {
+			return a.Status < b.Status
+		}
+		return a.Priority < b.Priority
+	})
+	assert.Equal(t, []models.TaskCount{
+		{Role: "assigned", Status: models.StatusToDo, Priority: int(models.PriorityMedium), Count: 1},
+		{Role: "assigned", Status: models.StatusToDo, Priority: int(models.PriorityHigh), Count: 1},
+		{Role: "created", Status: models.StatusDone, Priority: int(models.PriorityMedium), Count: 1},
+		{Role: "created", Status: models.StatusToDo, Priority: int(models.PriorityMedium), Count: 4},
+	}, counts)
+
+	weekEnd := now.Add(72 * time.Hour)
+	overdueCount, dueThisWeek, err := s.Tasks.CountDueForUser(ctx, alice, now, weekEnd)
+	require.NoError(t, err)
+	assert.Equal(t, 1, overdueCount)
+	assert.Equal(t, 2, dueThisWeek)
+
+	tasks, err := s.Tasks.ListDueForUser(ctx, alice, nil, now, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{overdue.ID}, taskIDs(tasks))
+
+	tasks, err = s.Tasks.ListDueForUser(ctx, alice, &now, weekEnd, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{soon.ID, later.ID}, taskIDs(tasks))
+
+	tasks, err = s.Tasks.ListDueForUser(ctx, alice, &now, weekEnd, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{soon.ID}, taskIDs(tasks))
+
+	// Touch the first task so it becomes the most recently updated
+	time.Sleep(10 * time.Millisecond)
+	overdue.Title = "Overdue!"
+	_, err = s.Tasks.Update(ctx, overdue)
+	require.NoError(t, err)
+
+	tasks, err = s.Tasks.ListRecentlyUpdatedForUser(ctx, alice, 2)
+	require.NoError(t, err)
+	require.Len(t, tasks, 2)
+	assert.Equal(t, overdue.ID, tasks[0].ID)
+
+	tasks, err = s.Tasks.ListRecentlyUpdatedForUser(ctx, bob+1000, 10)
+	require.NoError(t, err)
+	assert.Empty(t, tasks)
+}
+
+func testForEachVisible(t *testing.T, s testStorage) {
+	ctx := context.Background()
+	alice := createTestUser(t, s, "alice")
+	bob := createTestUser(t, s, "bob")
+	mine := createTestTask(t, s, models.Task{Title: "Mine", AssignerID: &alice})
+	assigned := createTestTask(t, s, models.Task{Title: "Assigned", AssignerID: &bob, AssigneeID: &alice, Status: models.StatusInProgress})
+	delegated := createTestTask(t, s, models.Task{Title: "Delegated", AssignerID: &alice, AssigneeID: &bob, Priority: int(models.PriorityHigh)})
+	createTestTask(t, s, models.Task{Title: "Hidden", AssignerID: &bob})
+
+	collect := func(filter models.TaskFilter) []int64 {
+		t.Helper()
+		ids := []int64{}
+		err := s.Tasks.ForEachVisible(ctx, alice, filter, func(task *models.Task) error {
+			ids = append(ids, task.ID)
+			return nil
+		})
+		require.NoError(t, err)
+		return ids
+	}
+
+	inProgress := models.StatusInProgress
+	high := int(models.PriorityHigh)
+	assert.Equal(t, []int64{mine.ID, assigned.ID, delegated.ID}, collect(models.TaskFilter{}))
+	assert.Equal(t, []int64{assigned.ID}, collect(models.TaskFilter{Status: &inProgress}))
+	assert.Equal(t, []int64{delegated.ID}, collect(models.TaskFilter{Priority: &high}))
+	assert.Equal(t, []int64{delegated.ID}, collect(models.TaskFilter{AssigneeID: &bob}))
+
+	stop := errors.New("stop")
+	calls := 0
+	err := s.Tasks.ForEachVisible(ctx, alice, models.TaskFilter{}, func(task *models.Task) error {
+		calls++
+		return stop
+	})
+	assert.ErrorIs(t, err, stop)
+	assert.Equal(t, 1, calls)
+}
+
+func testManyTasks(t *testing.T, s testStorage) {
+	ctx := context.Background()
+	alice := createTestUser(t, s, "alice")
+
+	created, err := s.Tasks.CreateMany(ctx, []*models.Task{
+		{Title: "One", Status: models.StatusToDo, AssignerID: &alice, Priority: 1},
+		{Title: "Two", Status: models.StatusToDo, AssignerID: &alice, Priority: 2},
+	})
+	require.NoError(t, err)
+	require.Len(t, created, 2)
+	assert.NotEqual(t, created[0].ID, created[1].ID)
+
+	tasks, err := s.Tasks.GetMany(ctx, []int64{created[0].ID, created[1].ID, created[1].ID + 1000})
+	require.NoError(t, err)
+	assert.Len(t, tasks, 2)
+
+	for _, task := range created {
+		task.Status = models.StatusDone
+	}
+	updated, err := s.Tasks.UpdateMany(ctx, created)
+	require.NoError(t, err)
+	assert.Len(t, updated, 2)
+
+	// A missing task fails the whole update
+	created[0].Status = models.StatusInProgress
+	missing := &models.Task{ID: created[1].ID + 1000, Title: "Missing", Status: models.StatusToDo, Priority: 1}
+	_, err = s.Tasks.UpdateMany(ctx, []*models.Task{created[0], missing})
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+	task, err := s.Tasks.Get(ctx, created[0].ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.StatusDone, task.Status)
+
+	require.NoError(t, s.Tasks.DeleteMany(ctx, []int64{created[0].ID, created[1].ID}))
+	tasks, err = s.Tasks.GetMany(ctx, []int64{created[0].ID, created[1].ID})
+	require.NoError(t, err)
+	assert.Empty(t, tasks)
+}
+
+func testTaskIntegrity(t *testing.T, s testStorage) {
+	ctx := context.Background()
+	alice := createTestUser(t, s, "alice")
+	missing := alice + 1000
+	valid := func() *models.Task {
+		return &models.Task{Title: "Task", Status: models.StatusToDo, AssignerID: &alice, Priority: int(models.PriorityMedium)}
+	}
+
+	invalid := []struct {
+		name   string
+		modify func(task *models.Task)
+		err    error
+	}{
+		{"missing assignee", func(task *models.Task) { task.AssigneeID = &missing }, ErrForeignKey},
+		{"missing assigner", func(task *models.Task) { task.AssignerID = &missing }, ErrForeignKey},
+		{"unknown status", func(task *models.Task) { task.Status = "BLOCKED" }, ErrCheck},
+		{"priority too low", func(task *models.Task) { task.Priority = 0 }, ErrCheck},
+		{"priority too high", func(task *models.Task) { task.Priority = 4 }, ErrCheck},
+	}
+
+	existing := createTestTask(t, s, *valid())
+	for _, tt := range invalid {
+		task := valid()
+		tt.modify(task)
+		_, err := s.Tasks.Create(ctx, task)
+		assert.ErrorIs(t, err, tt.err, "create with %s", tt.name)
+
+		task.ID = existing.ID
+		_, err = s.Tasks.Update(ctx, task)
+		assert.ErrorIs(t, err, tt.err, "update with %s", tt.name)
+	}
+
+	// One invalid task fails the whole batch
+	bad := valid()
+	bad.AssigneeID = &missing
+	_, err := s.Tasks.CreateMany(ctx, []*models.Task{valid(), bad})
+	assert.ErrorIs(t, err, ErrForeignKey)
+	bad.ID = existing.ID
+	_, err = s.Tasks.UpdateMany(ctx, []*models.Task{bad})
+	assert.ErrorIs(t, err, ErrForeignKey)
+
+	tasks, err := s.Tasks.GetTasksByAssignerID(ctx, alice)
+	require.NoError(t, err)
+	require.Len(t, tasks, 1)
+	assert.Nil(t, tasks[0].AssigneeID)
+	assert.Equal(t, models.StatusToDo, tasks[0].Status)
+}
+
+func testTransactions(t *testing.T, s testStorage) {
+	ctx := context.Background()
+	alice := createTestUser(t, s, "alice")
+	task := createTestTask(t, s, models.Task{Title: "Original", AssignerID: &alice})
+
+	failed := errors.New("failed")
+	err := s.Transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		locked, err := s.Tasks.GetForUpdate(ctx, task.ID)
+		require.NoError(t, err)
+		locked.Title = "Changed"
+		_, err = s.Tasks.Update(ctx, locked)
+		require.NoError(t, err)
+		_, err = s.Tasks.Create(ctx, &models.Task{Title: "Created", Status: models.StatusToDo, AssignerID: &alice, Priority: 1})
+		require.NoError(t, err)
+
+		// Nested transactions join the outer one
+		return s.Transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+			return failed
+		})
+	})
+	assert.ErrorIs(t, err, failed)
+
+	got, err := s.Tasks.Get(ctx, task.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Original", got.Title)
+	tasks, err := s.Tasks.GetTasksByAssignerID(ctx, alice)
+	require.NoError(t, err)
+	assert.Len(t, tasks, 1)
+
+	err = s.Transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		locked, err := s.Tasks.GetForUpdate(ctx, task.ID)
+		if err != nil {
+			return err
+		}
+		locked.Title = "Committed"
+		_, err = s.Tasks.Update(ctx, locked)
+		return err
+	})
+	require.NoError(t, err)
+
+	got, err = s.Tasks.Get(ctx, task.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Committed", got.Title)
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	err = s.Transactor.WithinTransaction(cancelled, func(ctx context.Context) error {
+		return nil
+	})
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func testLockingManyTasks(t *testing.T, s testStorage) {
+	ctx := context.Background()
+	alice := createTestUser(t, s, "alice")
+	created, err := s.Tasks.CreateMany(ctx, []*models.Task{
+		{Title: "One", Status: models.StatusToDo, AssignerID: &alice, Priority: 1, RemainingEstimateMinutes: new(int)},
+		{Title: "Two", Status: models.StatusToDo, AssignerID: &alice, Priority: 1, RemainingEstimateMinutes: new(int)},
+	})
+	require.NoError(t, err)
+	ids := []int64{created[1].ID, created[0].ID, created[1].ID + 1000}
+
+	// Concurrent read-modify-writes of overlapping sets never lose an update
+	const workers = 10
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := s.Transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+				locked, err := s.Tasks.GetManyForUpdate(ctx, ids)
+				if err != nil {
+					return err
+				}
+				if len(locked) != 2 {
+					return fmt.Errorf("locked %d tasks, want 2", len(locked))
+				}
+				updates := make([]*models.Task, len(locked))
+				for i := range locked {
+					*locked[i].RemainingEstimateMinutes++
+					updates[i] = &locked[i]
+				}
+				_, err = s.Tasks.UpdateMany(ctx, updates)
+				return err
+			})
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+
+	tasks, err := s.Tasks.GetMany(ctx, ids)
+	require.NoError(t, err)
+	require.Len(t, tasks, 2)
+	for _, task := range tasks {
+		assert.Equal(t, workers, *task.RemainingEstimateMinutes, task.Title)
+	}
+}
@@ -0,0 +1,57 @@
+package repositories
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/lib/pq"
+	"modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
+)
+
+var (
+	// ErrDuplicate is returned when a write would break a unique constraint.
+	// The wrapping error names the constraint.
+	ErrDuplicate = errors.New("duplicate key")
+	// ErrForeignKey is returned when a write refers to a row that does not
+	// exist, such as a task assigned to a missing user
+	ErrForeignKey = errors.New("referenced row does not exist")
+	// ErrCheck is returned when a write would break a CHECK constraint, such
+	// as a task with an unknown status
+	ErrCheck = errors.New("check constraint violated")
+)
+
+// PostgreSQL error codes of integrity constraint violations
+const (
+	pqUniqueViolation     = "23505"
+	pqForeignKeyViolation = "23503"
+	pqCheckViolation      = "23514"
+)
+
+// mapError turns driver errors callers need to tell apart into the errors
+// of this package
+func mapError(err error) error {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		switch pqErr.Code {
+		case pqUniqueViolation:
+			return fmt.Errorf("%w: %s", ErrDuplicate, pqErr.Constraint)
+		case pqForeignKeyViolation:
+			return fmt.Errorf("%w: %s", ErrForeignKey, pqErr.Constraint)
+		case pqCheckViolation:
+			return fmt.Errorf("%w: %s", ErrCheck, pqErr.Constraint)
+		}
+	}
+	var sqliteErr *sqlite.Error
+	if errors.As(err, &sqliteErr) {
+		switch sqliteErr.Code() {
+		case sqlite3.SQLITE_CONSTRAINT_UNIQUE:
+			return fmt.Errorf("%w: %s", ErrDuplicate, sqliteErr.Error())
+		case sqlite3.SQLITE_CONSTRAINT_FOREIGNKEY:
+			return fmt.Errorf("%w: %s", ErrForeignKey, sqliteErr.Error())
+		case sqlite3.SQLITE_CONSTRAINT_CHECK:
+			return fmt.Errorf("%w: %s", ErrCheck, sqliteErr.Error())
+		}
+	}
+	return err
+}
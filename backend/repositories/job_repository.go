@@ -0,0 +1,119 @@
+//go:generate mockgen -destination=mocks/mock_job_repository.go -package=mocks backend/repositories JobRepositoryInterface
+
+package repositories
+
+import (
+	"backend/models"
+	"context"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type JobRepository struct {
+	db sqlx.DB
+}
+
+func NewJobRepository(db sqlx.DB) JobRepositoryInterface {
+	return &JobRepository{db: db}
+}
+
+// Interface
+type JobRepositoryInterface interface {
+	Enqueue(ctx context.Context, job *models.Job) (*models.Job, error)
+	Lease(ctx context.Context, workerID string, limit int, lease time.Duration) ([]models.Job, error)
+	Complete(ctx context.Context, id int64) error
+	Fail(ctx context.Context, id int64, lastError string, retryAt time.Time) error
+}
+
+func (r *JobRepository) Enqueue(ctx context.Context, job *models.Job) (*models.Job, error) {
+	defer observeQuery("JobRepository", "Enqueue")()
+	if job.Payload == "" {
+		job.Payload = "{}"
+	}
+	if job.MaxAttempts == 0 {
+		job.MaxAttempts = 5
+	}
+	if job.RunAt.IsZero() {
+		job.RunAt = time.Now()
+	}
+
+	err := r.db.QueryRowxContext(ctx, `
+		INSERT INTO jobs (type, payload, status, max_attempts, run_at, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, NOW(), NOW())
+		RETURNING id, status, attempts, created_at, updated_at
+	`,
+		job.Type,
+		job.Payload,
+		models.JobPending,
+		job.MaxAttempts,
+		job.RunAt,
+	).StructScan(job)
+	if err != nil {
+		return nil, err
+	}
+
+	return job, nil
+}
+
+// Lease claims up to limit runnable jobs for workerID. A job is runnable when it
+// is pending and due, or when a previous worker's lease on it has expired and
+// it has attempts left. Expired jobs without attempts left are parked as
+// failed, so a job that keeps crashing its worker is not retried forever.
+func (r *JobRepository) Lease(ctx context.Context, workerID string, limit int, lease time.Duration) ([]models.Job, error) {
+	defer observeQuery("JobRepository", "Lease")()
+	_, err := r.db.ExecContext(ctx, `
+		UPDATE jobs SET status = $1, last_error = $2, locked_by = NULL, locked_until = NULL, updated_at = NOW()
+		WHERE status = $3 AND locked_until < NOW() AND attempts >= max_attempts
+	`, models.JobFailed, "lease expired on the last attempt", models.JobRunning)
+	if err != nil {
+		return nil, err
+	}
+
+	var jobs []models.Job
+	err = r.db.SelectContext(ctx, &jobs, `
+		UPDATE jobs SET status = $1, attempts = attempts + 1, locked_by = $2,
+			locked_until = NOW() + make_interval(secs => $3), updated_at = NOW()
+		WHERE id IN (
+			SELECT id FROM jobs
+			WHERE (status = $4 AND run_at <= NOW())
+				OR (status = $1 AND locked_until < NOW() AND attempts < max_attempts)
+			ORDER BY run_at
+			LIMIT $5
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id, type, payload, status, attempts, max_attempts, run_at, locked_by, locked_until, last_error, created_at, updated_at
+	`,
+		models.JobRunning,
+		workerID,
+		lease.Seconds(),
+		models.JobPending,
+		limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return jobs, nil
+}
+
+func (r *JobRepository) Complete(ctx context.Context, id int64) error {
+	defer observeQuery("JobRepository", "Complete")()
+	_, err := r.db.ExecContext(ctx, `
+		UPDATE jobs SET status = $1, locked_by = NULL, locked_until = NULL, updated_at = NOW()
+		WHERE id = $2
+	`, models.JobDone, id)
+	return err
+}
+
+// Fail records a failed attempt. The job is rescheduled at retryAt unless it has
+// used up its attempts, in which case it is parked as failed.
+func (r *JobRepository) Fail(ctx context.Context, id int64, lastError string, retryAt time.Time) error {
+	defer observeQuery("JobRepository", "Fail")()
+	_, err := r.db.ExecContext(ctx, `
+		UPDATE jobs SET
+			status = CASE WHEN attempts >= max_attempts THEN $1 ELSE $2 END,
+			run_at = $3, last_error = $4, locked_by = NULL, locked_until = NULL, updated_at = NOW()
+		WHERE id = $5
+	`, models.JobFailed, models.JobPending, retryAt, lastError, id)
+	return err
+}
@@ -0,0 +1,133 @@
+package repositories
+
+import (
+	"backend/models"
+	"context"
+	"sync"
+	"time"
+)
+
+// MemoryStore keeps users and tasks in memory, for tests and for running the
+// server without a database. Its repositories behave like the PostgreSQL
+// ones: lookups of missing rows return sql.ErrNoRows, duplicate usernames
+// or emails return ErrDuplicate, and tasks referring to missing users or with
+// an invalid status or priority return ErrForeignKey or ErrCheck.
+//
+// All repositories of a store share one lock. A transaction holds it until
+// it ends, so transactions are serialized, and restores a snapshot of the
+// store when it is rolled back.
+type MemoryStore struct {
+	mu         sync.Mutex
+	users      map[int64]*models.User
+	tasks      map[int64]*models.Task
+	nextUserID int64
+	nextTaskID int64
+	now        func() time.Time
+}
+
+type memoryTxKey struct{}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		users: make(map[int64]*models.User),
+		tasks: make(map[int64]*models.Task),
+		now:   time.Now,
+	}
+}
+
+func (s *MemoryStore) Users() UserRepositoryInterface {
+	return &MemoryUserRepository{store: s}
+}
+
+func (s *MemoryStore) Tasks() TaskRepositoryInterface {
+	return &MemoryTaskRepository{store: s}
+}
+
+func (s *MemoryStore) Transactor() Transactor {
+	return s
+}
+
+// WithinTransaction implements Transactor
+func (s *MemoryStore) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) (err error) {
+	if s.inTransaction(ctx) {
+		return fn(ctx)
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	snapshot := s.snapshot()
+	defer func() {
+		if p := recover(); p != nil {
+			s.restore(snapshot)
+			panic(p)
+		}
+		if err != nil {
+			s.restore(snapshot)
+		}
+	}()
+
+	if err = fn(context.WithValue(ctx, memoryTxKey{}, s)); err != nil {
+		return err
+	}
+	// Like a database, refuse to commit once the caller has given up
+	return ctx.Err()
+}
+
+// lock takes the store lock unless ctx is in one of its transactions, which
+// already holds it, and returns the function releasing it
+func (s *MemoryStore) lock(ctx context.Context) (func(), error) {
+	if s.inTransaction(ctx) {
+		return func() {}, ctx.Err()
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	return s.mu.Unlock, nil
+}
+
+func (s *MemoryStore) inTransaction(ctx context.Context) bool {
+	store, _ := ctx.Value(memoryTxKey{}).(*MemoryStore)
+	return store == s
+}
+
+// timestamp returns the current time at the precision PostgreSQL stores
+func (s *MemoryStore) timestamp() time.Time {
+	return s.now().UTC().Truncate(time.Microsecond)
+}
+
+type memorySnapshot struct {
+	users      map[int64]*models.User
+	tasks      map[int64]*models.Task
+	nextUserID int64
+	nextTaskID int64
+}
+
+// snapshot copies the maps but not the rows, which are replaced rather than
+// modified in place
+func (s *MemoryStore) snapshot() memorySnapshot {
+	snapshot := memorySnapshot{
+		users:      make(map[int64]*models.User, len(s.users)),
+		tasks:      make(map[int64]*models.Task, len(s.tasks)),
+		nextUserID: s.nextUserID,
+		nextTaskID: s.nextTaskID,
+	}
+	for id, user := range s.users {
+		snapshot.users[id] = user
+	}
+	for id, task := range s.tasks {
+		snapshot.tasks[id] = task
+	}
+	return snapshot
+}
+
+func (s *MemoryStore) restore(snapshot memorySnapshot) {
+	s.users = snapshot.users
+	s.tasks = snapshot.tasks
+	s.nextUserID = snapshot.nextUserID
+	s.nextTaskID = snapshot.nextTaskID
+}
@@ -0,0 +1,84 @@
+package repositories
+
+import (
+	"backend/models"
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryStore(t *testing.T) {
+	runConformanceTests(t, func(t *testing.T) testStorage {
+		store := NewMemoryStore()
+		return testStorage{Users: store.Users(), Tasks: store.Tasks(), Transactor: store.Transactor()}
+	})
+}
+
+func TestMemoryStore_Concurrent(t *testing.T) {
+	store := NewMemoryStore()
+	users, tasks, tx := store.Users(), store.Tasks(), store.Transactor()
+	ctx := context.Background()
+
+	owner := &models.User{Username: "owner", Email: "owner@example.com"}
+	require.NoError(t, users.Create(ctx, owner))
+	task, err := tasks.Create(ctx, &models.Task{Title: "Counter", Status: models.StatusToDo, AssignerID: &owner.ID, Priority: 1, RemainingEstimateMinutes: new(int)})
+	require.NoError(t, err)
+
+	const workers = 20
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			assert.NoError(t, users.Create(ctx, &models.User{Username: fmt.Sprintf("user%d", i), Email: fmt.Sprintf("user%d@example.com", i)}))
+
+			// Read-modify-write in a transaction never loses an increment
+			err := tx.WithinTransaction(ctx, func(ctx context.Context) error {
+				current, err := tasks.GetForUpdate(ctx, task.ID)
+				if err != nil {
+					return err
+				}
+				*current.RemainingEstimateMinutes++
+				_, err = tasks.Update(ctx, current)
+				return err
+			})
+			assert.NoError(t, err)
+		}(i)
+	}
+	wg.Wait()
+
+	got, err := tasks.Get(ctx, task.ID)
+	require.NoError(t, err)
+	assert.Equal(t, workers, *got.RemainingEstimateMinutes)
+
+	ids := make(map[int64]bool)
+	for i := 0; i < workers; i++ {
+		user, err := users.FindByUsername(ctx, fmt.Sprintf("user%d", i))
+		require.NoError(t, err)
+		ids[user.ID] = true
+	}
+	assert.Len(t, ids, workers)
+}
+
+func TestMemoryStore_ReturnsCopies(t *testing.T) {
+	store := NewMemoryStore()
+	users, tasks := store.Users(), store.Tasks()
+	ctx := context.Background()
+
+	assignee := &models.User{Username: "assignee", Email: "assignee@example.com"}
+	require.NoError(t, users.Create(ctx, assignee))
+	task, err := tasks.Create(ctx, &models.Task{Title: "Original", Status: models.StatusToDo, AssigneeID: &assignee.ID, Priority: 1})
+	require.NoError(t, err)
+
+	task.Title = "Changed"
+	*task.AssigneeID = 2
+
+	got, err := tasks.Get(ctx, task.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Original", got.Title)
+	assert.Equal(t, assignee.ID, *got.AssigneeID)
+}
@@ -0,0 +1,363 @@
+package repositories
+
+import (
+	"backend/models"
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// MemoryTaskRepository is a TaskRepositoryInterface backed by a MemoryStore
+type MemoryTaskRepository struct {
+	store *MemoryStore
+}
+
+func (r *MemoryTaskRepository) Create(ctx context.Context, task *models.Task) (*models.Task, error) {
+	unlock, err := r.store.lock(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	if err := r.check(task); err != nil {
+		return nil, err
+	}
+	return r.insert(task), nil
+}
+
+func (r *MemoryTaskRepository) Update(ctx context.Context, task *models.Task) (*models.Task, error) {
+	unlock, err := r.store.lock(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	return r.replace(task)
+}
+
+func (r *MemoryTaskRepository) Get(ctx context.Context, id int64) (*models.Task, error) {
+	unlock, err := r.store.lock(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	task, ok := r.store.tasks[id]
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+	return cloneTask(task), nil
+}
+
+// GetForUpdate is Get. A transaction already holds the whole store.
+func (r *MemoryTaskRepository) GetForUpdate(ctx context.Context, id int64) (*models.Task, error) {
+	return r.Get(ctx, id)
+}
+
+func (r *MemoryTaskRepository) Delete(ctx context.Context, id int64) error {
+	unlock, err := r.store.lock(ctx)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	delete(r.store.tasks, id)
+	return nil
+}
+
+func (r *MemoryTaskRepository) GetTasksByAssignerID(ctx context.Context, assignerID int64) ([]models.Task, error) {
+	tasks, err := r.list(ctx, func(task *models.Task) bool {
+		return task.AssignerID != nil && *task.AssignerID == assignerID
+	})
+	if len(tasks) == 0 {
+		// Like sqlx.Select, which leaves the slice nil without rows
+		return nil, err
+	}
+	return tasks, err
+}
+
+func (r *MemoryTaskRepository) CountForUser(ctx context.Context, userID int64) ([]models.TaskCount, error) {
+	tasks, err := r.list(ctx, func(task *models.Task) bool { return taskVisibleTo(task, userID) })
+	if err != nil {
+		return nil, err
+	}
+
+	counts := []models.TaskCount{}
+	index := make(map[models.TaskCount]int)
+	add := func(role string, task *models.Task) {
+		key := models.TaskCount{Role: role, Status: task.Status, Priority: task.Priority}
+		i, ok := index[key]
+		if !ok {
+			i = len(counts)
+			index[key] = i
+			counts = append(counts, key)
+		}
+		counts[i].Count++
+	}
+	for i := range tasks {
+		if tasks[i].AssigneeID != nil && *tasks[i].AssigneeID == userID {
+			add("assigned", &tasks[i])
+		}
+		if tasks[i].AssignerID != nil && *tasks[i].AssignerID == userID {
+			add("created", &tasks[i])
+		}
+	}
+	return counts, nil
+}
+
+func (r *MemoryTaskRepository) CountDueForUser(ctx context.Context, userID int64, now, weekEnd time.Time) (overdue int, dueThisWeek int, err error) {
+	tasks, err := r.list(ctx, func(task *models.Task) bool {
+		return taskVisibleTo(task, userID) && task.Status != models.StatusDone && task.DueAt != nil
+	})
+	for _, task := range tasks {
+		switch {
+		case task.DueAt.Before(now):
+			overdue++
+		case task.DueAt.Before(weekEnd):
+			dueThisWeek++
+		}
+	}
+	return overdue, dueThisWeek, err
+}
+
+func (r *MemoryTaskRepository) ListDueForUser(ctx context.Context, userID int64, from *time.Time, to time.Time, limit int) ([]models.Task, error) {
+	tasks, err := r.list(ctx, func(task *models.Task) bool {
+		return taskVisibleTo(task, userID) && task.Status != models.StatusDone &&
+			task.DueAt != nil && task.DueAt.Before(to) && (from == nil || !task.DueAt.Before(*from))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(tasks, func(i, j int) bool {
+		return tasks[i].DueAt.Before(*tasks[j].DueAt)
+	})
+	return limitTasks(tasks, limit), nil
+}
+
+func (r *MemoryTaskRepository) ListRecentlyUpdatedForUser(ctx context.Context, userID int64, limit int) ([]models.Task, error) {
+	tasks, err := r.list(ctx, func(task *models.Task) bool { return taskVisibleTo(task, userID) })
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(tasks, func(i, j int) bool {
+		if !tasks[i].UpdatedAt.Equal(tasks[j].UpdatedAt) {
+			return tasks[i].UpdatedAt.After(tasks[j].UpdatedAt)
+		}
+		return tasks[i].ID > tasks[j].ID
+	})
+	return limitTasks(tasks, limit), nil
+}
+
+// ForEachVisible copies the matching tasks before calling fn so that fn can
+// use the store's other repositories
+func (r *MemoryTaskRepository) ForEachVisible(ctx context.Context, userID int64, filter models.TaskFilter, fn func(task *models.Task) error) error {
+	tasks, err := r.list(ctx, func(task *models.Task) bool {
+		return taskVisibleTo(task, userID) &&
+			(filter.Status == nil || task.Status == *filter.Status) &&
+			(filter.Priority == nil || task.Priority == *filter.Priority) &&
+			(filter.AssigneeID == nil || (task.AssigneeID != nil && *task.AssigneeID == *filter.AssigneeID))
+	})
+	if err != nil {
+		return err
+	}
+
+	for i := range tasks {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := fn(&tasks[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *MemoryTaskRepository) CreateMany(ctx context.Context, tasks []*models.Task) ([]*models.Task, error) {
+	unlock, err := r.store.lock(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	for _, task := range tasks {
+		if err := r.check(task); err != nil {
+			return nil, err
+		}
+	}
+
+	created := make([]*models.Task, 0, len(tasks))
+	for _, task := range tasks {
+		created = append(created, r.insert(task))
+	}
+	return created, nil
+}
+
+func (r *MemoryTaskRepository) GetMany(ctx context.Context, ids []int64) ([]models.Task, error) {
+	wanted := make(map[int64]bool, len(ids))
+	for _, id := range ids {
+		wanted[id] = true
+	}
+	tasks, err := r.list(ctx, func(task *models.Task) bool { return wanted[task.ID] })
+	if err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
+
+// GetManyForUpdate is GetMany. A transaction already holds the whole store.
+func (r *MemoryTaskRepository) GetManyForUpdate(ctx context.Context, ids []int64) ([]models.Task, error) {
+	return r.GetMany(ctx, ids)
+}
+
+// UpdateMany updates all of tasks or, when one of them does not exist or is
+// invalid, none
+func (r *MemoryTaskRepository) UpdateMany(ctx context.Context, tasks []*models.Task) ([]*models.Task, error) {
+	unlock, err := r.store.lock(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	for _, task := range tasks {
+		if _, ok := r.store.tasks[task.ID]; !ok {
+			return nil, sql.ErrNoRows
+		}
+		if err := r.check(task); err != nil {
+			return nil, err
+		}
+	}
+
+	updated := make([]*models.Task, 0, len(tasks))
+	for _, task := range tasks {
+		// The row exists and was checked so replace cannot fail. Like the SQL
+		// version, the returned task keeps the caller's CreatedAt.
+		taskResponse, _ := r.replace(task)
+		taskResponse.CreatedAt = task.CreatedAt
+		updated = append(updated, taskResponse)
+	}
+	return updated, nil
+}
+
+func (r *MemoryTaskRepository) DeleteMany(ctx context.Context, ids []int64) error {
+	unlock, err := r.store.lock(ctx)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	for _, id := range ids {
+		delete(r.store.tasks, id)
+	}
+	return nil
+}
+
+// check returns the error the SQL schema's constraints give for task. The
+// store must be locked.
+func (r *MemoryTaskRepository) check(task *models.Task) error {
+	if !task.Status.IsValid() {
+		return fmt.Errorf("%w: tasks_status_check", ErrCheck)
+	}
+	if task.Priority < int(models.PriorityLow) || task.Priority > int(models.PriorityHigh) {
+		return fmt.Errorf("%w: tasks_priority_check", ErrCheck)
+	}
+	if task.AssigneeID != nil && r.store.users[*task.AssigneeID] == nil {
+		return fmt.Errorf("%w: tasks_assignee_id_fkey", ErrForeignKey)
+	}
+	if task.AssignerID != nil && r.store.users[*task.AssignerID] == nil {
+		return fmt.Errorf("%w: tasks_assigner_id_fkey", ErrForeignKey)
+	}
+	return nil
+}
+
+// insert stores a copy of task under a new ID. The store must be locked.
+func (r *MemoryTaskRepository) insert(task *models.Task) *models.Task {
+	r.store.nextTaskID++
+	now := r.store.timestamp()
+
+	stored := cloneTask(task)
+	stored.ID = r.store.nextTaskID
+	stored.CreatedAt = now
+	stored.UpdatedAt = now
+	r.store.tasks[stored.ID] = stored
+	return cloneTask(stored)
+}
+
+// replace overwrites the task with task's ID, keeping its CreatedAt. The
+// store must be locked.
+func (r *MemoryTaskRepository) replace(task *models.Task) (*models.Task, error) {
+	existing, ok := r.store.tasks[task.ID]
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+	if err := r.check(task); err != nil {
+		return nil, err
+	}
+
+	stored := cloneTask(task)
+	stored.CreatedAt = existing.CreatedAt
+	stored.UpdatedAt = r.store.timestamp()
+	r.store.tasks[stored.ID] = stored
+	return cloneTask(stored), nil
+}
+
+// list returns copies of the tasks matching match, ordered by ID
+func (r *MemoryTaskRepository) list(ctx context.Context, match func(task *models.Task) bool) ([]models.Task, error) {
+	unlock, err := r.store.lock(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	tasks := []models.Task{}
+	for _, task := range r.store.tasks {
+		if match(task) {
+			tasks = append(tasks, *cloneTask(task))
+		}
+	}
+	sort.Slice(tasks, func(i, j int) bool { return tasks[i].ID < tasks[j].ID })
+	return tasks, nil
+}
+
+func limitTasks(tasks []models.Task, limit int) []models.Task {
+	if limit >= 0 && len(tasks) > limit {
+		return tasks[:limit]
+	}
+	return tasks
+}
+
+// cloneTask copies task including the values its pointer fields point to, so
+// callers cannot change stored tasks
+func cloneTask(task *models.Task) *models.Task {
+	clone := *task
+	if task.AssigneeID != nil {
+		id := *task.AssigneeID
+		clone.AssigneeID = &id
+	}
+	if task.AssignerID != nil {
+		id := *task.AssignerID
+		clone.AssignerID = &id
+	}
+	if task.DueAt != nil {
+		dueAt := *task.DueAt
+		clone.DueAt = &dueAt
+	}
+	if task.OriginalEstimateMinutes != nil {
+		minutes := *task.OriginalEstimateMinutes
+		clone.OriginalEstimateMinutes = &minutes
+	}
+	if task.RemainingEstimateMinutes != nil {
+		minutes := *task.RemainingEstimateMinutes
+		clone.RemainingEstimateMinutes = &minutes
+	}
+	return &clone
+}
+
+// taskVisibleTo reports whether userID assigned or is assigned to task
+func taskVisibleTo(task *models.Task, userID int64) bool {
+	return (task.AssignerID != nil && *task.AssignerID == userID) ||
+		(task.AssigneeID != nil && *task.AssigneeID == userID)
+}
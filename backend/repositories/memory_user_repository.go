@@ -0,0 +1,68 @@
+package repositories
+
+import (
+	"backend/models"
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// MemoryUserRepository is a UserRepositoryInterface backed by a MemoryStore
+type MemoryUserRepository struct {
+	store *MemoryStore
+}
+
+func (r *MemoryUserRepository) Create(ctx context.Context, user *models.User) error {
+	unlock, err := r.store.lock(ctx)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	for _, existing := range r.store.users {
+		if existing.Username == user.Username {
+			return fmt.Errorf("%w: users_username_key", ErrDuplicate)
+		}
+		if existing.Email == user.Email {
+			return fmt.Errorf("%w: users_email_key", ErrDuplicate)
+		}
+	}
+
+	r.store.nextUserID++
+	now := r.store.timestamp()
+	user.ID = r.store.nextUserID
+	user.CreatedAt = now
+	user.UpdatedAt = now
+
+	stored := *user
+	r.store.users[stored.ID] = &stored
+	return nil
+}
+
+func (r *MemoryUserRepository) FindByUsername(ctx context.Context, username string) (*models.User, error) {
+	return r.find(ctx, func(user *models.User) bool { return user.Username == username })
+}
+
+func (r *MemoryUserRepository) FindByEmail(ctx context.Context, email string) (*models.User, error) {
+	return r.find(ctx, func(user *models.User) bool { return user.Email == email })
+}
+
+func (r *MemoryUserRepository) FindById(ctx context.Context, id int64) (*models.User, error) {
+	return r.find(ctx, func(user *models.User) bool { return user.ID == id })
+}
+
+func (r *MemoryUserRepository) find(ctx context.Context, match func(user *models.User) bool) (*models.User, error) {
+	unlock, err := r.store.lock(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	for _, user := range r.store.users {
+		if match(user) {
+			found := *user
+			return &found, nil
+		}
+	}
+	return nil, sql.ErrNoRows
+}
@@ -0,0 +1,69 @@
+//go:generate mockgen -destination=mocks/mock_mention_repository.go -package=mocks backend/repositories MentionRepositoryInterface
+
+package repositories
+
+import (
+	"backend/models"
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type MentionRepository struct {
+	db sqlx.DB
+}
+
+func NewMentionRepository(db sqlx.DB) MentionRepositoryInterface {
+	return &MentionRepository{db: db}
+}
+
+// Interface
+type MentionRepositoryInterface interface {
+	Create(ctx context.Context, mention *models.Mention) (created bool, err error)
+	ListByTaskID(ctx context.Context, taskID int64) ([]models.Mention, error)
+}
+
+// Create stores mention unless the same user is already mentioned by the same
+// source of the task. It reports whether a new row was inserted.
+func (r *MentionRepository) Create(ctx context.Context, mention *models.Mention) (bool, error) {
+	defer observeQuery("MentionRepository", "Create")()
+	err := r.db.QueryRowxContext(ctx, `
+		INSERT INTO mentions (task_id, source, mentioned_user_id, actor_id, flagged, created_at)
+		VALUES ($1, $2, $3, $4, $5, NOW())
+		ON CONFLICT (task_id, source, mentioned_user_id) DO NOTHING
+		RETURNING id, created_at
+	`,
+		mention.TaskID,
+		mention.Source,
+		mention.MentionedUserID,
+		mention.ActorID,
+		mention.Flagged,
+	).StructScan(mention)
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (r *MentionRepository) ListByTaskID(ctx context.Context, taskID int64) ([]models.Mention, error) {
+	defer observeQuery("MentionRepository", "ListByTaskID")()
+	mentions := []models.Mention{}
+	err := r.db.SelectContext(ctx, &mentions, `
+		SELECT m.id, m.task_id, m.source, m.mentioned_user_id, u.username AS mentioned_username,
+			m.actor_id, m.flagged, m.created_at
+		FROM mentions m
+		JOIN users u ON u.id = m.mentioned_user_id
+		WHERE m.task_id = $1
+		ORDER BY m.created_at, m.id
+	`, taskID)
+	if err != nil {
+		return nil, err
+	}
+	return mentions, nil
+}
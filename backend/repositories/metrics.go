@@ -0,0 +1,17 @@
+package repositories
+
+import (
+	"backend/metrics"
+	"time"
+)
+
+// observeQuery times a method of a database repository for
+// metrics.QueryDuration. Call it first thing as
+//
+//	defer observeQuery("TaskRepository", "Create")()
+func observeQuery(repository, method string) func() {
+	start := time.Now()
+	return func() {
+		metrics.ObserveQuery(repository, method, start)
+	}
+}
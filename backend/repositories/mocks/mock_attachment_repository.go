@@ -0,0 +1,94 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: backend/repositories (interfaces: AttachmentRepositoryInterface)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	models "backend/models"
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockAttachmentRepositoryInterface is a mock of AttachmentRepositoryInterface interface.
+type MockAttachmentRepositoryInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockAttachmentRepositoryInterfaceMockRecorder
+}
+
+// MockAttachmentRepositoryInterfaceMockRecorder is the mock recorder for MockAttachmentRepositoryInterface.
+type MockAttachmentRepositoryInterfaceMockRecorder struct {
+	mock *MockAttachmentRepositoryInterface
+}
+
+// NewMockAttachmentRepositoryInterface creates a new mock instance.
+func NewMockAttachmentRepositoryInterface(ctrl *gomock.Controller) *MockAttachmentRepositoryInterface {
+	mock := &MockAttachmentRepositoryInterface{ctrl: ctrl}
+	mock.recorder = &MockAttachmentRepositoryInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAttachmentRepositoryInterface) EXPECT() *MockAttachmentRepositoryInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockAttachmentRepositoryInterface) Create(arg0 context.Context, arg1 *models.Attachment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockAttachmentRepositoryInterfaceMockRecorder) Create(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockAttachmentRepositoryInterface)(nil).Create), arg0, arg1)
+}
+
+// Delete mocks base method.
+func (m *MockAttachmentRepositoryInterface) Delete(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockAttachmentRepositoryInterfaceMockRecorder) Delete(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockAttachmentRepositoryInterface)(nil).Delete), arg0, arg1)
+}
+
+// Get mocks base method.
+func (m *MockAttachmentRepositoryInterface) Get(arg0 context.Context, arg1 int64) (*models.Attachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", arg0, arg1)
+	ret0, _ := ret[0].(*models.Attachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockAttachmentRepositoryInterfaceMockRecorder) Get(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockAttachmentRepositoryInterface)(nil).Get), arg0, arg1)
+}
+
+// ListByTaskID mocks base method.
+func (m *MockAttachmentRepositoryInterface) ListByTaskID(arg0 context.Context, arg1 int64) ([]models.Attachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByTaskID", arg0, arg1)
+	ret0, _ := ret[0].([]models.Attachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByTaskID indicates an expected call of ListByTaskID.
+func (mr *MockAttachmentRepositoryInterfaceMockRecorder) ListByTaskID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByTaskID", reflect.TypeOf((*MockAttachmentRepositoryInterface)(nil).ListByTaskID), arg0, arg1)
+}
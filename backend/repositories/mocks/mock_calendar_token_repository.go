@@ -0,0 +1,94 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: backend/repositories (interfaces: CalendarTokenRepositoryInterface)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	models "backend/models"
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockCalendarTokenRepositoryInterface is a mock of CalendarTokenRepositoryInterface interface.
+type MockCalendarTokenRepositoryInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockCalendarTokenRepositoryInterfaceMockRecorder
+}
+
+// MockCalendarTokenRepositoryInterfaceMockRecorder is the mock recorder for MockCalendarTokenRepositoryInterface.
+type MockCalendarTokenRepositoryInterfaceMockRecorder struct {
+	mock *MockCalendarTokenRepositoryInterface
+}
+
+// NewMockCalendarTokenRepositoryInterface creates a new mock instance.
+func NewMockCalendarTokenRepositoryInterface(ctrl *gomock.Controller) *MockCalendarTokenRepositoryInterface {
+	mock := &MockCalendarTokenRepositoryInterface{ctrl: ctrl}
+	mock.recorder = &MockCalendarTokenRepositoryInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCalendarTokenRepositoryInterface) EXPECT() *MockCalendarTokenRepositoryInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockCalendarTokenRepositoryInterface) Delete(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockCalendarTokenRepositoryInterfaceMockRecorder) Delete(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockCalendarTokenRepositoryInterface)(nil).Delete), arg0, arg1)
+}
+
+// GetByHash mocks base method.
+func (m *MockCalendarTokenRepositoryInterface) GetByHash(arg0 context.Context, arg1 string) (*models.CalendarToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByHash", arg0, arg1)
+	ret0, _ := ret[0].(*models.CalendarToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByHash indicates an expected call of GetByHash.
+func (mr *MockCalendarTokenRepositoryInterfaceMockRecorder) GetByHash(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByHash", reflect.TypeOf((*MockCalendarTokenRepositoryInterface)(nil).GetByHash), arg0, arg1)
+}
+
+// GetByUserID mocks base method.
+func (m *MockCalendarTokenRepositoryInterface) GetByUserID(arg0 context.Context, arg1 int64) (*models.CalendarToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByUserID", arg0, arg1)
+	ret0, _ := ret[0].(*models.CalendarToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByUserID indicates an expected call of GetByUserID.
+func (mr *MockCalendarTokenRepositoryInterfaceMockRecorder) GetByUserID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByUserID", reflect.TypeOf((*MockCalendarTokenRepositoryInterface)(nil).GetByUserID), arg0, arg1)
+}
+
+// Upsert mocks base method.
+func (m *MockCalendarTokenRepositoryInterface) Upsert(arg0 context.Context, arg1 *models.CalendarToken) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Upsert", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Upsert indicates an expected call of Upsert.
+func (mr *MockCalendarTokenRepositoryInterfaceMockRecorder) Upsert(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Upsert", reflect.TypeOf((*MockCalendarTokenRepositoryInterface)(nil).Upsert), arg0, arg1)
+}
@@ -0,0 +1,95 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: backend/repositories (interfaces: JobRepositoryInterface)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	models "backend/models"
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockJobRepositoryInterface is a mock of JobRepositoryInterface interface.
+type MockJobRepositoryInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockJobRepositoryInterfaceMockRecorder
+}
+
+// MockJobRepositoryInterfaceMockRecorder is the mock recorder for MockJobRepositoryInterface.
+type MockJobRepositoryInterfaceMockRecorder struct {
+	mock *MockJobRepositoryInterface
+}
+
+// NewMockJobRepositoryInterface creates a new mock instance.
+func NewMockJobRepositoryInterface(ctrl *gomock.Controller) *MockJobRepositoryInterface {
+	mock := &MockJobRepositoryInterface{ctrl: ctrl}
+	mock.recorder = &MockJobRepositoryInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockJobRepositoryInterface) EXPECT() *MockJobRepositoryInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Complete mocks base method.
+func (m *MockJobRepositoryInterface) Complete(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Complete", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Complete indicates an expected call of Complete.
+func (mr *MockJobRepositoryInterfaceMockRecorder) Complete(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Complete", reflect.TypeOf((*MockJobRepositoryInterface)(nil).Complete), arg0, arg1)
+}
+
+// Enqueue mocks base method.
+func (m *MockJobRepositoryInterface) Enqueue(arg0 context.Context, arg1 *models.Job) (*models.Job, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Enqueue", arg0, arg1)
+	ret0, _ := ret[0].(*models.Job)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Enqueue indicates an expected call of Enqueue.
+func (mr *MockJobRepositoryInterfaceMockRecorder) Enqueue(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Enqueue", reflect.TypeOf((*MockJobRepositoryInterface)(nil).Enqueue), arg0, arg1)
+}
+
+// Fail mocks base method.
+func (m *MockJobRepositoryInterface) Fail(arg0 context.Context, arg1 int64, arg2 string, arg3 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Fail", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Fail indicates an expected call of Fail.
+func (mr *MockJobRepositoryInterfaceMockRecorder) Fail(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Fail", reflect.TypeOf((*MockJobRepositoryInterface)(nil).Fail), arg0, arg1, arg2, arg3)
+}
+
+// Lease mocks base method.
+func (m *MockJobRepositoryInterface) Lease(arg0 context.Context, arg1 string, arg2 int, arg3 time.Duration) ([]models.Job, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Lease", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]models.Job)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Lease indicates an expected call of Lease.
+func (mr *MockJobRepositoryInterfaceMockRecorder) Lease(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Lease", reflect.TypeOf((*MockJobRepositoryInterface)(nil).Lease), arg0, arg1, arg2, arg3)
+}
@@ -0,0 +1,66 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: backend/repositories (interfaces: MentionRepositoryInterface)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	models "backend/models"
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockMentionRepositoryInterface is a mock of MentionRepositoryInterface interface.
+type MockMentionRepositoryInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockMentionRepositoryInterfaceMockRecorder
+}
+
+// MockMentionRepositoryInterfaceMockRecorder is the mock recorder for MockMentionRepositoryInterface.
+type MockMentionRepositoryInterfaceMockRecorder struct {
+	mock *MockMentionRepositoryInterface
+}
+
+// NewMockMentionRepositoryInterface creates a new mock instance.
+func NewMockMentionRepositoryInterface(ctrl *gomock.Controller) *MockMentionRepositoryInterface {
+	mock := &MockMentionRepositoryInterface{ctrl: ctrl}
+	mock.recorder = &MockMentionRepositoryInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMentionRepositoryInterface) EXPECT() *MockMentionRepositoryInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockMentionRepositoryInterface) Create(arg0 context.Context, arg1 *models.Mention) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0, arg1)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockMentionRepositoryInterfaceMockRecorder) Create(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockMentionRepositoryInterface)(nil).Create), arg0, arg1)
+}
+
+// ListByTaskID mocks base method.
+func (m *MockMentionRepositoryInterface) ListByTaskID(arg0 context.Context, arg1 int64) ([]models.Mention, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByTaskID", arg0, arg1)
+	ret0, _ := ret[0].([]models.Mention)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByTaskID indicates an expected call of ListByTaskID.
+func (mr *MockMentionRepositoryInterfaceMockRecorder) ListByTaskID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByTaskID", reflect.TypeOf((*MockMentionRepositoryInterface)(nil).ListByTaskID), arg0, arg1)
+}
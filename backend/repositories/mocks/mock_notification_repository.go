@@ -0,0 +1,123 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: backend/repositories (interfaces: NotificationRepositoryInterface)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	models "backend/models"
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockNotificationRepositoryInterface is a mock of NotificationRepositoryInterface interface.
+type MockNotificationRepositoryInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockNotificationRepositoryInterfaceMockRecorder
+}
+
+// MockNotificationRepositoryInterfaceMockRecorder is the mock recorder for MockNotificationRepositoryInterface.
+type MockNotificationRepositoryInterfaceMockRecorder struct {
+	mock *MockNotificationRepositoryInterface
+}
+
+// NewMockNotificationRepositoryInterface creates a new mock instance.
+func NewMockNotificationRepositoryInterface(ctrl *gomock.Controller) *MockNotificationRepositoryInterface {
+	mock := &MockNotificationRepositoryInterface{ctrl: ctrl}
+	mock.recorder = &MockNotificationRepositoryInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNotificationRepositoryInterface) EXPECT() *MockNotificationRepositoryInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockNotificationRepositoryInterface) Create(arg0 context.Context, arg1 *models.Notification) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockNotificationRepositoryInterfaceMockRecorder) Create(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockNotificationRepositoryInterface)(nil).Create), arg0, arg1)
+}
+
+// GetPreferences mocks base method.
+func (m *MockNotificationRepositoryInterface) GetPreferences(arg0 context.Context, arg1 int64) (map[models.NotificationType]bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPreferences", arg0, arg1)
+	ret0, _ := ret[0].(map[models.NotificationType]bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPreferences indicates an expected call of GetPreferences.
+func (mr *MockNotificationRepositoryInterfaceMockRecorder) GetPreferences(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPreferences", reflect.TypeOf((*MockNotificationRepositoryInterface)(nil).GetPreferences), arg0, arg1)
+}
+
+// ListByUserID mocks base method.
+func (m *MockNotificationRepositoryInterface) ListByUserID(arg0 context.Context, arg1 int64, arg2 bool, arg3 int) ([]models.Notification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByUserID", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]models.Notification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByUserID indicates an expected call of ListByUserID.
+func (mr *MockNotificationRepositoryInterfaceMockRecorder) ListByUserID(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByUserID", reflect.TypeOf((*MockNotificationRepositoryInterface)(nil).ListByUserID), arg0, arg1, arg2, arg3)
+}
+
+// MarkAllRead mocks base method.
+func (m *MockNotificationRepositoryInterface) MarkAllRead(arg0 context.Context, arg1 int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkAllRead", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkAllRead indicates an expected call of MarkAllRead.
+func (mr *MockNotificationRepositoryInterfaceMockRecorder) MarkAllRead(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkAllRead", reflect.TypeOf((*MockNotificationRepositoryInterface)(nil).MarkAllRead), arg0, arg1)
+}
+
+// MarkRead mocks base method.
+func (m *MockNotificationRepositoryInterface) MarkRead(arg0 context.Context, arg1, arg2 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkRead", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkRead indicates an expected call of MarkRead.
+func (mr *MockNotificationRepositoryInterfaceMockRecorder) MarkRead(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkRead", reflect.TypeOf((*MockNotificationRepositoryInterface)(nil).MarkRead), arg0, arg1, arg2)
+}
+
+// SetPreference mocks base method.
+func (m *MockNotificationRepositoryInterface) SetPreference(arg0 context.Context, arg1 int64, arg2 models.NotificationType, arg3 bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPreference", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetPreference indicates an expected call of SetPreference.
+func (mr *MockNotificationRepositoryInterfaceMockRecorder) SetPreference(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPreference", reflect.TypeOf((*MockNotificationRepositoryInterface)(nil).SetPreference), arg0, arg1, arg2, arg3)
+}
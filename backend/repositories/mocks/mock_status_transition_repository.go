@@ -0,0 +1,81 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: backend/repositories (interfaces: StatusTransitionRepositoryInterface)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	models "backend/models"
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockStatusTransitionRepositoryInterface is a mock of StatusTransitionRepositoryInterface interface.
+type MockStatusTransitionRepositoryInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockStatusTransitionRepositoryInterfaceMockRecorder
+}
+
+// MockStatusTransitionRepositoryInterfaceMockRecorder is the mock recorder for MockStatusTransitionRepositoryInterface.
+type MockStatusTransitionRepositoryInterfaceMockRecorder struct {
+	mock *MockStatusTransitionRepositoryInterface
+}
+
+// NewMockStatusTransitionRepositoryInterface creates a new mock instance.
+func NewMockStatusTransitionRepositoryInterface(ctrl *gomock.Controller) *MockStatusTransitionRepositoryInterface {
+	mock := &MockStatusTransitionRepositoryInterface{ctrl: ctrl}
+	mock.recorder = &MockStatusTransitionRepositoryInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStatusTransitionRepositoryInterface) EXPECT() *MockStatusTransitionRepositoryInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockStatusTransitionRepositoryInterface) Create(arg0 context.Context, arg1 *models.StatusTransition) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockStatusTransitionRepositoryInterfaceMockRecorder) Create(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockStatusTransitionRepositoryInterface)(nil).Create), arg0, arg1)
+}
+
+// DailyStatusCounts mocks base method.
+func (m *MockStatusTransitionRepositoryInterface) DailyStatusCounts(arg0 context.Context, arg1 models.AnalyticsFilter, arg2, arg3 time.Time) ([]models.DailyStatusCount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DailyStatusCounts", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]models.DailyStatusCount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DailyStatusCounts indicates an expected call of DailyStatusCounts.
+func (mr *MockStatusTransitionRepositoryInterfaceMockRecorder) DailyStatusCounts(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DailyStatusCounts", reflect.TypeOf((*MockStatusTransitionRepositoryInterface)(nil).DailyStatusCounts), arg0, arg1, arg2, arg3)
+}
+
+// ListCompleted mocks base method.
+func (m *MockStatusTransitionRepositoryInterface) ListCompleted(arg0 context.Context, arg1 models.AnalyticsFilter) ([]models.CompletedTask, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCompleted", arg0, arg1)
+	ret0, _ := ret[0].([]models.CompletedTask)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCompleted indicates an expected call of ListCompleted.
+func (mr *MockStatusTransitionRepositoryInterfaceMockRecorder) ListCompleted(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCompleted", reflect.TypeOf((*MockStatusTransitionRepositoryInterface)(nil).ListCompleted), arg0, arg1)
+}
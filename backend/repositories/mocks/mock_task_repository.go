@@ -0,0 +1,275 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: backend/repositories (interfaces: TaskRepositoryInterface)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	models "backend/models"
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockTaskRepositoryInterface is a mock of TaskRepositoryInterface interface.
+type MockTaskRepositoryInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockTaskRepositoryInterfaceMockRecorder
+}
+
+// MockTaskRepositoryInterfaceMockRecorder is the mock recorder for MockTaskRepositoryInterface.
+type MockTaskRepositoryInterfaceMockRecorder struct {
+	mock *MockTaskRepositoryInterface
+}
+
+// NewMockTaskRepositoryInterface creates a new mock instance.
+func NewMockTaskRepositoryInterface(ctrl *gomock.Controller) *MockTaskRepositoryInterface {
+	mock := &MockTaskRepositoryInterface{ctrl: ctrl}
+	mock.recorder = &MockTaskRepositoryInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTaskRepositoryInterface) EXPECT() *MockTaskRepositoryInterfaceMockRecorder {
+	return m.recorder
+}
+
+// CountDueForUser mocks base method.
+func (m *MockTaskRepositoryInterface) CountDueForUser(arg0 context.Context, arg1 int64, arg2, arg3 time.Time) (int, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountDueForUser", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// CountDueForUser indicates an expected call of CountDueForUser.
+func (mr *MockTaskRepositoryInterfaceMockRecorder) CountDueForUser(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountDueForUser", reflect.TypeOf((*MockTaskRepositoryInterface)(nil).CountDueForUser), arg0, arg1, arg2, arg3)
+}
+
+// CountForUser mocks base method.
+func (m *MockTaskRepositoryInterface) CountForUser(arg0 context.Context, arg1 int64) ([]models.TaskCount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountForUser", arg0, arg1)
+	ret0, _ := ret[0].([]models.TaskCount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountForUser indicates an expected call of CountForUser.
+func (mr *MockTaskRepositoryInterfaceMockRecorder) CountForUser(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountForUser", reflect.TypeOf((*MockTaskRepositoryInterface)(nil).CountForUser), arg0, arg1)
+}
+
+// Create mocks base method.
+func (m *MockTaskRepositoryInterface) Create(arg0 context.Context, arg1 *models.Task) (*models.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0, arg1)
+	ret0, _ := ret[0].(*models.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockTaskRepositoryInterfaceMockRecorder) Create(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockTaskRepositoryInterface)(nil).Create), arg0, arg1)
+}
+
+// CreateMany mocks base method.
+func (m *MockTaskRepositoryInterface) CreateMany(arg0 context.Context, arg1 []*models.Task) ([]*models.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateMany", arg0, arg1)
+	ret0, _ := ret[0].([]*models.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateMany indicates an expected call of CreateMany.
+func (mr *MockTaskRepositoryInterfaceMockRecorder) CreateMany(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMany", reflect.TypeOf((*MockTaskRepositoryInterface)(nil).CreateMany), arg0, arg1)
+}
+
+// Delete mocks base method.
+func (m *MockTaskRepositoryInterface) Delete(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockTaskRepositoryInterfaceMockRecorder) Delete(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockTaskRepositoryInterface)(nil).Delete), arg0, arg1)
+}
+
+// DeleteMany mocks base method.
+func (m *MockTaskRepositoryInterface) DeleteMany(arg0 context.Context, arg1 []int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteMany", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteMany indicates an expected call of DeleteMany.
+func (mr *MockTaskRepositoryInterfaceMockRecorder) DeleteMany(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMany", reflect.TypeOf((*MockTaskRepositoryInterface)(nil).DeleteMany), arg0, arg1)
+}
+
+// ForEachVisible mocks base method.
+func (m *MockTaskRepositoryInterface) ForEachVisible(arg0 context.Context, arg1 int64, arg2 models.TaskFilter, arg3 func(*models.Task) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ForEachVisible", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ForEachVisible indicates an expected call of ForEachVisible.
+func (mr *MockTaskRepositoryInterfaceMockRecorder) ForEachVisible(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForEachVisible", reflect.TypeOf((*MockTaskRepositoryInterface)(nil).ForEachVisible), arg0, arg1, arg2, arg3)
+}
+
+// Get mocks base method.
+func (m *MockTaskRepositoryInterface) Get(arg0 context.Context, arg1 int64) (*models.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", arg0, arg1)
+	ret0, _ := ret[0].(*models.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockTaskRepositoryInterfaceMockRecorder) Get(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockTaskRepositoryInterface)(nil).Get), arg0, arg1)
+}
+
+// GetForUpdate mocks base method.
+func (m *MockTaskRepositoryInterface) GetForUpdate(arg0 context.Context, arg1 int64) (*models.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetForUpdate", arg0, arg1)
+	ret0, _ := ret[0].(*models.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetForUpdate indicates an expected call of GetForUpdate.
+func (mr *MockTaskRepositoryInterfaceMockRecorder) GetForUpdate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetForUpdate", reflect.TypeOf((*MockTaskRepositoryInterface)(nil).GetForUpdate), arg0, arg1)
+}
+
+// GetMany mocks base method.
+func (m *MockTaskRepositoryInterface) GetMany(arg0 context.Context, arg1 []int64) ([]models.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMany", arg0, arg1)
+	ret0, _ := ret[0].([]models.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMany indicates an expected call of GetMany.
+func (mr *MockTaskRepositoryInterfaceMockRecorder) GetMany(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMany", reflect.TypeOf((*MockTaskRepositoryInterface)(nil).GetMany), arg0, arg1)
+}
+
+// GetManyForUpdate mocks base method.
+func (m *MockTaskRepositoryInterface) GetManyForUpdate(arg0 context.Context, arg1 []int64) ([]models.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetManyForUpdate", arg0, arg1)
+	ret0, _ := ret[0].([]models.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetManyForUpdate indicates an expected call of GetManyForUpdate.
+func (mr *MockTaskRepositoryInterfaceMockRecorder) GetManyForUpdate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetManyForUpdate", reflect.TypeOf((*MockTaskRepositoryInterface)(nil).GetManyForUpdate), arg0, arg1)
+}
+
+// GetTasksByAssignerID mocks base method.
+func (m *MockTaskRepositoryInterface) GetTasksByAssignerID(arg0 context.Context, arg1 int64) ([]models.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTasksByAssignerID", arg0, arg1)
+	ret0, _ := ret[0].([]models.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTasksByAssignerID indicates an expected call of GetTasksByAssignerID.
+func (mr *MockTaskRepositoryInterfaceMockRecorder) GetTasksByAssignerID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTasksByAssignerID", reflect.TypeOf((*MockTaskRepositoryInterface)(nil).GetTasksByAssignerID), arg0, arg1)
+}
+
+// ListDueForUser mocks base method.
+func (m *MockTaskRepositoryInterface) ListDueForUser(arg0 context.Context, arg1 int64, arg2 *time.Time, arg3 time.Time, arg4 int) ([]models.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDueForUser", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].([]models.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDueForUser indicates an expected call of ListDueForUser.
+func (mr *MockTaskRepositoryInterfaceMockRecorder) ListDueForUser(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDueForUser", reflect.TypeOf((*MockTaskRepositoryInterface)(nil).ListDueForUser), arg0, arg1, arg2, arg3, arg4)
+}
+
+// ListRecentlyUpdatedForUser mocks base method.
+func (m *MockTaskRepositoryInterface) ListRecentlyUpdatedForUser(arg0 context.Context, arg1 int64, arg2 int) ([]models.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRecentlyUpdatedForUser", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]models.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRecentlyUpdatedForUser indicates an expected call of ListRecentlyUpdatedForUser.
+func (mr *MockTaskRepositoryInterfaceMockRecorder) ListRecentlyUpdatedForUser(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRecentlyUpdatedForUser", reflect.TypeOf((*MockTaskRepositoryInterface)(nil).ListRecentlyUpdatedForUser), arg0, arg1, arg2)
+}
+
+// Update mocks base method.
+func (m *MockTaskRepositoryInterface) Update(arg0 context.Context, arg1 *models.Task) (*models.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", arg0, arg1)
+	ret0, _ := ret[0].(*models.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockTaskRepositoryInterfaceMockRecorder) Update(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockTaskRepositoryInterface)(nil).Update), arg0, arg1)
+}
+
+// UpdateMany mocks base method.
+func (m *MockTaskRepositoryInterface) UpdateMany(arg0 context.Context, arg1 []*models.Task) ([]*models.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateMany", arg0, arg1)
+	ret0, _ := ret[0].([]*models.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateMany indicates an expected call of UpdateMany.
+func (mr *MockTaskRepositoryInterfaceMockRecorder) UpdateMany(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMany", reflect.TypeOf((*MockTaskRepositoryInterface)(nil).UpdateMany), arg0, arg1)
+}
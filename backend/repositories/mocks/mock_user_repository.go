@@ -6,6 +6,7 @@ package mocks
 
 import (
 	models "backend/models"
+	context "context"
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
@@ -35,60 +36,60 @@ func (m *MockUserRepositoryInterface) EXPECT() *MockUserRepositoryInterfaceMockR
 }
 
 // Create mocks base method.
-func (m *MockUserRepositoryInterface) Create(arg0 *models.User) error {
+func (m *MockUserRepositoryInterface) Create(arg0 context.Context, arg1 *models.User) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Create", arg0)
+	ret := m.ctrl.Call(m, "Create", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Create indicates an expected call of Create.
-func (mr *MockUserRepositoryInterfaceMockRecorder) Create(arg0 interface{}) *gomock.Call {
+func (mr *MockUserRepositoryInterfaceMockRecorder) Create(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockUserRepositoryInterface)(nil).Create), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockUserRepositoryInterface)(nil).Create), arg0, arg1)
 }
 
 // FindByEmail mocks base method.
-func (m *MockUserRepositoryInterface) FindByEmail(arg0 string) (*models.User, error) {
+func (m *MockUserRepositoryInterface) FindByEmail(arg0 context.Context, arg1 string) (*models.User, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindByEmail", arg0)
+	ret := m.ctrl.Call(m, "FindByEmail", arg0, arg1)
 	ret0, _ := ret[0].(*models.User)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // FindByEmail indicates an expected call of FindByEmail.
-func (mr *MockUserRepositoryInterfaceMockRecorder) FindByEmail(arg0 interface{}) *gomock.Call {
+func (mr *MockUserRepositoryInterfaceMockRecorder) FindByEmail(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByEmail", reflect.TypeOf((*MockUserRepositoryInterface)(nil).FindByEmail), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByEmail", reflect.TypeOf((*MockUserRepositoryInterface)(nil).FindByEmail), arg0, arg1)
 }
 
 // FindById mocks base method.
-func (m *MockUserRepositoryInterface) FindById(arg0 int64) (*models.User, error) {
+func (m *MockUserRepositoryInterface) FindById(arg0 context.Context, arg1 int64) (*models.User, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindById", arg0)
+	ret := m.ctrl.Call(m, "FindById", arg0, arg1)
 	ret0, _ := ret[0].(*models.User)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // FindById indicates an expected call of FindById.
-func (mr *MockUserRepositoryInterfaceMockRecorder) FindById(arg0 interface{}) *gomock.Call {
+func (mr *MockUserRepositoryInterfaceMockRecorder) FindById(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockUserRepositoryInterface)(nil).FindById), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockUserRepositoryInterface)(nil).FindById), arg0, arg1)
 }
 
 // FindByUsername mocks base method.
-func (m *MockUserRepositoryInterface) FindByUsername(arg0 string) (*models.User, error) {
+func (m *MockUserRepositoryInterface) FindByUsername(arg0 context.Context, arg1 string) (*models.User, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindByUsername", arg0)
+	ret := m.ctrl.Call(m, "FindByUsername", arg0, arg1)
 	ret0, _ := ret[0].(*models.User)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // FindByUsername indicates an expected call of FindByUsername.
-func (mr *MockUserRepositoryInterfaceMockRecorder) FindByUsername(arg0 interface{}) *gomock.Call {
+func (mr *MockUserRepositoryInterfaceMockRecorder) FindByUsername(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByUsername", reflect.TypeOf((*MockUserRepositoryInterface)(nil).FindByUsername), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByUsername", reflect.TypeOf((*MockUserRepositoryInterface)(nil).FindByUsername), arg0, arg1)
 }
@@ -0,0 +1,167 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: backend/repositories (interfaces: WebhookRepositoryInterface)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	models "backend/models"
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockWebhookRepositoryInterface is a mock of WebhookRepositoryInterface interface.
+type MockWebhookRepositoryInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockWebhookRepositoryInterfaceMockRecorder
+}
+
+// MockWebhookRepositoryInterfaceMockRecorder is the mock recorder for MockWebhookRepositoryInterface.
+type MockWebhookRepositoryInterfaceMockRecorder struct {
+	mock *MockWebhookRepositoryInterface
+}
+
+// NewMockWebhookRepositoryInterface creates a new mock instance.
+func NewMockWebhookRepositoryInterface(ctrl *gomock.Controller) *MockWebhookRepositoryInterface {
+	mock := &MockWebhookRepositoryInterface{ctrl: ctrl}
+	mock.recorder = &MockWebhookRepositoryInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWebhookRepositoryInterface) EXPECT() *MockWebhookRepositoryInterfaceMockRecorder {
+	return m.recorder
+}
+
+// CreateDelivery mocks base method.
+func (m *MockWebhookRepositoryInterface) CreateDelivery(arg0 context.Context, arg1 *models.WebhookDelivery) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateDelivery", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateDelivery indicates an expected call of CreateDelivery.
+func (mr *MockWebhookRepositoryInterfaceMockRecorder) CreateDelivery(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDelivery", reflect.TypeOf((*MockWebhookRepositoryInterface)(nil).CreateDelivery), arg0, arg1)
+}
+
+// CreateSubscription mocks base method.
+func (m *MockWebhookRepositoryInterface) CreateSubscription(arg0 context.Context, arg1 *models.WebhookSubscription) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSubscription", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateSubscription indicates an expected call of CreateSubscription.
+func (mr *MockWebhookRepositoryInterfaceMockRecorder) CreateSubscription(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSubscription", reflect.TypeOf((*MockWebhookRepositoryInterface)(nil).CreateSubscription), arg0, arg1)
+}
+
+// DeleteSubscription mocks base method.
+func (m *MockWebhookRepositoryInterface) DeleteSubscription(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSubscription", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSubscription indicates an expected call of DeleteSubscription.
+func (mr *MockWebhookRepositoryInterfaceMockRecorder) DeleteSubscription(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSubscription", reflect.TypeOf((*MockWebhookRepositoryInterface)(nil).DeleteSubscription), arg0, arg1)
+}
+
+// FindSubscriptionsForEvent mocks base method.
+func (m *MockWebhookRepositoryInterface) FindSubscriptionsForEvent(arg0 context.Context, arg1 []int64, arg2 string) ([]models.WebhookSubscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindSubscriptionsForEvent", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]models.WebhookSubscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindSubscriptionsForEvent indicates an expected call of FindSubscriptionsForEvent.
+func (mr *MockWebhookRepositoryInterfaceMockRecorder) FindSubscriptionsForEvent(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindSubscriptionsForEvent", reflect.TypeOf((*MockWebhookRepositoryInterface)(nil).FindSubscriptionsForEvent), arg0, arg1, arg2)
+}
+
+// GetDelivery mocks base method.
+func (m *MockWebhookRepositoryInterface) GetDelivery(arg0 context.Context, arg1 int64) (*models.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDelivery", arg0, arg1)
+	ret0, _ := ret[0].(*models.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDelivery indicates an expected call of GetDelivery.
+func (mr *MockWebhookRepositoryInterfaceMockRecorder) GetDelivery(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDelivery", reflect.TypeOf((*MockWebhookRepositoryInterface)(nil).GetDelivery), arg0, arg1)
+}
+
+// GetSubscription mocks base method.
+func (m *MockWebhookRepositoryInterface) GetSubscription(arg0 context.Context, arg1 int64) (*models.WebhookSubscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubscription", arg0, arg1)
+	ret0, _ := ret[0].(*models.WebhookSubscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubscription indicates an expected call of GetSubscription.
+func (mr *MockWebhookRepositoryInterfaceMockRecorder) GetSubscription(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubscription", reflect.TypeOf((*MockWebhookRepositoryInterface)(nil).GetSubscription), arg0, arg1)
+}
+
+// ListDeliveries mocks base method.
+func (m *MockWebhookRepositoryInterface) ListDeliveries(arg0 context.Context, arg1 int64, arg2 int) ([]models.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDeliveries", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]models.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDeliveries indicates an expected call of ListDeliveries.
+func (mr *MockWebhookRepositoryInterfaceMockRecorder) ListDeliveries(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDeliveries", reflect.TypeOf((*MockWebhookRepositoryInterface)(nil).ListDeliveries), arg0, arg1, arg2)
+}
+
+// ListSubscriptionsByUserID mocks base method.
+func (m *MockWebhookRepositoryInterface) ListSubscriptionsByUserID(arg0 context.Context, arg1 int64) ([]models.WebhookSubscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSubscriptionsByUserID", arg0, arg1)
+	ret0, _ := ret[0].([]models.WebhookSubscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSubscriptionsByUserID indicates an expected call of ListSubscriptionsByUserID.
+func (mr *MockWebhookRepositoryInterfaceMockRecorder) ListSubscriptionsByUserID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSubscriptionsByUserID", reflect.TypeOf((*MockWebhookRepositoryInterface)(nil).ListSubscriptionsByUserID), arg0, arg1)
+}
+
+// UpdateDelivery mocks base method.
+func (m *MockWebhookRepositoryInterface) UpdateDelivery(arg0 context.Context, arg1 *models.WebhookDelivery) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateDelivery", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateDelivery indicates an expected call of UpdateDelivery.
+func (mr *MockWebhookRepositoryInterfaceMockRecorder) UpdateDelivery(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateDelivery", reflect.TypeOf((*MockWebhookRepositoryInterface)(nil).UpdateDelivery), arg0, arg1)
+}
@@ -0,0 +1,126 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: backend/repositories (interfaces: WorkLogRepositoryInterface)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	models "backend/models"
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockWorkLogRepositoryInterface is a mock of WorkLogRepositoryInterface interface.
+type MockWorkLogRepositoryInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockWorkLogRepositoryInterfaceMockRecorder
+}
+
+// MockWorkLogRepositoryInterfaceMockRecorder is the mock recorder for MockWorkLogRepositoryInterface.
+type MockWorkLogRepositoryInterfaceMockRecorder struct {
+	mock *MockWorkLogRepositoryInterface
+}
+
+// NewMockWorkLogRepositoryInterface creates a new mock instance.
+func NewMockWorkLogRepositoryInterface(ctrl *gomock.Controller) *MockWorkLogRepositoryInterface {
+	mock := &MockWorkLogRepositoryInterface{ctrl: ctrl}
+	mock.recorder = &MockWorkLogRepositoryInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWorkLogRepositoryInterface) EXPECT() *MockWorkLogRepositoryInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockWorkLogRepositoryInterface) Create(arg0 context.Context, arg1 *models.WorkLog) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockWorkLogRepositoryInterfaceMockRecorder) Create(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockWorkLogRepositoryInterface)(nil).Create), arg0, arg1)
+}
+
+// GetRunningTimer mocks base method.
+func (m *MockWorkLogRepositoryInterface) GetRunningTimer(arg0 context.Context, arg1 int64) (*models.WorkLog, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRunningTimer", arg0, arg1)
+	ret0, _ := ret[0].(*models.WorkLog)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRunningTimer indicates an expected call of GetRunningTimer.
+func (mr *MockWorkLogRepositoryInterfaceMockRecorder) GetRunningTimer(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRunningTimer", reflect.TypeOf((*MockWorkLogRepositoryInterface)(nil).GetRunningTimer), arg0, arg1)
+}
+
+// ListByTaskID mocks base method.
+func (m *MockWorkLogRepositoryInterface) ListByTaskID(arg0 context.Context, arg1 int64) ([]models.WorkLog, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByTaskID", arg0, arg1)
+	ret0, _ := ret[0].([]models.WorkLog)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByTaskID indicates an expected call of ListByTaskID.
+func (mr *MockWorkLogRepositoryInterfaceMockRecorder) ListByTaskID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByTaskID", reflect.TypeOf((*MockWorkLogRepositoryInterface)(nil).ListByTaskID), arg0, arg1)
+}
+
+// StopTimer mocks base method.
+func (m *MockWorkLogRepositoryInterface) StopTimer(arg0 context.Context, arg1 int64, arg2 time.Time) (*models.WorkLog, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StopTimer", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*models.WorkLog)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StopTimer indicates an expected call of StopTimer.
+func (mr *MockWorkLogRepositoryInterfaceMockRecorder) StopTimer(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StopTimer", reflect.TypeOf((*MockWorkLogRepositoryInterface)(nil).StopTimer), arg0, arg1, arg2)
+}
+
+// TotalsForTask mocks base method.
+func (m *MockWorkLogRepositoryInterface) TotalsForTask(arg0 context.Context, arg1 int64, arg2, arg3 time.Time) ([]models.UserTimeTotal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TotalsForTask", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]models.UserTimeTotal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TotalsForTask indicates an expected call of TotalsForTask.
+func (mr *MockWorkLogRepositoryInterfaceMockRecorder) TotalsForTask(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TotalsForTask", reflect.TypeOf((*MockWorkLogRepositoryInterface)(nil).TotalsForTask), arg0, arg1, arg2, arg3)
+}
+
+// TotalsForUser mocks base method.
+func (m *MockWorkLogRepositoryInterface) TotalsForUser(arg0 context.Context, arg1, arg2 int64, arg3, arg4 time.Time) ([]models.TaskTimeTotal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TotalsForUser", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].([]models.TaskTimeTotal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TotalsForUser indicates an expected call of TotalsForUser.
+func (mr *MockWorkLogRepositoryInterfaceMockRecorder) TotalsForUser(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TotalsForUser", reflect.TypeOf((*MockWorkLogRepositoryInterface)(nil).TotalsForUser), arg0, arg1, arg2, arg3, arg4)
+}
@@ -0,0 +1,119 @@
+//go:generate mockgen -destination=mocks/mock_notification_repository.go -package=mocks backend/repositories NotificationRepositoryInterface
+
+package repositories
+
+import (
+	"backend/models"
+	"context"
+	"database/sql"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type NotificationRepository struct {
+	db sqlx.DB
+}
+
+func NewNotificationRepository(db sqlx.DB) NotificationRepositoryInterface {
+	return &NotificationRepository{db: db}
+}
+
+// Interface
+type NotificationRepositoryInterface interface {
+	Create(ctx context.Context, notification *models.Notification) error
+	ListByUserID(ctx context.Context, userID int64, unreadOnly bool, limit int) ([]models.Notification, error)
+	MarkRead(ctx context.Context, id, userID int64) error
+	MarkAllRead(ctx context.Context, userID int64) (int64, error)
+	GetPreferences(ctx context.Context, userID int64) (map[models.NotificationType]bool, error)
+	SetPreference(ctx context.Context, userID int64, notificationType models.NotificationType, enabled bool) error
+}
+
+func (r *NotificationRepository) Create(ctx context.Context, notification *models.Notification) error {
+	defer observeQuery("NotificationRepository", "Create")()
+	return r.db.QueryRowxContext(ctx, `
+		INSERT INTO notifications (user_id, type, task_id, actor_id, message, created_at)
+		VALUES ($1, $2, $3, $4, $5, NOW())
+		RETURNING id, created_at
+	`,
+		notification.UserID,
+		notification.Type,
+		notification.TaskID,
+		notification.ActorID,
+		notification.Message,
+	).StructScan(notification)
+}
+
+func (r *NotificationRepository) ListByUserID(ctx context.Context, userID int64, unreadOnly bool, limit int) ([]models.Notification, error) {
+	defer observeQuery("NotificationRepository", "ListByUserID")()
+	notifications := []models.Notification{}
+	err := r.db.SelectContext(ctx, &notifications, `
+		SELECT id, user_id, type, task_id, actor_id, message, read_at, created_at
+		FROM notifications
+		WHERE user_id = $1 AND ($2 = FALSE OR read_at IS NULL)
+		ORDER BY created_at DESC, id DESC
+		LIMIT $3
+	`, userID, unreadOnly, limit)
+	if err != nil {
+		return nil, err
+	}
+	return notifications, nil
+}
+
+// MarkRead marks one of userID's notifications as read. It returns
+// sql.ErrNoRows when the notification does not exist or belongs to someone else.
+func (r *NotificationRepository) MarkRead(ctx context.Context, id, userID int64) error {
+	defer observeQuery("NotificationRepository", "MarkRead")()
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE notifications SET read_at = COALESCE(read_at, NOW())
+		WHERE id = $1 AND user_id = $2
+	`, id, userID)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+func (r *NotificationRepository) MarkAllRead(ctx context.Context, userID int64) (int64, error) {
+	defer observeQuery("NotificationRepository", "MarkAllRead")()
+	result, err := r.db.ExecContext(ctx, `UPDATE notifications SET read_at = NOW() WHERE user_id = $1 AND read_at IS NULL`, userID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+func (r *NotificationRepository) GetPreferences(ctx context.Context, userID int64) (map[models.NotificationType]bool, error) {
+	defer observeQuery("NotificationRepository", "GetPreferences")()
+	var rows []struct {
+		Type    models.NotificationType `db:"type"`
+		Enabled bool                    `db:"enabled"`
+	}
+	err := r.db.SelectContext(ctx, &rows, `SELECT type, enabled FROM notification_preferences WHERE user_id = $1`, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	preferences := make(map[models.NotificationType]bool, len(rows))
+	for _, row := range rows {
+		preferences[row.Type] = row.Enabled
+	}
+	return preferences, nil
+}
+
+func (r *NotificationRepository) SetPreference(ctx context.Context, userID int64, notificationType models.NotificationType, enabled bool) error {
+	defer observeQuery("NotificationRepository", "SetPreference")()
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO notification_preferences (user_id, type, enabled)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (user_id, type) DO UPDATE SET enabled = EXCLUDED.enabled
+	`, userID, notificationType, enabled)
+	return err
+}
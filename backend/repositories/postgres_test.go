@@ -0,0 +1,37 @@
+package repositories
+
+import (
+	"backend/db"
+	"context"
+	"os"
+	"testing"
+
+	"github.com/jmoiron/sqlx"
+	_ "github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+)
+
+// TestPostgresRepositories migrates the database at TEST_DATABASE_URL and
+// empties its tables. It is skipped without one.
+func TestPostgresRepositories(t *testing.T) {
+	dsn := os.Getenv("TEST_DATABASE_URL")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_URL is not set")
+	}
+	database, err := sqlx.Connect("postgres", dsn)
+	require.NoError(t, err)
+	t.Cleanup(func() { database.Close() })
+	migrator, err := db.NewMigrator(database, db.Postgres)
+	require.NoError(t, err)
+	require.NoError(t, migrator.Up(context.Background()))
+
+	runConformanceTests(t, func(t *testing.T) testStorage {
+		_, err := database.Exec("TRUNCATE users, tasks RESTART IDENTITY CASCADE")
+		require.NoError(t, err)
+		return testStorage{
+			Users:      NewUserRepository(*database),
+			Tasks:      NewTaskRepository(*database),
+			Transactor: NewTransactor(database),
+		}
+	})
+}
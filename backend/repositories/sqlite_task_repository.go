@@ -0,0 +1,318 @@
+package repositories
+
+import (
+	"backend/models"
+	"context"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// sqliteTimeLayout stores times as fixed-width UTC text, so comparing and
+// sorting the text orders the times
+const sqliteTimeLayout = "2006-01-02 15:04:05.000000"
+
+// SQLiteTaskRepository is a TaskRepositoryInterface for SQLite databases. It
+// runs the same queries as TaskRepository, in SQLite's dialect.
+type SQLiteTaskRepository struct {
+	db  sqlx.DB
+	now func() time.Time
+}
+
+func NewSQLiteTaskRepository(db sqlx.DB) TaskRepositoryInterface {
+	return &SQLiteTaskRepository{db: db, now: time.Now}
+}
+
+func (r *SQLiteTaskRepository) q(ctx context.Context) Querier {
+	return querier(ctx, &r.db)
+}
+
+func (r *SQLiteTaskRepository) Create(ctx context.Context, task *models.Task) (*models.Task, error) {
+	defer observeQuery("SQLiteTaskRepository", "Create")()
+	return r.insert(ctx, r.q(ctx), task)
+}
+
+func (r *SQLiteTaskRepository) Update(ctx context.Context, task *models.Task) (*models.Task, error) {
+	defer observeQuery("SQLiteTaskRepository", "Update")()
+	var id int64
+	err := r.q(ctx).QueryRowxContext(ctx, `
+		UPDATE tasks SET title = $1, description = $2, status = $3, assignee_id = $4, assigner_id = $5, priority = $6, due_at = $7,
+			original_estimate_minutes = $8, remaining_estimate_minutes = $9, updated_at = $10
+		WHERE id = $11
+		RETURNING id
+	`,
+		task.Title,
+		task.Description,
+		task.Status,
+		task.AssigneeID,
+		task.AssignerID,
+		task.Priority,
+		sqliteNullTime(task.DueAt),
+		task.OriginalEstimateMinutes,
+		task.RemainingEstimateMinutes,
+		sqliteTime(sqliteNow(r.now)),
+		task.ID,
+	).Scan(&id)
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	// Read the row back for the timestamps
+	return r.Get(ctx, task.ID)
+}
+
+func (r *SQLiteTaskRepository) Get(ctx context.Context, id int64) (*models.Task, error) {
+	defer observeQuery("SQLiteTaskRepository", "Get")()
+	task := &models.Task{}
+	err := r.q(ctx).GetContext(ctx, task, "SELECT "+taskColumns+" FROM tasks WHERE id = $1", id)
+	if err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
+// GetForUpdate is Get. SQLite transactions are begun with BEGIN IMMEDIATE
+// and so already hold the database's write lock.
+func (r *SQLiteTaskRepository) GetForUpdate(ctx context.Context, id int64) (*models.Task, error) {
+	defer observeQuery("SQLiteTaskRepository", "GetForUpdate")()
+	return r.Get(ctx, id)
+}
+
+func (r *SQLiteTaskRepository) Delete(ctx context.Context, id int64) error {
+	defer observeQuery("SQLiteTaskRepository", "Delete")()
+	_, err := r.q(ctx).ExecContext(ctx, `DELETE FROM tasks WHERE id = $1`, id)
+	return err
+}
+
+func (r *SQLiteTaskRepository) GetTasksByAssignerID(ctx context.Context, assignerID int64) ([]models.Task, error) {
+	defer observeQuery("SQLiteTaskRepository", "GetTasksByAssignerID")()
+	var tasks []models.Task
+	err := r.q(ctx).SelectContext(ctx, &tasks, "SELECT "+taskColumns+" FROM tasks WHERE assigner_id = $1", assignerID)
+	if err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
+
+func (r *SQLiteTaskRepository) CountForUser(ctx context.Context, userID int64) ([]models.TaskCount, error) {
+	defer observeQuery("SQLiteTaskRepository", "CountForUser")()
+	counts := []models.TaskCount{}
+	err := r.q(ctx).SelectContext(ctx, &counts, `
+		SELECT 'assigned' AS role, status, priority, COUNT(*) AS count
+		FROM tasks WHERE assignee_id = $1
+		GROUP BY status, priority
+		UNION ALL
+		SELECT 'created' AS role, status, priority, COUNT(*) AS count
+		FROM tasks WHERE assigner_id = $1
+		GROUP BY status, priority
+	`, userID)
+	if err != nil {
+		return nil, err
+	}
+	return counts, nil
+}
+
+func (r *SQLiteTaskRepository) CountDueForUser(ctx context.Context, userID int64, now, weekEnd time.Time) (overdue int, dueThisWeek int, err error) {
+	defer observeQuery("SQLiteTaskRepository", "CountDueForUser")()
+	err = r.q(ctx).QueryRowxContext(ctx, `
+		SELECT
+			COUNT(*) FILTER (WHERE due_at < $2),
+			COUNT(*) FILTER (WHERE due_at >= $2 AND due_at < $3)
+		FROM tasks
+		WHERE (assignee_id = $1 OR assigner_id = $1) AND status <> 'DONE' AND due_at IS NOT NULL
+	`, userID, sqliteTime(now), sqliteTime(weekEnd)).Scan(&overdue, &dueThisWeek)
+	return overdue, dueThisWeek, err
+}
+
+func (r *SQLiteTaskRepository) ListDueForUser(ctx context.Context, userID int64, from *time.Time, to time.Time, limit int) ([]models.Task, error) {
+	defer observeQuery("SQLiteTaskRepository", "ListDueForUser")()
+	tasks := []models.Task{}
+	err := r.q(ctx).SelectContext(ctx, &tasks, "SELECT "+taskColumns+` FROM tasks
+		WHERE (assignee_id = $1 OR assigner_id = $1) AND status <> 'DONE'
+			AND due_at < $2 AND ($3 IS NULL OR due_at >= $3)
+		ORDER BY due_at, id
+		LIMIT $4
+	`, userID, sqliteTime(to), sqliteNullTime(from), limit)
+	if err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
+
+func (r *SQLiteTaskRepository) ListRecentlyUpdatedForUser(ctx context.Context, userID int64, limit int) ([]models.Task, error) {
+	defer observeQuery("SQLiteTaskRepository", "ListRecentlyUpdatedForUser")()
+	tasks := []models.Task{}
+	err := r.q(ctx).SelectContext(ctx, &tasks, "SELECT "+taskColumns+` FROM tasks
+		WHERE assignee_id = $1 OR assigner_id = $1
+		ORDER BY updated_at DESC, id DESC
+		LIMIT $2
+	`, userID, limit)
+	if err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
+
+func (r *SQLiteTaskRepository) ForEachVisible(ctx context.Context, userID int64, filter models.TaskFilter, fn func(task *models.Task) error) error {
+	defer observeQuery("SQLiteTaskRepository", "ForEachVisible")()
+	rows, err := r.q(ctx).QueryxContext(ctx, "SELECT "+taskColumns+` FROM tasks
+		WHERE (assignee_id = $1 OR assigner_id = $1)
+			AND ($2 IS NULL OR status = $2)
+			AND ($3 IS NULL OR priority = $3)
+			AND ($4 IS NULL OR assignee_id = $4)
+		ORDER BY id
+	`, userID, filter.Status, filter.Priority, filter.AssigneeID)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var task models.Task
+		if err := rows.StructScan(&task); err != nil {
+			return err
+		}
+		if err := fn(&task); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+func (r *SQLiteTaskRepository) CreateMany(ctx context.Context, tasks []*models.Task) ([]*models.Task, error) {
+	defer observeQuery("SQLiteTaskRepository", "CreateMany")()
+	created := make([]*models.Task, 0, len(tasks))
+	err := withinTransaction(ctx, &r.db, func(ctx context.Context, tx Querier) error {
+		for _, task := range tasks {
+			taskResponse, err := r.insert(ctx, tx, task)
+			if err != nil {
+				return err
+			}
+			created = append(created, taskResponse)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+func (r *SQLiteTaskRepository) GetMany(ctx context.Context, ids []int64) ([]models.Task, error) {
+	defer observeQuery("SQLiteTaskRepository", "GetMany")()
+	tasks := []models.Task{}
+	if len(ids) == 0 {
+		return tasks, nil
+	}
+	query, args, err := sqlx.In("SELECT "+taskColumns+" FROM tasks WHERE id IN (?)", ids)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.q(ctx).SelectContext(ctx, &tasks, query, args...); err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
+
+// GetManyForUpdate is GetMany, for the same reason GetForUpdate is Get
+func (r *SQLiteTaskRepository) GetManyForUpdate(ctx context.Context, ids []int64) ([]models.Task, error) {
+	defer observeQuery("SQLiteTaskRepository", "GetManyForUpdate")()
+	return r.GetMany(ctx, ids)
+}
+
+func (r *SQLiteTaskRepository) UpdateMany(ctx context.Context, tasks []*models.Task) ([]*models.Task, error) {
+	defer observeQuery("SQLiteTaskRepository", "UpdateMany")()
+	updated := make([]*models.Task, 0, len(tasks))
+	err := withinTransaction(ctx, &r.db, func(ctx context.Context, tx Querier) error {
+		now := sqliteNow(r.now)
+		for _, task := range tasks {
+			taskResponse := *task
+			err := tx.QueryRowxContext(ctx, `
+			UPDATE tasks SET title = $1, description = $2, status = $3, assignee_id = $4, assigner_id = $5, priority = $6, due_at = $7,
+				original_estimate_minutes = $8, remaining_estimate_minutes = $9, updated_at = $10
+			WHERE id = $11
+			RETURNING id
+		`,
+				task.Title,
+				task.Description,
+				task.Status,
+				task.AssigneeID,
+				task.AssignerID,
+				task.Priority,
+				sqliteNullTime(task.DueAt),
+				task.OriginalEstimateMinutes,
+				task.RemainingEstimateMinutes,
+				sqliteTime(now),
+				task.ID,
+			).Scan(&taskResponse.ID)
+			if err != nil {
+				return mapError(err)
+			}
+			taskResponse.UpdatedAt = now
+			updated = append(updated, &taskResponse)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+func (r *SQLiteTaskRepository) DeleteMany(ctx context.Context, ids []int64) error {
+	defer observeQuery("SQLiteTaskRepository", "DeleteMany")()
+	if len(ids) == 0 {
+		return nil
+	}
+	query, args, err := sqlx.In("DELETE FROM tasks WHERE id IN (?)", ids)
+	if err != nil {
+		return err
+	}
+	_, err = r.q(ctx).ExecContext(ctx, query, args...)
+	return err
+}
+
+func (r *SQLiteTaskRepository) insert(ctx context.Context, q Querier, task *models.Task) (*models.Task, error) {
+	now := sqliteNow(r.now)
+	taskResponse := cloneTask(task)
+	err := q.QueryRowxContext(ctx, `
+		INSERT INTO tasks (title, description, status, assignee_id, assigner_id, priority, due_at,
+			original_estimate_minutes, remaining_estimate_minutes, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $10)
+		RETURNING id
+	`,
+		task.Title,
+		task.Description,
+		task.Status,
+		task.AssigneeID,
+		task.AssignerID,
+		task.Priority,
+		sqliteNullTime(task.DueAt),
+		task.OriginalEstimateMinutes,
+		task.RemainingEstimateMinutes,
+		sqliteTime(now),
+	).Scan(&taskResponse.ID)
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	taskResponse.CreatedAt = now
+	taskResponse.UpdatedAt = now
+	return taskResponse, nil
+}
+
+// sqliteNow returns the current time at the precision stored
+func sqliteNow(now func() time.Time) time.Time {
+	return now().UTC().Truncate(time.Microsecond)
+}
+
+func sqliteTime(t time.Time) string {
+	return t.UTC().Format(sqliteTimeLayout)
+}
+
+func sqliteNullTime(t *time.Time) interface{} {
+	if t == nil {
+		return nil
+	}
+	return sqliteTime(*t)
+}
@@ -0,0 +1,85 @@
+package repositories
+
+import (
+	"backend/db"
+	"backend/metrics"
+	"backend/models"
+	"context"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestSQLite(t *testing.T) *sqlx.DB {
+	database, err := db.ConnectSQLite(filepath.Join(t.TempDir(), "tasks.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() { database.Close() })
+	migrator, err := db.NewMigrator(database, db.SQLite)
+	require.NoError(t, err)
+	require.NoError(t, migrator.Up(context.Background()))
+	return database
+}
+
+func TestSQLiteRepositories(t *testing.T) {
+	runConformanceTests(t, func(t *testing.T) testStorage {
+		database := newTestSQLite(t)
+		return testStorage{
+			Users:      NewSQLiteUserRepository(*database),
+			Tasks:      NewSQLiteTaskRepository(*database),
+			Transactor: NewTransactor(database),
+		}
+	})
+}
+
+func TestSQLiteRepositories_ConcurrentUpdates(t *testing.T) {
+	database := newTestSQLite(t)
+	tasks, tx := NewSQLiteTaskRepository(*database), NewTransactor(database)
+	ctx := context.Background()
+
+	task, err := tasks.Create(ctx, &models.Task{Title: "Counter", Status: models.StatusToDo, Priority: 1, RemainingEstimateMinutes: new(int)})
+	require.NoError(t, err)
+
+	const workers = 10
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := tx.WithinTransaction(ctx, func(ctx context.Context) error {
+				current, err := tasks.GetForUpdate(ctx, task.ID)
+				if err != nil {
+					return err
+				}
+				*current.RemainingEstimateMinutes++
+				_, err = tasks.Update(ctx, current)
+				return err
+			})
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+
+	got, err := tasks.Get(ctx, task.ID)
+	require.NoError(t, err)
+	assert.Equal(t, workers, *got.RemainingEstimateMinutes)
+}
+
+func TestSQLiteRepositories_QueryMetrics(t *testing.T) {
+	users := NewSQLiteUserRepository(*newTestSQLite(t))
+	observed := func() uint64 {
+		var m dto.Metric
+		histogram := metrics.QueryDuration.WithLabelValues("SQLiteUserRepository", "Create").(prometheus.Histogram)
+		require.NoError(t, histogram.Write(&m))
+		return m.GetHistogram().GetSampleCount()
+	}
+	before := observed()
+
+	require.NoError(t, users.Create(context.Background(), &models.User{Username: "alice", Email: "alice@example.com", Password: "x"}))
+	assert.Equal(t, before+1, observed())
+}
@@ -0,0 +1,65 @@
+package repositories
+
+import (
+	"backend/models"
+	"context"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// SQLiteUserRepository is a UserRepositoryInterface for SQLite databases
+type SQLiteUserRepository struct {
+	db  sqlx.DB
+	now func() time.Time
+}
+
+func NewSQLiteUserRepository(db sqlx.DB) UserRepositoryInterface {
+	return &SQLiteUserRepository{db: db, now: time.Now}
+}
+
+func (r *SQLiteUserRepository) Create(ctx context.Context, user *models.User) error {
+	defer observeQuery("SQLiteUserRepository", "Create")()
+	now := sqliteNow(r.now)
+	err := querier(ctx, &r.db).QueryRowxContext(ctx, `
+		INSERT INTO users (username, email, password, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $4)
+		RETURNING id
+	`,
+		user.Username,
+		user.Email,
+		user.Password,
+		sqliteTime(now),
+	).Scan(&user.ID)
+	if err != nil {
+		return mapError(err)
+	}
+
+	user.CreatedAt = now
+	user.UpdatedAt = now
+	return nil
+}
+
+func (r *SQLiteUserRepository) FindByUsername(ctx context.Context, username string) (*models.User, error) {
+	defer observeQuery("SQLiteUserRepository", "FindByUsername")()
+	return r.find(ctx, "username = $1", username)
+}
+
+func (r *SQLiteUserRepository) FindByEmail(ctx context.Context, email string) (*models.User, error) {
+	defer observeQuery("SQLiteUserRepository", "FindByEmail")()
+	return r.find(ctx, "email = $1", email)
+}
+
+func (r *SQLiteUserRepository) FindById(ctx context.Context, id int64) (*models.User, error) {
+	defer observeQuery("SQLiteUserRepository", "FindById")()
+	return r.find(ctx, "id = $1", id)
+}
+
+func (r *SQLiteUserRepository) find(ctx context.Context, where string, arg interface{}) (*models.User, error) {
+	var user models.User
+	err := querier(ctx, &r.db).GetContext(ctx, &user, "SELECT id, username, email, password, created_at, updated_at FROM users WHERE "+where, arg)
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
@@ -0,0 +1,95 @@
+//go:generate mockgen -destination=mocks/mock_status_transition_repository.go -package=mocks backend/repositories StatusTransitionRepositoryInterface
+
+package repositories
+
+import (
+	"backend/models"
+	"context"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type StatusTransitionRepository struct {
+	db sqlx.DB
+}
+
+func NewStatusTransitionRepository(db sqlx.DB) StatusTransitionRepositoryInterface {
+	return &StatusTransitionRepository{db: db}
+}
+
+// Interface
+type StatusTransitionRepositoryInterface interface {
+	Create(ctx context.Context, transition *models.StatusTransition) error
+	ListCompleted(ctx context.Context, filter models.AnalyticsFilter) ([]models.CompletedTask, error)
+	DailyStatusCounts(ctx context.Context, filter models.AnalyticsFilter, firstDay, lastDay time.Time) ([]models.DailyStatusCount, error)
+}
+
+func (r *StatusTransitionRepository) Create(ctx context.Context, transition *models.StatusTransition) error {
+	defer observeQuery("StatusTransitionRepository", "Create")()
+	return r.db.QueryRowxContext(ctx, `
+		INSERT INTO task_status_transitions (task_id, from_status, to_status, changed_by, changed_at)
+		VALUES ($1, $2, $3, $4, NOW())
+		RETURNING id, changed_at
+	`,
+		transition.TaskID,
+		transition.FromStatus,
+		transition.ToStatus,
+		transition.ChangedBy,
+	).StructScan(transition)
+}
+
+// ListCompleted returns tasks currently done whose last move to done was in
+// [filter.From, filter.To)
+func (r *StatusTransitionRepository) ListCompleted(ctx context.Context, filter models.AnalyticsFilter) ([]models.CompletedTask, error) {
+	defer observeQuery("StatusTransitionRepository", "ListCompleted")()
+	tasks := []models.CompletedTask{}
+	err := r.db.SelectContext(ctx, &tasks, `
+		SELECT t.id AS task_id, t.title, t.assignee_id, t.created_at, started.started_at, done.done_at
+		FROM tasks t
+		JOIN LATERAL (
+			SELECT MAX(changed_at) AS done_at FROM task_status_transitions
+			WHERE task_id = t.id AND to_status = 'DONE'
+		) done ON true
+		LEFT JOIN LATERAL (
+			SELECT MIN(changed_at) AS started_at FROM task_status_transitions
+			WHERE task_id = t.id AND to_status = 'IN_PROGRESS' AND changed_at <= done.done_at
+		) started ON true
+		WHERE t.status = 'DONE' AND done.done_at >= $1 AND done.done_at < $2
+			AND (t.assigner_id = $3 OR t.assignee_id = $3)
+			AND ($4::INT IS NULL OR t.assignee_id = $4)
+		ORDER BY done.done_at, t.id
+	`, filter.From, filter.To, filter.ViewerID, filter.AssigneeID)
+	if err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
+
+// DailyStatusCounts counts tasks per status as of the end of each day from
+// firstDay to lastDay. Days without tasks are omitted. Days are stepped in
+// hours, which unlike '1 day' don't shift with the session's daylight saving
+// changes.
+func (r *StatusTransitionRepository) DailyStatusCounts(ctx context.Context, filter models.AnalyticsFilter, firstDay, lastDay time.Time) ([]models.DailyStatusCount, error) {
+	defer observeQuery("StatusTransitionRepository", "DailyStatusCounts")()
+	counts := []models.DailyStatusCount{}
+	err := r.db.SelectContext(ctx, &counts, `
+		SELECT d.day, latest.to_status AS status, COUNT(*) AS count
+		FROM generate_series($1::TIMESTAMPTZ, $2::TIMESTAMPTZ, INTERVAL '24 hours') AS d(day)
+		JOIN LATERAL (
+			SELECT DISTINCT ON (tr.task_id) tr.to_status
+			FROM task_status_transitions tr
+			JOIN tasks t ON t.id = tr.task_id
+			WHERE tr.changed_at < d.day + INTERVAL '24 hours'
+				AND (t.assigner_id = $3 OR t.assignee_id = $3)
+				AND ($4::INT IS NULL OR t.assignee_id = $4)
+			ORDER BY tr.task_id, tr.changed_at DESC, tr.id DESC
+		) latest ON true
+		GROUP BY d.day, latest.to_status
+		ORDER BY d.day, latest.to_status
+	`, firstDay, lastDay, filter.ViewerID, filter.AssigneeID)
+	if err != nil {
+		return nil, err
+	}
+	return counts, nil
+}
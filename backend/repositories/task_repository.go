@@ -1,11 +1,14 @@
-//go:generate mockgen -destination=mocks/mock_user_repository.go -package=mocks backend/repositories UserRepositoryInterface
+//go:generate mockgen -destination=mocks/mock_task_repository.go -package=mocks backend/repositories TaskRepositoryInterface
 
 package repositories
 
 import (
 	"backend/models"
+	"context"
+	"time"
 
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 )
 
 type TaskRepository struct {
@@ -16,29 +19,52 @@ func NewTaskRepository(db sqlx.DB) TaskRepositoryInterface {
 	return &TaskRepository{db: db}
 }
 
+// q returns the transaction carried by ctx or the database
+func (r *TaskRepository) q(ctx context.Context) Querier {
+	return querier(ctx, &r.db)
+}
+
 // Interface
 type TaskRepositoryInterface interface {
-	Create(task *models.Task) (taskResponse *models.Task, err error)
-	Update(task *models.Task) (taskResponse *models.Task, err error)
-	Get(id int64) (task *models.Task, err error)
-	Delete(id int64) (err error)
-	GetTasksByAssignerID(assignerID int64) ([]models.Task, error)
+	Create(ctx context.Context, task *models.Task) (taskResponse *models.Task, err error)
+	Update(ctx context.Context, task *models.Task) (taskResponse *models.Task, err error)
+	Get(ctx context.Context, id int64) (task *models.Task, err error)
+	GetForUpdate(ctx context.Context, id int64) (task *models.Task, err error)
+	Delete(ctx context.Context, id int64) (err error)
+	GetTasksByAssignerID(ctx context.Context, assignerID int64) ([]models.Task, error)
+	CountForUser(ctx context.Context, userID int64) ([]models.TaskCount, error)
+	CountDueForUser(ctx context.Context, userID int64, now, weekEnd time.Time) (overdue int, dueThisWeek int, err error)
+	ListDueForUser(ctx context.Context, userID int64, from *time.Time, to time.Time, limit int) ([]models.Task, error)
+	ListRecentlyUpdatedForUser(ctx context.Context, userID int64, limit int) ([]models.Task, error)
+	ForEachVisible(ctx context.Context, userID int64, filter models.TaskFilter, fn func(task *models.Task) error) error
+	CreateMany(ctx context.Context, tasks []*models.Task) ([]*models.Task, error)
+	GetMany(ctx context.Context, ids []int64) ([]models.Task, error)
+	GetManyForUpdate(ctx context.Context, ids []int64) ([]models.Task, error)
+	UpdateMany(ctx context.Context, tasks []*models.Task) ([]*models.Task, error)
+	DeleteMany(ctx context.Context, ids []int64) error
 }
 
-func (r *TaskRepository) Create(task *models.Task) (taskResponse *models.Task, err error) {
+const taskColumns = `id, title, description, status, assignee_id, assigner_id, priority, due_at, original_estimate_minutes, remaining_estimate_minutes, created_at, updated_at`
+
+func (r *TaskRepository) Create(ctx context.Context, task *models.Task) (taskResponse *models.Task, err error) {
+	defer observeQuery("TaskRepository", "Create")()
 	taskResponse = &models.Task{
-		Title:       task.Title,
-		Description: task.Description,
-		Status:      task.Status,
-		AssigneeID:  task.AssigneeID,
-		AssignerID:  task.AssignerID,
-		Priority:    task.Priority,
+		Title:                    task.Title,
+		Description:              task.Description,
+		Status:                   task.Status,
+		AssigneeID:               task.AssigneeID,
+		AssignerID:               task.AssignerID,
+		Priority:                 task.Priority,
+		DueAt:                    task.DueAt,
+		OriginalEstimateMinutes:  task.OriginalEstimateMinutes,
+		RemainingEstimateMinutes: task.RemainingEstimateMinutes,
 	}
 
-	err = r.db.QueryRow(`
-        INSERT INTO tasks (title, description, status, assignee_id, assigner_id, priority, created_at, updated_at)
-        VALUES ($1, $2, $3, $4, $5, $6, NOW(), NOW())
-        RETURNING id
+	err = r.q(ctx).QueryRowxContext(ctx, `
+        INSERT INTO tasks (title, description, status, assignee_id, assigner_id, priority, due_at,
+            original_estimate_minutes, remaining_estimate_minutes, created_at, updated_at)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, NOW(), NOW())
+        RETURNING id, created_at, updated_at
     `,
 		task.Title,
 		task.Description,
@@ -46,30 +72,38 @@ func (r *TaskRepository) Create(task *models.Task) (taskResponse *models.Task, e
 		task.AssigneeID,
 		task.AssignerID,
 		task.Priority,
-	).Scan(&taskResponse.ID)
+		task.DueAt,
+		task.OriginalEstimateMinutes,
+		task.RemainingEstimateMinutes,
+	).Scan(&taskResponse.ID, &taskResponse.CreatedAt, &taskResponse.UpdatedAt)
 
 	if err != nil {
-		return nil, err
+		return nil, mapError(err)
 	}
 
 	return taskResponse, nil
 }
 
-func (r *TaskRepository) Update(task *models.Task) (taskResponse *models.Task, err error) {
+func (r *TaskRepository) Update(ctx context.Context, task *models.Task) (taskResponse *models.Task, err error) {
+	defer observeQuery("TaskRepository", "Update")()
 	taskResponse = &models.Task{
-		ID:          task.ID,
-		Title:       task.Title,
-		Description: task.Description,
-		Status:      task.Status,
-		AssigneeID:  task.AssigneeID,
-		AssignerID:  task.AssignerID,
-		Priority:    task.Priority,
+		ID:                       task.ID,
+		Title:                    task.Title,
+		Description:              task.Description,
+		Status:                   task.Status,
+		AssigneeID:               task.AssigneeID,
+		AssignerID:               task.AssignerID,
+		Priority:                 task.Priority,
+		DueAt:                    task.DueAt,
+		OriginalEstimateMinutes:  task.OriginalEstimateMinutes,
+		RemainingEstimateMinutes: task.RemainingEstimateMinutes,
 	}
 
-	err = r.db.QueryRow(`
-		UPDATE tasks SET title = $1, description = $2, status = $3, assignee_id = $4, assigner_id = $5, priority = $6, updated_at = NOW()
-		WHERE id = $7
-		RETURNING id
+	err = r.q(ctx).QueryRowxContext(ctx, `
+		UPDATE tasks SET title = $1, description = $2, status = $3, assignee_id = $4, assigner_id = $5, priority = $6, due_at = $7,
+			original_estimate_minutes = $8, remaining_estimate_minutes = $9, updated_at = NOW()
+		WHERE id = $10
+		RETURNING id, created_at, updated_at
 	`,
 		task.Title,
 		task.Description,
@@ -77,24 +111,41 @@ func (r *TaskRepository) Update(task *models.Task) (taskResponse *models.Task, e
 		task.AssigneeID,
 		task.AssignerID,
 		task.Priority,
+		task.DueAt,
+		task.OriginalEstimateMinutes,
+		task.RemainingEstimateMinutes,
 		task.ID,
-	).Scan(&taskResponse.ID)
+	).Scan(&taskResponse.ID, &taskResponse.CreatedAt, &taskResponse.UpdatedAt)
 
 	if err != nil {
-		return nil, err
+		return nil, mapError(err)
 	}
 
 	return taskResponse, nil
 }
 
-func (r *TaskRepository) Get(id int64) (task *models.Task, err error) {
+func (r *TaskRepository) Get(ctx context.Context, id int64) (task *models.Task, err error) {
+	defer observeQuery("TaskRepository", "Get")()
+	return r.get(ctx, id, "")
+}
+
+// GetForUpdate is Get that also locks the row until the transaction carried
+// by ctx ends
+func (r *TaskRepository) GetForUpdate(ctx context.Context, id int64) (task *models.Task, err error) {
+	defer observeQuery("TaskRepository", "GetForUpdate")()
+	return r.get(ctx, id, "FOR UPDATE")
+}
+
+func (r *TaskRepository) get(ctx context.Context, id int64, lock string) (task *models.Task, err error) {
 	// Select the task from the database but not using *
 	task = &models.Task{}
-	err = r.db.QueryRow(`
-		SELECT id, title, description, status, assignee_id, assigner_id, priority, created_at, updated_at
+	err = r.q(ctx).QueryRowxContext(ctx, `
+		SELECT id, title, description, status, assignee_id, assigner_id, priority, due_at,
+			original_estimate_minutes, remaining_estimate_minutes, created_at, updated_at
 		FROM tasks
 		WHERE id = $1
-	`, id).Scan(&task.ID, &task.Title, &task.Description, &task.Status, &task.AssigneeID, &task.AssignerID, &task.Priority, &task.CreatedAt, &task.UpdatedAt)
+	`+lock, id).Scan(&task.ID, &task.Title, &task.Description, &task.Status, &task.AssigneeID, &task.AssignerID, &task.Priority, &task.DueAt,
+		&task.OriginalEstimateMinutes, &task.RemainingEstimateMinutes, &task.CreatedAt, &task.UpdatedAt)
 
 	if err != nil {
 		return nil, err
@@ -103,16 +154,217 @@ func (r *TaskRepository) Get(id int64) (task *models.Task, err error) {
 	return task, nil
 }
 
-func (r *TaskRepository) Delete(id int64) (err error) {
-	_, err = r.db.Exec(`DELETE FROM tasks WHERE id = $1`, id)
+func (r *TaskRepository) Delete(ctx context.Context, id int64) (err error) {
+	defer observeQuery("TaskRepository", "Delete")()
+	_, err = r.q(ctx).ExecContext(ctx, `DELETE FROM tasks WHERE id = $1`, id)
 	return err
 }
 
-func (r *TaskRepository) GetTasksByAssignerID(assignerID int64) ([]models.Task, error) {
+func (r *TaskRepository) GetTasksByAssignerID(ctx context.Context, assignerID int64) ([]models.Task, error) {
+	defer observeQuery("TaskRepository", "GetTasksByAssignerID")()
 	var tasks []models.Task
-	err := r.db.Select(&tasks, "SELECT id, title, description, status, assignee_id, assigner_id, priority, created_at, updated_at FROM tasks WHERE assigner_id = $1", assignerID)
+	err := r.q(ctx).SelectContext(ctx, &tasks, "SELECT "+taskColumns+" FROM tasks WHERE assigner_id = $1", assignerID)
+	if err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
+
+// CountForUser counts the tasks assigned to and created by userID, grouped by
+// status and priority
+func (r *TaskRepository) CountForUser(ctx context.Context, userID int64) ([]models.TaskCount, error) {
+	defer observeQuery("TaskRepository", "CountForUser")()
+	counts := []models.TaskCount{}
+	err := r.q(ctx).SelectContext(ctx, &counts, `
+		SELECT 'assigned' AS role, status, priority, COUNT(*) AS count
+		FROM tasks WHERE assignee_id = $1
+		GROUP BY status, priority
+		UNION ALL
+		SELECT 'created' AS role, status, priority, COUNT(*) AS count
+		FROM tasks WHERE assigner_id = $1
+		GROUP BY status, priority
+	`, userID)
+	if err != nil {
+		return nil, err
+	}
+	return counts, nil
+}
+
+// CountDueForUser counts unfinished tasks visible to userID that are overdue
+// at now or due between now and weekEnd
+func (r *TaskRepository) CountDueForUser(ctx context.Context, userID int64, now, weekEnd time.Time) (overdue int, dueThisWeek int, err error) {
+	defer observeQuery("TaskRepository", "CountDueForUser")()
+	err = r.q(ctx).QueryRowxContext(ctx, `
+		SELECT
+			COUNT(*) FILTER (WHERE due_at < $2),
+			COUNT(*) FILTER (WHERE due_at >= $2 AND due_at < $3)
+		FROM tasks
+		WHERE (assignee_id = $1 OR assigner_id = $1) AND status <> 'DONE' AND due_at IS NOT NULL
+	`, userID, now, weekEnd).Scan(&overdue, &dueThisWeek)
+	return overdue, dueThisWeek, err
+}
+
+// ListDueForUser returns unfinished tasks visible to userID due before to and,
+// when from is set, at or after from, soonest first
+func (r *TaskRepository) ListDueForUser(ctx context.Context, userID int64, from *time.Time, to time.Time, limit int) ([]models.Task, error) {
+	defer observeQuery("TaskRepository", "ListDueForUser")()
+	tasks := []models.Task{}
+	err := r.q(ctx).SelectContext(ctx, &tasks, "SELECT "+taskColumns+` FROM tasks
+		WHERE (assignee_id = $1 OR assigner_id = $1) AND status <> 'DONE'
+			AND due_at < $2 AND ($3::TIMESTAMPTZ IS NULL OR due_at >= $3)
+		ORDER BY due_at, id
+		LIMIT $4
+	`, userID, to, from, limit)
+	if err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
+
+func (r *TaskRepository) ListRecentlyUpdatedForUser(ctx context.Context, userID int64, limit int) ([]models.Task, error) {
+	defer observeQuery("TaskRepository", "ListRecentlyUpdatedForUser")()
+	tasks := []models.Task{}
+	err := r.q(ctx).SelectContext(ctx, &tasks, "SELECT "+taskColumns+` FROM tasks
+		WHERE assignee_id = $1 OR assigner_id = $1
+		ORDER BY updated_at DESC, id DESC
+		LIMIT $2
+	`, userID, limit)
+	if err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
+
+// ForEachVisible calls fn for every task userID assigned or is assigned to
+// that matches filter, ordered by id. Rows are read one at a time so large
+// results are not held in memory. Iteration stops at the first error from fn.
+func (r *TaskRepository) ForEachVisible(ctx context.Context, userID int64, filter models.TaskFilter, fn func(task *models.Task) error) error {
+	defer observeQuery("TaskRepository", "ForEachVisible")()
+	rows, err := r.q(ctx).QueryxContext(ctx, "SELECT "+taskColumns+` FROM tasks
+		WHERE (assignee_id = $1 OR assigner_id = $1)
+			AND ($2::VARCHAR IS NULL OR status = $2)
+			AND ($3::INT IS NULL OR priority = $3)
+			AND ($4::INT IS NULL OR assignee_id = $4)
+		ORDER BY id
+	`, userID, filter.Status, filter.Priority, filter.AssigneeID)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var task models.Task
+		if err := rows.StructScan(&task); err != nil {
+			return err
+		}
+		if err := fn(&task); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// CreateMany inserts tasks in one transaction, joining the one carried by ctx
+// if there is one. Either all are created or, on error, none are.
+func (r *TaskRepository) CreateMany(ctx context.Context, tasks []*models.Task) ([]*models.Task, error) {
+	defer observeQuery("TaskRepository", "CreateMany")()
+	created := make([]*models.Task, 0, len(tasks))
+	err := withinTransaction(ctx, &r.db, func(ctx context.Context, tx Querier) error {
+		for _, task := range tasks {
+			taskResponse := *task
+			err := tx.QueryRowxContext(ctx, `
+			INSERT INTO tasks (title, description, status, assignee_id, assigner_id, priority, due_at,
+				original_estimate_minutes, remaining_estimate_minutes, created_at, updated_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, NOW(), NOW())
+			RETURNING id, created_at, updated_at
+		`,
+				task.Title,
+				task.Description,
+				task.Status,
+				task.AssigneeID,
+				task.AssignerID,
+				task.Priority,
+				task.DueAt,
+				task.OriginalEstimateMinutes,
+				task.RemainingEstimateMinutes,
+			).Scan(&taskResponse.ID, &taskResponse.CreatedAt, &taskResponse.UpdatedAt)
+			if err != nil {
+				return mapError(err)
+			}
+			created = append(created, &taskResponse)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+// GetMany returns the tasks that exist among ids, in no particular order
+func (r *TaskRepository) GetMany(ctx context.Context, ids []int64) ([]models.Task, error) {
+	defer observeQuery("TaskRepository", "GetMany")()
+	tasks := []models.Task{}
+	err := r.q(ctx).SelectContext(ctx, &tasks, "SELECT "+taskColumns+" FROM tasks WHERE id = ANY($1)", pq.Array(ids))
+	if err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
+
+// GetManyForUpdate is GetMany that also locks the rows until the transaction
+// carried by ctx ends. Rows are locked in id order so that two transactions
+// locking overlapping sets can't deadlock.
+func (r *TaskRepository) GetManyForUpdate(ctx context.Context, ids []int64) ([]models.Task, error) {
+	defer observeQuery("TaskRepository", "GetManyForUpdate")()
+	tasks := []models.Task{}
+	err := r.q(ctx).SelectContext(ctx, &tasks, "SELECT "+taskColumns+" FROM tasks WHERE id = ANY($1) ORDER BY id FOR UPDATE", pq.Array(ids))
 	if err != nil {
 		return nil, err
 	}
 	return tasks, nil
 }
+
+// UpdateMany updates tasks in one transaction, joining the one carried by ctx
+// if there is one. Either all are updated or, on error, none are.
+func (r *TaskRepository) UpdateMany(ctx context.Context, tasks []*models.Task) ([]*models.Task, error) {
+	defer observeQuery("TaskRepository", "UpdateMany")()
+	updated := make([]*models.Task, 0, len(tasks))
+	err := withinTransaction(ctx, &r.db, func(ctx context.Context, tx Querier) error {
+		for _, task := range tasks {
+			taskResponse := *task
+			err := tx.QueryRowxContext(ctx, `
+			UPDATE tasks SET title = $1, description = $2, status = $3, assignee_id = $4, assigner_id = $5, priority = $6, due_at = $7,
+				original_estimate_minutes = $8, remaining_estimate_minutes = $9, updated_at = NOW()
+			WHERE id = $10
+			RETURNING updated_at
+		`,
+				task.Title,
+				task.Description,
+				task.Status,
+				task.AssigneeID,
+				task.AssignerID,
+				task.Priority,
+				task.DueAt,
+				task.OriginalEstimateMinutes,
+				task.RemainingEstimateMinutes,
+				task.ID,
+			).Scan(&taskResponse.UpdatedAt)
+			if err != nil {
+				return mapError(err)
+			}
+			updated = append(updated, &taskResponse)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+func (r *TaskRepository) DeleteMany(ctx context.Context, ids []int64) error {
+	defer observeQuery("TaskRepository", "DeleteMany")()
+	_, err := r.q(ctx).ExecContext(ctx, "DELETE FROM tasks WHERE id = ANY($1)", pq.Array(ids))
+	return err
+}
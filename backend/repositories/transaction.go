@@ -0,0 +1,82 @@
+//go:generate mockgen -destination=mocks/mock_transactor.go -package=mocks backend/repositories Transactor
+
+package repositories
+
+import (
+	"backend/pkg/logging"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// Querier is the part of *sqlx.DB and *sqlx.Tx repositories use
+type Querier interface {
+	sqlx.ExtContext
+	GetContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error
+	SelectContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error
+}
+
+// Transactor is a unit of work. Context-aware repository methods called with
+// the context passed to fn run in the same transaction, which is committed
+// when fn returns nil and rolled back otherwise. Nested calls join the
+// outer transaction.
+type Transactor interface {
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+type txKey struct{}
+
+type TxManager struct {
+	db *sqlx.DB
+}
+
+func NewTransactor(db *sqlx.DB) Transactor {
+	return &TxManager{db: db}
+}
+
+func (m *TxManager) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return withinTransaction(ctx, m.db, func(ctx context.Context, _ Querier) error {
+		return fn(ctx)
+	})
+}
+
+// querier returns the transaction carried by ctx, or db outside of one
+func querier(ctx context.Context, db *sqlx.DB) Querier {
+	if tx, ok := ctx.Value(txKey{}).(*sqlx.Tx); ok {
+		return tx
+	}
+	return db
+}
+
+// withinTransaction runs fn in the transaction carried by ctx or, if there is
+// none, in a new one
+func withinTransaction(ctx context.Context, db *sqlx.DB, fn func(ctx context.Context, q Querier) error) (err error) {
+	if tx, ok := ctx.Value(txKey{}).(*sqlx.Tx); ok {
+		return fn(ctx, tx)
+	}
+
+	tx, err := db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p)
+		}
+		if err != nil {
+			if rerr := tx.Rollback(); rerr != nil && !errors.Is(rerr, sql.ErrTxDone) {
+				err = fmt.Errorf("%w (rollback: %v)", err, rerr)
+			}
+			logging.FromContext(ctx).Debug("transaction rolled back", "error", err)
+		}
+	}()
+
+	if err = fn(context.WithValue(ctx, txKey{}, tx), tx); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
@@ -4,6 +4,7 @@ package repositories
 
 import (
 	"backend/models"
+	"context"
 
 	"github.com/jmoiron/sqlx"
 )
@@ -18,47 +19,52 @@ func NewUserRepository(db sqlx.DB) UserRepositoryInterface {
 
 // Interface
 type UserRepositoryInterface interface {
-	Create(user *models.User) error
-	FindByUsername(username string) (*models.User, error)
-	FindByEmail(email string) (*models.User, error)
-	FindById(id int64) (*models.User, error)
+	Create(ctx context.Context, user *models.User) error
+	FindByUsername(ctx context.Context, username string) (*models.User, error)
+	FindByEmail(ctx context.Context, email string) (*models.User, error)
+	FindById(ctx context.Context, id int64) (*models.User, error)
 }
 
-func (r *UserRepository) Create(user *models.User) error {
+func (r *UserRepository) Create(ctx context.Context, user *models.User) error {
+	defer observeQuery("UserRepository", "Create")()
 	query := `
 		INSERT INTO users (username, email, password, created_at, updated_at)
 		VALUES ($1, $2, $3, NOW(), NOW())
 		RETURNING id, created_at, updated_at
 	`
 
-	return r.db.QueryRowx(query,
+	err := querier(ctx, &r.db).QueryRowxContext(ctx, query,
 		user.Username,
 		user.Email,
 		user.Password,
 	).StructScan(user)
+	return mapError(err)
 }
 
-func (r *UserRepository) FindByUsername(username string) (*models.User, error) {
+func (r *UserRepository) FindByUsername(ctx context.Context, username string) (*models.User, error) {
+	defer observeQuery("UserRepository", "FindByUsername")()
 	var user models.User
-	err := r.db.Get(&user, "SELECT username, email, password, created_at, updated_at FROM users WHERE username = $1", username)
+	err := querier(ctx, &r.db).GetContext(ctx, &user, "SELECT id, username, email, password, created_at, updated_at FROM users WHERE username = $1", username)
 	if err != nil {
 		return nil, err
 	}
 	return &user, nil
 }
 
-func (r *UserRepository) FindByEmail(email string) (*models.User, error) {
+func (r *UserRepository) FindByEmail(ctx context.Context, email string) (*models.User, error) {
+	defer observeQuery("UserRepository", "FindByEmail")()
 	var user models.User
-	err := r.db.Get(&user, "SELECT id, username, email, password, created_at, updated_at FROM users WHERE email = $1", email)
+	err := querier(ctx, &r.db).GetContext(ctx, &user, "SELECT id, username, email, password, created_at, updated_at FROM users WHERE email = $1", email)
 	if err != nil {
 		return nil, err
 	}
 	return &user, nil
 }
 
-func (r *UserRepository) FindById(id int64) (*models.User, error) {
+func (r *UserRepository) FindById(ctx context.Context, id int64) (*models.User, error) {
+	defer observeQuery("UserRepository", "FindById")()
 	var user models.User
-	err := r.db.Get(&user, "SELECT id, username, email, password, created_at, updated_at FROM users WHERE id = $1", id)
+	err := querier(ctx, &r.db).GetContext(ctx, &user, "SELECT id, username, email, password, created_at, updated_at FROM users WHERE id = $1", id)
 	if err != nil {
 		return nil, err
 	}
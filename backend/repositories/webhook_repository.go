@@ -0,0 +1,146 @@
+//go:generate mockgen -destination=mocks/mock_webhook_repository.go -package=mocks backend/repositories WebhookRepositoryInterface
+
+package repositories
+
+import (
+	"backend/models"
+	"context"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+type WebhookRepository struct {
+	db sqlx.DB
+}
+
+func NewWebhookRepository(db sqlx.DB) WebhookRepositoryInterface {
+	return &WebhookRepository{db: db}
+}
+
+// Interface
+type WebhookRepositoryInterface interface {
+	CreateSubscription(ctx context.Context, sub *models.WebhookSubscription) error
+	GetSubscription(ctx context.Context, id int64) (*models.WebhookSubscription, error)
+	ListSubscriptionsByUserID(ctx context.Context, userID int64) ([]models.WebhookSubscription, error)
+	FindSubscriptionsForEvent(ctx context.Context, userIDs []int64, eventType string) ([]models.WebhookSubscription, error)
+	DeleteSubscription(ctx context.Context, id int64) error
+	CreateDelivery(ctx context.Context, delivery *models.WebhookDelivery) error
+	GetDelivery(ctx context.Context, id int64) (*models.WebhookDelivery, error)
+	UpdateDelivery(ctx context.Context, delivery *models.WebhookDelivery) error
+	ListDeliveries(ctx context.Context, subscriptionID int64, limit int) ([]models.WebhookDelivery, error)
+}
+
+const webhookSubscriptionColumns = `id, user_id, url, secret, event_types, active, created_at, updated_at`
+
+const webhookDeliveryColumns = `id, subscription_id, event_type, payload, status, attempts, response_code, last_error, created_at, updated_at`
+
+func (r *WebhookRepository) CreateSubscription(ctx context.Context, sub *models.WebhookSubscription) error {
+	defer observeQuery("WebhookRepository", "CreateSubscription")()
+	return r.db.QueryRowxContext(ctx, `
+		INSERT INTO webhook_subscriptions (user_id, url, secret, event_types, active, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, NOW(), NOW())
+		RETURNING id, created_at, updated_at
+	`,
+		sub.UserID,
+		sub.URL,
+		sub.Secret,
+		sub.EventTypes,
+		sub.Active,
+	).StructScan(sub)
+}
+
+func (r *WebhookRepository) GetSubscription(ctx context.Context, id int64) (*models.WebhookSubscription, error) {
+	defer observeQuery("WebhookRepository", "GetSubscription")()
+	var sub models.WebhookSubscription
+	err := r.db.GetContext(ctx, &sub, "SELECT "+webhookSubscriptionColumns+" FROM webhook_subscriptions WHERE id = $1", id)
+	if err != nil {
+		return nil, err
+	}
+	return &sub, nil
+}
+
+func (r *WebhookRepository) ListSubscriptionsByUserID(ctx context.Context, userID int64) ([]models.WebhookSubscription, error) {
+	defer observeQuery("WebhookRepository", "ListSubscriptionsByUserID")()
+	subs := []models.WebhookSubscription{}
+	err := r.db.SelectContext(ctx, &subs, "SELECT "+webhookSubscriptionColumns+" FROM webhook_subscriptions WHERE user_id = $1 ORDER BY id", userID)
+	if err != nil {
+		return nil, err
+	}
+	return subs, nil
+}
+
+func (r *WebhookRepository) FindSubscriptionsForEvent(ctx context.Context, userIDs []int64, eventType string) ([]models.WebhookSubscription, error) {
+	defer observeQuery("WebhookRepository", "FindSubscriptionsForEvent")()
+	var subs []models.WebhookSubscription
+	err := r.db.SelectContext(ctx, &subs, `
+		SELECT `+webhookSubscriptionColumns+`
+		FROM webhook_subscriptions
+		WHERE active AND user_id = ANY($1) AND $2 = ANY(event_types)
+	`, pq.Array(userIDs), eventType)
+	if err != nil {
+		return nil, err
+	}
+	return subs, nil
+}
+
+func (r *WebhookRepository) DeleteSubscription(ctx context.Context, id int64) error {
+	defer observeQuery("WebhookRepository", "DeleteSubscription")()
+	_, err := r.db.ExecContext(ctx, `DELETE FROM webhook_subscriptions WHERE id = $1`, id)
+	return err
+}
+
+func (r *WebhookRepository) CreateDelivery(ctx context.Context, delivery *models.WebhookDelivery) error {
+	defer observeQuery("WebhookRepository", "CreateDelivery")()
+	return r.db.QueryRowxContext(ctx, `
+		INSERT INTO webhook_deliveries (subscription_id, event_type, payload, status, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, NOW(), NOW())
+		RETURNING id, attempts, created_at, updated_at
+	`,
+		delivery.SubscriptionID,
+		delivery.EventType,
+		delivery.Payload,
+		delivery.Status,
+	).StructScan(delivery)
+}
+
+func (r *WebhookRepository) GetDelivery(ctx context.Context, id int64) (*models.WebhookDelivery, error) {
+	defer observeQuery("WebhookRepository", "GetDelivery")()
+	var delivery models.WebhookDelivery
+	err := r.db.GetContext(ctx, &delivery, "SELECT "+webhookDeliveryColumns+" FROM webhook_deliveries WHERE id = $1", id)
+	if err != nil {
+		return nil, err
+	}
+	return &delivery, nil
+}
+
+func (r *WebhookRepository) UpdateDelivery(ctx context.Context, delivery *models.WebhookDelivery) error {
+	defer observeQuery("WebhookRepository", "UpdateDelivery")()
+	_, err := r.db.ExecContext(ctx, `
+		UPDATE webhook_deliveries SET status = $1, attempts = $2, response_code = $3, last_error = $4, updated_at = NOW()
+		WHERE id = $5
+	`,
+		delivery.Status,
+		delivery.Attempts,
+		delivery.ResponseCode,
+		delivery.LastError,
+		delivery.ID,
+	)
+	return err
+}
+
+func (r *WebhookRepository) ListDeliveries(ctx context.Context, subscriptionID int64, limit int) ([]models.WebhookDelivery, error) {
+	defer observeQuery("WebhookRepository", "ListDeliveries")()
+	deliveries := []models.WebhookDelivery{}
+	err := r.db.SelectContext(ctx, &deliveries, `
+		SELECT `+webhookDeliveryColumns+`
+		FROM webhook_deliveries
+		WHERE subscription_id = $1
+		ORDER BY created_at DESC, id DESC
+		LIMIT $2
+	`, subscriptionID, limit)
+	if err != nil {
+		return nil, err
+	}
+	return deliveries, nil
+}
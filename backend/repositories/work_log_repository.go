@@ -0,0 +1,122 @@
+//go:generate mockgen -destination=mocks/mock_work_log_repository.go -package=mocks backend/repositories WorkLogRepositoryInterface
+
+package repositories
+
+import (
+	"backend/models"
+	"context"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type WorkLogRepository struct {
+	db sqlx.DB
+}
+
+func NewWorkLogRepository(db sqlx.DB) WorkLogRepositoryInterface {
+	return &WorkLogRepository{db: db}
+}
+
+// Interface
+type WorkLogRepositoryInterface interface {
+	Create(ctx context.Context, workLog *models.WorkLog) error
+	GetRunningTimer(ctx context.Context, userID int64) (*models.WorkLog, error)
+	StopTimer(ctx context.Context, id int64, endedAt time.Time) (*models.WorkLog, error)
+	ListByTaskID(ctx context.Context, taskID int64) ([]models.WorkLog, error)
+	TotalsForTask(ctx context.Context, taskID int64, from, to time.Time) ([]models.UserTimeTotal, error)
+	TotalsForUser(ctx context.Context, userID, viewerID int64, from, to time.Time) ([]models.TaskTimeTotal, error)
+}
+
+const workLogColumns = `id, task_id, user_id, started_at, ended_at, duration_seconds, note, created_at`
+
+// Create inserts a work log. Leaving EndedAt nil starts a timer, which fails
+// with ErrDuplicate while the user has another one running.
+func (r *WorkLogRepository) Create(ctx context.Context, workLog *models.WorkLog) error {
+	defer observeQuery("WorkLogRepository", "Create")()
+	err := r.db.QueryRowxContext(ctx, `
+		INSERT INTO work_logs (task_id, user_id, started_at, ended_at, duration_seconds, note, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, NOW())
+		RETURNING id, created_at
+	`,
+		workLog.TaskID,
+		workLog.UserID,
+		workLog.StartedAt,
+		workLog.EndedAt,
+		workLog.DurationSeconds,
+		workLog.Note,
+	).StructScan(workLog)
+	return mapError(err)
+}
+
+func (r *WorkLogRepository) GetRunningTimer(ctx context.Context, userID int64) (*models.WorkLog, error) {
+	defer observeQuery("WorkLogRepository", "GetRunningTimer")()
+	var workLog models.WorkLog
+	err := r.db.GetContext(ctx, &workLog, "SELECT "+workLogColumns+" FROM work_logs WHERE user_id = $1 AND ended_at IS NULL", userID)
+	if err != nil {
+		return nil, err
+	}
+	return &workLog, nil
+}
+
+func (r *WorkLogRepository) StopTimer(ctx context.Context, id int64, endedAt time.Time) (*models.WorkLog, error) {
+	defer observeQuery("WorkLogRepository", "StopTimer")()
+	var workLog models.WorkLog
+	err := r.db.GetContext(ctx, &workLog, `
+		UPDATE work_logs
+		SET ended_at = $2, duration_seconds = GREATEST(0, EXTRACT(EPOCH FROM ($2 - started_at)))::INT
+		WHERE id = $1 AND ended_at IS NULL
+		RETURNING `+workLogColumns, id, endedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &workLog, nil
+}
+
+func (r *WorkLogRepository) ListByTaskID(ctx context.Context, taskID int64) ([]models.WorkLog, error) {
+	defer observeQuery("WorkLogRepository", "ListByTaskID")()
+	workLogs := []models.WorkLog{}
+	err := r.db.SelectContext(ctx, &workLogs, "SELECT "+workLogColumns+" FROM work_logs WHERE task_id = $1 ORDER BY started_at, id", taskID)
+	if err != nil {
+		return nil, err
+	}
+	return workLogs, nil
+}
+
+// TotalsForTask sums finished work logs started in [from, to) per user
+func (r *WorkLogRepository) TotalsForTask(ctx context.Context, taskID int64, from, to time.Time) ([]models.UserTimeTotal, error) {
+	defer observeQuery("WorkLogRepository", "TotalsForTask")()
+	totals := []models.UserTimeTotal{}
+	err := r.db.SelectContext(ctx, &totals, `
+		SELECT w.user_id, u.username, SUM(w.duration_seconds) AS total_seconds
+		FROM work_logs w
+		JOIN users u ON u.id = w.user_id
+		WHERE w.task_id = $1 AND w.ended_at IS NOT NULL AND w.started_at >= $2 AND w.started_at < $3
+		GROUP BY w.user_id, u.username
+		ORDER BY total_seconds DESC
+	`, taskID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	return totals, nil
+}
+
+// TotalsForUser sums userID's finished work logs started in [from, to) per
+// task, limited to tasks viewerID assigned or is assigned to
+func (r *WorkLogRepository) TotalsForUser(ctx context.Context, userID, viewerID int64, from, to time.Time) ([]models.TaskTimeTotal, error) {
+	defer observeQuery("WorkLogRepository", "TotalsForUser")()
+	totals := []models.TaskTimeTotal{}
+	err := r.db.SelectContext(ctx, &totals, `
+		SELECT w.task_id, t.title, SUM(w.duration_seconds) AS total_seconds
+		FROM work_logs w
+		JOIN tasks t ON t.id = w.task_id
+		WHERE w.user_id = $1 AND (t.assigner_id = $2 OR t.assignee_id = $2)
+			AND w.ended_at IS NOT NULL AND w.started_at >= $3 AND w.started_at < $4
+		GROUP BY w.task_id, t.title
+		ORDER BY total_seconds DESC
+	`, userID, viewerID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	return totals, nil
+}
@@ -1,13 +1,25 @@
 package routes
 
 import (
+	"backend/config"
 	"backend/handlers"
 	"backend/middleware"
+	"regexp"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/cors"
 )
 
+// attachmentUpload matches the path attachments are uploaded to. Routing is
+// case insensitive and ignores a trailing slash.
+var attachmentUpload = regexp.MustCompile(`(?i)^/api/task/[^/]+/attachments/?$`)
+
+// HasOwnBodyLimit reports whether the request goes to a route that sets a
+// body limit larger than the app's
+func HasOwnBodyLimit(c *fiber.Ctx) bool {
+	return c.Method() == fiber.MethodPost && attachmentUpload.MatchString(c.Path())
+}
+
 // @title Task Manager API
 // @version 1.0
 // @description This is a task management server.
@@ -26,14 +38,16 @@ import (
 // @in header
 // @name Authorization
 // @description Enter your bearer token in the format **Bearer &lt;token&gt;**
-func SetupRoutes(app *fiber.App, userHandler *handlers.UserHandler, taskHandler *handlers.TaskHandler) {
+func SetupRoutes(app *fiber.App, cfg *config.Config, userHandler *handlers.UserHandler, taskHandler *handlers.TaskHandler, webhookHandler *handlers.WebhookHandler, streamHandler *handlers.StreamHandler, notificationHandler *handlers.NotificationHandler, mentionHandler *handlers.MentionHandler, attachmentHandler *handlers.AttachmentHandler, timeTrackingHandler *handlers.TimeTrackingHandler, analyticsHandler *handlers.AnalyticsHandler, dashboardHandler *handlers.DashboardHandler, exportHandler *handlers.ExportHandler, importHandler *handlers.ImportHandler, calendarHandler *handlers.CalendarHandler) {
 	app.Use(cors.New(cors.Config{
-		AllowOrigins:     "http://localhost:3000",
+		AllowOrigins:     cfg.CORSOrigins,
 		AllowHeaders:     "Origin, Content-Type, Accept, Authorization",
 		AllowMethods:     "GET,POST,HEAD,PUT,DELETE,PATCH",
 		AllowCredentials: true,
 	}))
 
+	requireAuth := middleware.AuthMiddleware(cfg.JWTSecret)
+
 	api := app.Group("/api")
 	auth := api.Group("/auth")
 
@@ -42,14 +56,84 @@ func SetupRoutes(app *fiber.App, userHandler *handlers.UserHandler, taskHandler
 	auth.Post("/login", userHandler.Login)
 
 	// Protected routes
-	protected := auth.Group("/", middleware.AuthMiddleware())
+	protected := auth.Group("/", requireAuth)
 	protected.Get("/profile", userHandler.GetProfile) // Fixed this line
 
+	// Task event stream, registered ahead of the task group so EventSource
+	// clients can authenticate with a query parameter
+	api.Get("/task/stream", middleware.QueryTokenMiddleware(), requireAuth, streamHandler.StreamTasks)
+
 	// Task routes (already protected correctly)
-	task := api.Group("/task", middleware.AuthMiddleware())
+	task := api.Group("/task", requireAuth)
 	task.Get("/assigner", taskHandler.GetTasksByAssignerID)
+	task.Get("/export", exportHandler.ExportTasks)
 	task.Post("/", taskHandler.CreateTask)
+	task.Post("/import", importHandler.ImportTasks)
+	task.Post("/bulk", taskHandler.BulkUpdateTasks)
 	task.Put("/:id", taskHandler.UpdateTask)
 	task.Get("/:id", taskHandler.GetTask)
 	task.Delete("/:id", taskHandler.DeleteTask)
+
+	// Dashboard
+	api.Get("/dashboard", requireAuth, dashboardHandler.GetDashboard)
+
+	// The remaining features need PostgreSQL and their handlers are nil when
+	// the server runs with another storage
+	if mentionHandler != nil {
+		task.Get("/:id/mentions", mentionHandler.GetTaskMentions)
+	}
+	if attachmentHandler != nil {
+		// Leave room for multipart overhead on top of the largest attachment
+		uploadLimit := middleware.BodyLimit(int(cfg.MaxAttachmentSize)+1<<20, nil)
+		task.Post("/:id/attachments", uploadLimit, attachmentHandler.UploadAttachment)
+		task.Get("/:id/attachments", attachmentHandler.ListAttachments)
+		task.Get("/:id/attachments/:attachmentId", attachmentHandler.DownloadAttachment)
+		task.Delete("/:id/attachments/:attachmentId", attachmentHandler.DeleteAttachment)
+	}
+	if timeTrackingHandler != nil {
+		task.Post("/:id/timer/start", timeTrackingHandler.StartTimer)
+		task.Post("/:id/timer/stop", timeTrackingHandler.StopTimer)
+		task.Post("/:id/worklogs", timeTrackingHandler.LogWork)
+		task.Get("/:id/worklogs", timeTrackingHandler.ListWorkLogs)
+		task.Get("/:id/time-totals", timeTrackingHandler.GetTaskTimeTotals)
+
+		// Time tracking totals across tasks
+		api.Get("/time-totals", requireAuth, timeTrackingHandler.GetUserTimeTotals)
+	}
+
+	// Analytics routes
+	if analyticsHandler != nil {
+		analytics := api.Group("/analytics", requireAuth)
+		analytics.Get("/cycle-time", analyticsHandler.GetCycleTime)
+		analytics.Get("/throughput", analyticsHandler.GetThroughput)
+		analytics.Get("/cumulative-flow", analyticsHandler.GetCumulativeFlow)
+	}
+
+	// Calendar feed, authenticated by the secret token in its URL
+	if calendarHandler != nil {
+		api.Get("/calendar/feed/:token.ics", calendarHandler.GetCalendarFeed)
+		calendar := api.Group("/calendar", requireAuth)
+		calendar.Post("/token", calendarHandler.CreateCalendarToken)
+		calendar.Get("/token", calendarHandler.GetCalendarToken)
+		calendar.Delete("/token", calendarHandler.RevokeCalendarToken)
+	}
+
+	// Webhook routes
+	if webhookHandler != nil {
+		webhook := api.Group("/webhooks", requireAuth)
+		webhook.Post("/", webhookHandler.CreateWebhook)
+		webhook.Get("/", webhookHandler.ListWebhooks)
+		webhook.Delete("/:id", webhookHandler.DeleteWebhook)
+		webhook.Get("/:id/deliveries", webhookHandler.ListWebhookDeliveries)
+	}
+
+	// Notification routes
+	if notificationHandler != nil {
+		notification := api.Group("/notifications", requireAuth)
+		notification.Get("/", notificationHandler.ListNotifications)
+		notification.Post("/read-all", notificationHandler.MarkAllNotificationsRead)
+		notification.Get("/preferences", notificationHandler.GetNotificationPreferences)
+		notification.Put("/preferences", notificationHandler.UpdateNotificationPreferences)
+		notification.Post("/:id/read", notificationHandler.MarkNotificationRead)
+	}
 }
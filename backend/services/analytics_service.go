@@ -0,0 +1,251 @@
+package services
+
+import (
+	"backend/models"
+	"backend/pkg/logging"
+	"backend/repositories"
+	"context"
+	"math"
+	"sort"
+	"time"
+)
+
+// maxCumulativeFlowDays bounds the number of days a cumulative flow report
+// covers
+const maxCumulativeFlowDays = 366
+
+var ErrTimeRangeTooLong = newError(ErrValidation, "time range is too long")
+
+type AnalyticsServiceInterface interface {
+	CycleTime(ctx context.Context, filter models.AnalyticsFilter) (*CycleTimeReport, error)
+	Throughput(ctx context.Context, filter models.AnalyticsFilter) (*ThroughputReport, error)
+	CumulativeFlow(ctx context.Context, filter models.AnalyticsFilter) (*CumulativeFlowReport, error)
+}
+
+// DurationStats summarises durations in hours
+type DurationStats struct {
+	Count        int     `json:"count"`
+	AverageHours float64 `json:"average_hours"`
+	MedianHours  float64 `json:"median_hours"`
+	P85Hours     float64 `json:"p85_hours"`
+}
+
+// CycleTimeReport covers tasks completed in [From, To). Lead time runs from
+// creation to done, cycle time from first moving to in progress to done.
+type CycleTimeReport struct {
+	From      time.Time              `json:"from"`
+	To        time.Time              `json:"to"`
+	LeadTime  DurationStats          `json:"lead_time"`
+	CycleTime DurationStats          `json:"cycle_time"`
+	Tasks     []models.CompletedTask `json:"tasks"`
+}
+
+type WeeklyThroughput struct {
+	WeekStart time.Time `json:"week_start"`
+	Completed int       `json:"completed"`
+}
+
+// ThroughputReport counts tasks completed per week, weeks starting on Monday
+type ThroughputReport struct {
+	From  time.Time          `json:"from"`
+	To    time.Time          `json:"to"`
+	Total int                `json:"total"`
+	Weeks []WeeklyThroughput `json:"weeks"`
+}
+
+type CumulativeFlowDay struct {
+	Day    time.Time                 `json:"day"`
+	Counts map[models.TaskStatus]int `json:"counts"`
+}
+
+// CumulativeFlowReport has the number of tasks in each status at the end of
+// every day in the range
+type CumulativeFlowReport struct {
+	From time.Time           `json:"from"`
+	To   time.Time           `json:"to"`
+	Days []CumulativeFlowDay `json:"days"`
+}
+
+// AnalyticsService records status transitions from task events and computes
+// flow metrics from them
+type AnalyticsService struct {
+	transitionRepo repositories.StatusTransitionRepositoryInterface
+}
+
+func NewAnalyticsService(transitionRepo repositories.StatusTransitionRepositoryInterface) *AnalyticsService {
+	return &AnalyticsService{transitionRepo: transitionRepo}
+}
+
+// OnTaskEvent records the initial status of created tasks and status changes
+// of updated ones
+func (s *AnalyticsService) OnTaskEvent(ctx context.Context, event TaskEvent) {
+	transition := &models.StatusTransition{
+		TaskID:    event.Task.ID,
+		ToStatus:  event.Task.Status,
+		ChangedBy: event.ActorID,
+	}
+
+	switch event.Type {
+	case TaskCreated:
+	case TaskUpdated:
+		if event.Previous == nil || event.Previous.Status == event.Task.Status {
+			return
+		}
+		from := event.Previous.Status
+		transition.FromStatus = &from
+	default:
+		return
+	}
+
+	if err := s.transitionRepo.Create(ctx, transition); err != nil {
+		logging.FromContext(ctx).Error("record status transition failed", "task_id", event.Task.ID, "error", err)
+	}
+}
+
+func (s *AnalyticsService) CycleTime(ctx context.Context, filter models.AnalyticsFilter) (*CycleTimeReport, error) {
+	filter, err := normalizeAnalyticsFilter(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	tasks, err := s.transitionRepo.ListCompleted(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	var leadTimes, cycleTimes []time.Duration
+	for _, task := range tasks {
+		leadTimes = append(leadTimes, task.DoneAt.Sub(task.CreatedAt))
+		if task.StartedAt != nil {
+			cycleTimes = append(cycleTimes, task.DoneAt.Sub(*task.StartedAt))
+		}
+	}
+
+	return &CycleTimeReport{
+		From:      filter.From,
+		To:        filter.To,
+		LeadTime:  durationStats(leadTimes),
+		CycleTime: durationStats(cycleTimes),
+		Tasks:     tasks,
+	}, nil
+}
+
+func (s *AnalyticsService) Throughput(ctx context.Context, filter models.AnalyticsFilter) (*ThroughputReport, error) {
+	filter, err := normalizeAnalyticsFilter(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	tasks, err := s.transitionRepo.ListCompleted(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	completed := make(map[time.Time]int)
+	for _, task := range tasks {
+		completed[weekStart(task.DoneAt)]++
+	}
+
+	report := &ThroughputReport{From: filter.From, To: filter.To, Total: len(tasks)}
+	for week := weekStart(filter.From); week.Before(filter.To); week = week.AddDate(0, 0, 7) {
+		report.Weeks = append(report.Weeks, WeeklyThroughput{WeekStart: week, Completed: completed[week]})
+	}
+	return report, nil
+}
+
+func (s *AnalyticsService) CumulativeFlow(ctx context.Context, filter models.AnalyticsFilter) (*CumulativeFlowReport, error) {
+	filter, err := normalizeAnalyticsFilter(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	firstDay := dayStart(filter.From)
+	lastDay := dayStart(filter.To.Add(-time.Nanosecond))
+	if lastDay.Sub(firstDay) >= maxCumulativeFlowDays*24*time.Hour {
+		return nil, ErrTimeRangeTooLong
+	}
+
+	counts, err := s.transitionRepo.DailyStatusCounts(ctx, filter, firstDay, lastDay)
+	if err != nil {
+		return nil, err
+	}
+
+	byDay := make(map[time.Time]map[models.TaskStatus]int)
+	for _, count := range counts {
+		// Returned in the database session's time zone
+		day := dayStart(count.Day.UTC())
+		if byDay[day] == nil {
+			byDay[day] = make(map[models.TaskStatus]int)
+		}
+		byDay[day][count.Status] = count.Count
+	}
+
+	report := &CumulativeFlowReport{From: filter.From, To: filter.To}
+	for day := firstDay; !day.After(lastDay); day = day.AddDate(0, 0, 1) {
+		dayCounts := map[models.TaskStatus]int{
+			models.StatusToDo:       0,
+			models.StatusInProgress: 0,
+			models.StatusDone:       0,
+		}
+		for status, n := range byDay[day] {
+			dayCounts[status] = n
+		}
+		report.Days = append(report.Days, CumulativeFlowDay{Day: day, Counts: dayCounts})
+	}
+	return report, nil
+}
+
+// normalizeAnalyticsFilter converts the range to UTC since the timestamp
+// columns have no time zone
+func normalizeAnalyticsFilter(filter models.AnalyticsFilter) (models.AnalyticsFilter, error) {
+	filter.From = filter.From.UTC()
+	filter.To = filter.To.UTC()
+	if !filter.From.Before(filter.To) {
+		return filter, ErrInvalidTimeRange
+	}
+	return filter, nil
+}
+
+func durationStats(durations []time.Duration) DurationStats {
+	stats := DurationStats{Count: len(durations)}
+	if len(durations) == 0 {
+		return stats
+	}
+
+	hours := make([]float64, len(durations))
+	var sum float64
+	for i, d := range durations {
+		hours[i] = d.Hours()
+		sum += hours[i]
+	}
+	sort.Float64s(hours)
+
+	stats.AverageHours = roundHours(sum / float64(len(hours)))
+	stats.MedianHours = roundHours(percentile(hours, 0.5))
+	stats.P85Hours = roundHours(percentile(hours, 0.85))
+	return stats
+}
+
+// percentile interpolates linearly between the closest ranks of sorted
+func percentile(sorted []float64, p float64) float64 {
+	rank := p * float64(len(sorted)-1)
+	lower := int(math.Floor(rank))
+	upper := int(math.Ceil(rank))
+	return sorted[lower] + (sorted[upper]-sorted[lower])*(rank-float64(lower))
+}
+
+func roundHours(h float64) float64 {
+	return math.Round(h*100) / 100
+}
+
+func dayStart(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// weekStart returns the Monday starting the week of t
+func weekStart(t time.Time) time.Time {
+	day := dayStart(t)
+	offset := (int(day.Weekday()) + 6) % 7
+	return day.AddDate(0, 0, -offset)
+}
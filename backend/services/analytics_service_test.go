@@ -0,0 +1,106 @@
+package services
+
+import (
+	"backend/models"
+	"context"
+	"testing"
+	"time"
+
+	mock_repo "backend/repositories/mocks"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAnalyticsService_OnTaskEvent(t *testing.T) {
+	actor := int64(1)
+	toDo := models.StatusToDo
+
+	tests := []struct {
+		name           string
+		event          TaskEvent
+		expectedRecord *models.StatusTransition
+	}{
+		{
+			name:           "Created task records its initial status",
+			event:          TaskEvent{Type: TaskCreated, Task: &models.Task{ID: 5, Status: models.StatusToDo}, ActorID: &actor},
+			expectedRecord: &models.StatusTransition{TaskID: 5, ToStatus: models.StatusToDo, ChangedBy: &actor},
+		},
+		{
+			name: "Status change is recorded",
+			event: TaskEvent{
+				Type:     TaskUpdated,
+				Task:     &models.Task{ID: 5, Status: models.StatusInProgress},
+				Previous: &models.Task{ID: 5, Status: models.StatusToDo},
+				ActorID:  &actor,
+			},
+			expectedRecord: &models.StatusTransition{TaskID: 5, FromStatus: &toDo, ToStatus: models.StatusInProgress, ChangedBy: &actor},
+		},
+		{
+			name: "Update without status change is ignored",
+			event: TaskEvent{
+				Type:     TaskUpdated,
+				Task:     &models.Task{ID: 5, Title: "Renamed", Status: models.StatusToDo},
+				Previous: &models.Task{ID: 5, Status: models.StatusToDo},
+			},
+		},
+		{
+			name:  "Delete is ignored",
+			event: TaskEvent{Type: TaskDeleted, Task: &models.Task{ID: 5, Status: models.StatusDone}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			transitionRepo := mock_repo.NewMockStatusTransitionRepositoryInterface(ctrl)
+			if tt.expectedRecord != nil {
+				transitionRepo.EXPECT().Create(gomock.Any(), tt.expectedRecord).Return(nil)
+			}
+
+			NewAnalyticsService(transitionRepo).OnTaskEvent(context.Background(), tt.event)
+		})
+	}
+}
+
+func TestAnalyticsService_CycleTimeAndThroughput(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	day := func(d, h int) time.Time { return time.Date(2026, 3, d, h, 0, 0, 0, time.UTC) }
+	at := func(t time.Time) *time.Time { return &t }
+	completed := []models.CompletedTask{
+		// Monday 2 March to Friday 6 March
+		{TaskID: 1, CreatedAt: day(1, 0), StartedAt: at(day(2, 0)), DoneAt: day(2, 10)},
+		{TaskID: 2, CreatedAt: day(1, 0), StartedAt: at(day(2, 0)), DoneAt: day(3, 0)},
+		// Never moved to in progress
+		{TaskID: 3, CreatedAt: day(6, 0), DoneAt: day(7, 0)},
+		// Following week
+		{TaskID: 4, CreatedAt: day(9, 0), StartedAt: at(day(9, 0)), DoneAt: day(10, 20)},
+	}
+
+	filter := models.AnalyticsFilter{ViewerID: 1, From: day(1, 0), To: day(15, 0)}
+	transitionRepo := mock_repo.NewMockStatusTransitionRepositoryInterface(ctrl)
+	transitionRepo.EXPECT().ListCompleted(gomock.Any(), filter).Return(completed, nil).Times(2)
+	service := NewAnalyticsService(transitionRepo)
+
+	cycleTime, err := service.CycleTime(context.Background(), filter)
+	require.NoError(t, err)
+	assert.Equal(t, DurationStats{Count: 4, AverageHours: 37.5, MedianHours: 39, P85Hours: 46.2}, cycleTime.LeadTime)
+	assert.Equal(t, DurationStats{Count: 3, AverageHours: 26, MedianHours: 24, P85Hours: 38}, cycleTime.CycleTime)
+
+	throughput, err := service.Throughput(context.Background(), filter)
+	require.NoError(t, err)
+	assert.Equal(t, 4, throughput.Total)
+	assert.Equal(t, []WeeklyThroughput{
+		{WeekStart: time.Date(2026, 2, 23, 0, 0, 0, 0, time.UTC), Completed: 0},
+		{WeekStart: day(2, 0), Completed: 3},
+		{WeekStart: day(9, 0), Completed: 1},
+	}, throughput.Weeks)
+
+	_, err = service.Throughput(context.Background(), models.AnalyticsFilter{From: day(2, 0), To: day(2, 0)})
+	assert.ErrorIs(t, err, ErrInvalidTimeRange)
+}
@@ -0,0 +1,287 @@
+package services
+
+import (
+	"backend/models"
+	"backend/pkg/blob"
+	"backend/pkg/logging"
+	"backend/repositories"
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"unicode"
+)
+
+// JobTypeAttachmentPurge removes the attachments of a deleted task
+const JobTypeAttachmentPurge = "attachment.purge"
+
+var (
+	ErrAttachmentNotFound    = newError(ErrNotFound, "attachment not found")
+	ErrAttachmentTooLarge    = newError(ErrValidation, "attachment is too large")
+	ErrAttachmentTypeInvalid = newError(ErrValidation, "attachment type is not allowed")
+)
+
+type AttachmentServiceInterface interface {
+	Upload(ctx context.Context, userID, taskID int64, filename string, size int64, r io.Reader) (*models.Attachment, error)
+	List(ctx context.Context, userID, taskID int64) ([]models.Attachment, error)
+	Open(ctx context.Context, userID, taskID, attachmentID int64) (*models.Attachment, io.ReadCloser, error)
+	Delete(ctx context.Context, userID, taskID, attachmentID int64) error
+}
+
+// AttachmentService stores task attachments in a BlobStore and their
+// metadata in the database
+type AttachmentService struct {
+	attachmentRepo repositories.AttachmentRepositoryInterface
+	taskRepo       repositories.TaskRepositoryInterface
+	jobRepo        repositories.JobRepositoryInterface
+	store          blob.BlobStore
+	maxSize        int64
+	allowedTypes   map[string]bool
+}
+
+func NewAttachmentService(
+	attachmentRepo repositories.AttachmentRepositoryInterface,
+	taskRepo repositories.TaskRepositoryInterface,
+	jobRepo repositories.JobRepositoryInterface,
+	store blob.BlobStore,
+	maxSize int64,
+	allowedTypes []string,
+) *AttachmentService {
+	allowed := make(map[string]bool, len(allowedTypes))
+	for _, t := range allowedTypes {
+		allowed[strings.ToLower(strings.TrimSpace(t))] = true
+	}
+
+	return &AttachmentService{
+		attachmentRepo: attachmentRepo,
+		taskRepo:       taskRepo,
+		jobRepo:        jobRepo,
+		store:          store,
+		maxSize:        maxSize,
+		allowedTypes:   allowed,
+	}
+}
+
+// Upload stores the content of r for the task. The content type is sniffed
+// from the data rather than trusted from the client.
+func (s *AttachmentService) Upload(ctx context.Context, userID, taskID int64, filename string, size int64, r io.Reader) (*models.Attachment, error) {
+	if _, err := s.visibleTask(ctx, userID, taskID); err != nil {
+		return nil, err
+	}
+	if size > s.maxSize {
+		return nil, ErrAttachmentTooLarge
+	}
+
+	head := make([]byte, 512)
+	n, err := io.ReadFull(r, head)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+	head = head[:n]
+
+	contentType, _, err := mime.ParseMediaType(http.DetectContentType(head))
+	if err != nil || !s.allowedTypes[contentType] {
+		return nil, fmt.Errorf("%w: %s", ErrAttachmentTypeInvalid, contentType)
+	}
+
+	key, err := attachmentKey(taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	hasher := sha256.New()
+	counter := &countingWriter{}
+	body := io.TeeReader(
+		io.LimitReader(io.MultiReader(bytes.NewReader(head), r), s.maxSize+1),
+		io.MultiWriter(hasher, counter),
+	)
+	if err := s.store.Put(ctx, key, body, size, contentType); err != nil {
+		return nil, err
+	}
+
+	// The declared size is only a hint, enforce the limit on what was read
+	if counter.n > s.maxSize {
+		s.deleteBlob(ctx, key)
+		return nil, ErrAttachmentTooLarge
+	}
+
+	attachment := &models.Attachment{
+		TaskID:         taskID,
+		UploaderID:     &userID,
+		Filename:       sanitizeFilename(filename),
+		ContentType:    contentType,
+		SizeBytes:      counter.n,
+		ChecksumSHA256: hex.EncodeToString(hasher.Sum(nil)),
+		StorageKey:     key,
+	}
+	if err := s.attachmentRepo.Create(ctx, attachment); err != nil {
+		s.deleteBlob(ctx, key)
+		return nil, err
+	}
+
+	return attachment, nil
+}
+
+func (s *AttachmentService) List(ctx context.Context, userID, taskID int64) ([]models.Attachment, error) {
+	if _, err := s.visibleTask(ctx, userID, taskID); err != nil {
+		return nil, err
+	}
+	return s.attachmentRepo.ListByTaskID(ctx, taskID)
+}
+
+// Open returns the attachment metadata and a reader for its content. The
+// caller must close the reader.
+func (s *AttachmentService) Open(ctx context.Context, userID, taskID, attachmentID int64) (*models.Attachment, io.ReadCloser, error) {
+	attachment, err := s.visibleAttachment(ctx, userID, taskID, attachmentID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	content, err := s.store.Get(ctx, attachment.StorageKey)
+	if errors.Is(err, blob.ErrNotFound) {
+		return nil, nil, ErrAttachmentNotFound
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	return attachment, content, nil
+}
+
+func (s *AttachmentService) Delete(ctx context.Context, userID, taskID, attachmentID int64) error {
+	attachment, err := s.visibleAttachment(ctx, userID, taskID, attachmentID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.store.Delete(ctx, attachment.StorageKey); err != nil {
+		return err
+	}
+	return s.attachmentRepo.Delete(ctx, attachment.ID)
+}
+
+// OnTaskEvent schedules removal of a deleted task's attachments
+func (s *AttachmentService) OnTaskEvent(ctx context.Context, event TaskEvent) {
+	if event.Type != TaskDeleted {
+		return
+	}
+
+	payload, err := json.Marshal(attachmentPurgePayload{TaskID: event.Task.ID})
+	if err == nil {
+		_, err = s.jobRepo.Enqueue(ctx, &models.Job{Type: JobTypeAttachmentPurge, Payload: string(payload)})
+	}
+	if err != nil {
+		logging.FromContext(ctx).Error("schedule attachment purge failed", "task_id", event.Task.ID, "error", err)
+	}
+}
+
+type attachmentPurgePayload struct {
+	TaskID int64 `json:"task_id"`
+}
+
+// HandlePurge is the jobs.Handler for JobTypeAttachmentPurge. Blobs are
+// removed before their rows so a failed run can be retried.
+func (s *AttachmentService) HandlePurge(ctx context.Context, job *models.Job) error {
+	var payload attachmentPurgePayload
+	if err := json.Unmarshal([]byte(job.Payload), &payload); err != nil {
+		return err
+	}
+
+	attachments, err := s.attachmentRepo.ListByTaskID(ctx, payload.TaskID)
+	if err != nil {
+		return err
+	}
+
+	for _, attachment := range attachments {
+		if err := s.store.Delete(ctx, attachment.StorageKey); err != nil {
+			return err
+		}
+		if err := s.attachmentRepo.Delete(ctx, attachment.ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *AttachmentService) visibleTask(ctx context.Context, userID, taskID int64) (*models.Task, error) {
+	task, err := s.taskRepo.Get(ctx, taskID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrTaskNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !taskVisibleTo(task, userID) {
+		return nil, ErrTaskNotFound
+	}
+	return task, nil
+}
+
+func (s *AttachmentService) visibleAttachment(ctx context.Context, userID, taskID, attachmentID int64) (*models.Attachment, error) {
+	if _, err := s.visibleTask(ctx, userID, taskID); err != nil {
+		return nil, err
+	}
+
+	attachment, err := s.attachmentRepo.Get(ctx, attachmentID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrAttachmentNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if attachment.TaskID != taskID {
+		return nil, ErrAttachmentNotFound
+	}
+	return attachment, nil
+}
+
+func (s *AttachmentService) deleteBlob(ctx context.Context, key string) {
+	if err := s.store.Delete(ctx, key); err != nil {
+		logging.FromContext(ctx).Error("delete orphaned blob failed", "key", key, "error", err)
+	}
+}
+
+func attachmentKey(taskID int64) (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("tasks/%d/%s", taskID, hex.EncodeToString(buf)), nil
+}
+
+// sanitizeFilename keeps the base name and drops characters that would break
+// a Content-Disposition header
+func sanitizeFilename(name string) string {
+	name = filepath.Base(strings.ReplaceAll(name, "\\", "/"))
+	name = strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) || r == '"' {
+			return -1
+		}
+		return r
+	}, name)
+	if name == "" || name == "." || name == "/" {
+		return "attachment"
+	}
+	if len(name) > 255 {
+		name = name[:255]
+	}
+	return name
+}
+
+type countingWriter struct {
+	n int64
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	w.n += int64(len(p))
+	return len(p), nil
+}
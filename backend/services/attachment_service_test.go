@@ -0,0 +1,99 @@
+package services
+
+import (
+	"backend/models"
+	"backend/pkg/blob"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"strings"
+	"testing"
+
+	mock_repo "backend/repositories/mocks"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAttachmentService_Upload(t *testing.T) {
+	owner, stranger := int64(1), int64(2)
+	logContent := "2026-01-01 12:00:00 ERROR something broke\n"
+	logChecksum := sha256.Sum256([]byte(logContent))
+
+	tests := []struct {
+		name          string
+		userID        int64
+		filename      string
+		content       string
+		expectCreate  bool
+		expectedError error
+	}{
+		{
+			name:         "Text log is stored with checksum",
+			userID:       owner,
+			filename:     "../../server.log",
+			content:      logContent,
+			expectCreate: true,
+		},
+		{
+			name:          "Stranger cannot attach",
+			userID:        stranger,
+			filename:      "server.log",
+			content:       logContent,
+			expectedError: ErrTaskNotFound,
+		},
+		{
+			name:          "Disallowed type is rejected",
+			userID:        owner,
+			filename:      "page.html",
+			content:       "<!DOCTYPE html><html></html>",
+			expectedError: ErrAttachmentTypeInvalid,
+		},
+		{
+			name:          "Oversized content is rejected",
+			userID:        owner,
+			filename:      "big.txt",
+			content:       strings.Repeat("a", 200),
+			expectedError: ErrAttachmentTooLarge,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			taskRepo := mock_repo.NewMockTaskRepositoryInterface(ctrl)
+			attachmentRepo := mock_repo.NewMockAttachmentRepositoryInterface(ctrl)
+			taskRepo.EXPECT().Get(gomock.Any(), int64(5)).Return(&models.Task{ID: 5, AssignerID: &owner}, nil)
+			if tt.expectCreate {
+				attachmentRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+			}
+
+			store, err := blob.NewLocalStore(t.TempDir())
+			require.NoError(t, err)
+			service := NewAttachmentService(attachmentRepo, taskRepo, nil, store, 100, []string{"text/plain", "image/png"})
+
+			// Declare a small size so the limit is enforced on the bytes read
+			attachment, err := service.Upload(context.Background(), tt.userID, 5, tt.filename, 10, strings.NewReader(tt.content))
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "server.log", attachment.Filename)
+			assert.Equal(t, "text/plain", attachment.ContentType)
+			assert.Equal(t, int64(len(logContent)), attachment.SizeBytes)
+			assert.Equal(t, hex.EncodeToString(logChecksum[:]), attachment.ChecksumSHA256)
+
+			stored, err := store.Get(context.Background(), attachment.StorageKey)
+			require.NoError(t, err)
+			defer stored.Close()
+			body, _ := io.ReadAll(stored)
+			assert.Equal(t, logContent, string(body))
+		})
+	}
+}
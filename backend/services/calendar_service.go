@@ -0,0 +1,204 @@
+package services
+
+import (
+	"backend/models"
+	"backend/pkg/ical"
+	"backend/repositories"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// calendarUIDDomain makes iCalendar UIDs globally unique
+const calendarUIDDomain = "task-manager"
+
+var ErrCalendarTokenNotFound = newError(ErrNotFound, "calendar token not found")
+
+type CalendarServiceInterface interface {
+	CreateToken(ctx context.Context, userID int64) (string, *models.CalendarToken, error)
+	GetToken(ctx context.Context, userID int64) (*models.CalendarToken, error)
+	RevokeToken(ctx context.Context, userID int64) error
+	Authenticate(ctx context.Context, token string) (int64, error)
+	WriteFeed(ctx context.Context, userID int64, w io.Writer) error
+}
+
+// CalendarService serves tasks assigned to a user as an iCalendar feed. Feeds
+// are authenticated with a long-lived secret token in the URL, separate from
+// login tokens, because calendar apps cannot send an Authorization header.
+type CalendarService struct {
+	tokenRepo       repositories.CalendarTokenRepositoryInterface
+	taskRepo        repositories.TaskRepositoryInterface
+	taskURLTemplate string
+}
+
+// NewCalendarService links feed entries to taskURLTemplate with "{id}"
+// replaced by the task ID
+func NewCalendarService(tokenRepo repositories.CalendarTokenRepositoryInterface, taskRepo repositories.TaskRepositoryInterface, taskURLTemplate string) CalendarServiceInterface {
+	return &CalendarService{
+		tokenRepo:       tokenRepo,
+		taskRepo:        taskRepo,
+		taskURLTemplate: taskURLTemplate,
+	}
+}
+
+// CreateToken issues a new feed token for the user, revoking the previous
+// one. The token is only returned here.
+func (s *CalendarService) CreateToken(ctx context.Context, userID int64) (string, *models.CalendarToken, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", nil, err
+	}
+	token := hex.EncodeToString(buf)
+
+	calendarToken := &models.CalendarToken{UserID: userID, TokenHash: hashCalendarToken(token)}
+	if err := s.tokenRepo.Upsert(ctx, calendarToken); err != nil {
+		return "", nil, err
+	}
+	return token, calendarToken, nil
+}
+
+func (s *CalendarService) GetToken(ctx context.Context, userID int64) (*models.CalendarToken, error) {
+	token, err := s.tokenRepo.GetByUserID(ctx, userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrCalendarTokenNotFound
+	}
+	return token, err
+}
+
+func (s *CalendarService) RevokeToken(ctx context.Context, userID int64) error {
+	err := s.tokenRepo.Delete(ctx, userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrCalendarTokenNotFound
+	}
+	return err
+}
+
+// Authenticate returns the user a feed token belongs to
+func (s *CalendarService) Authenticate(ctx context.Context, token string) (int64, error) {
+	if token == "" {
+		return 0, ErrCalendarTokenNotFound
+	}
+	calendarToken, err := s.tokenRepo.GetByHash(ctx, hashCalendarToken(token))
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, ErrCalendarTokenNotFound
+	}
+	if err != nil {
+		return 0, err
+	}
+	return calendarToken.UserID, nil
+}
+
+// WriteFeed writes a VTODO for every task assigned to the user and a VEVENT
+// at the due date of those that have one, since some calendar apps ignore
+// VTODOs
+func (s *CalendarService) WriteFeed(ctx context.Context, userID int64, w io.Writer) error {
+	cal := ical.NewWriter(w)
+	cal.Begin("VCALENDAR")
+	cal.Property("VERSION", "2.0")
+	cal.Property("PRODID", "-//Task Manager//Tasks//EN")
+	cal.Property("CALSCALE", "GREGORIAN")
+	cal.Property("METHOD", "PUBLISH")
+	cal.Text("X-WR-CALNAME", "Tasks")
+
+	filter := models.TaskFilter{AssigneeID: &userID}
+	err := s.taskRepo.ForEachVisible(ctx, userID, filter, func(task *models.Task) error {
+		s.writeTask(cal, task)
+		return cal.Err()
+	})
+	if err != nil {
+		return err
+	}
+
+	cal.End("VCALENDAR")
+	return cal.Err()
+}
+
+func (s *CalendarService) writeTask(cal *ical.Writer, task *models.Task) {
+	url := s.taskURL(task.ID)
+	description := task.Description
+	if url != "" {
+		description = strings.TrimSpace(description + "\n\n" + url)
+	}
+
+	cal.Begin("VTODO")
+	cal.Property("UID", fmt.Sprintf("task-%d@%s", task.ID, calendarUIDDomain))
+	cal.DateTime("DTSTAMP", task.UpdatedAt)
+	cal.DateTime("CREATED", task.CreatedAt)
+	cal.DateTime("LAST-MODIFIED", task.UpdatedAt)
+	cal.Text("SUMMARY", task.Title)
+	if description != "" {
+		cal.Text("DESCRIPTION", description)
+	}
+	if url != "" {
+		cal.Property("URL", url)
+	}
+	cal.Property("STATUS", todoStatus(task.Status))
+	cal.Property("PRIORITY", strconv.Itoa(calendarPriority(task.Priority)))
+	if task.DueAt != nil {
+		cal.DateTime("DUE", *task.DueAt)
+	}
+	cal.End("VTODO")
+
+	if task.DueAt == nil {
+		return
+	}
+
+	cal.Begin("VEVENT")
+	cal.Property("UID", fmt.Sprintf("task-%d-due@%s", task.ID, calendarUIDDomain))
+	cal.DateTime("DTSTAMP", task.UpdatedAt)
+	cal.DateTime("DTSTART", *task.DueAt)
+	cal.Text("SUMMARY", "Due: "+task.Title)
+	if description != "" {
+		cal.Text("DESCRIPTION", description)
+	}
+	if url != "" {
+		cal.Property("URL", url)
+	}
+	cal.Property("STATUS", "CONFIRMED")
+	cal.Property("TRANSP", "TRANSPARENT")
+	cal.Property("PRIORITY", strconv.Itoa(calendarPriority(task.Priority)))
+	cal.End("VEVENT")
+}
+
+func (s *CalendarService) taskURL(taskID int64) string {
+	if s.taskURLTemplate == "" {
+		return ""
+	}
+	return strings.ReplaceAll(s.taskURLTemplate, "{id}", strconv.FormatInt(taskID, 10))
+}
+
+func todoStatus(status models.TaskStatus) string {
+	switch status {
+	case models.StatusInProgress:
+		return "IN-PROCESS"
+	case models.StatusDone:
+		return "COMPLETED"
+	}
+	return "NEEDS-ACTION"
+}
+
+// calendarPriority maps task priorities onto iCalendar's 1 (highest) to 9
+// (lowest) scale, 0 meaning undefined
+func calendarPriority(priority int) int {
+	switch models.Priority(priority) {
+	case models.PriorityHigh:
+		return 1
+	case models.PriorityMedium:
+		return 5
+	case models.PriorityLow:
+		return 9
+	}
+	return 0
+}
+
+func hashCalendarToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,98 @@
+package services
+
+import (
+	"backend/models"
+	"bytes"
+	"context"
+	"database/sql"
+	"strings"
+	"testing"
+	"time"
+
+	mock_repo "backend/repositories/mocks"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCalendarService_TokenLifecycle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tokenRepo := mock_repo.NewMockCalendarTokenRepositoryInterface(ctrl)
+	service := NewCalendarService(tokenRepo, nil, "")
+
+	var stored *models.CalendarToken
+	tokenRepo.EXPECT().Upsert(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, token *models.CalendarToken) error {
+		stored = token
+		return nil
+	})
+	token, _, err := service.CreateToken(context.Background(), 7)
+	require.NoError(t, err)
+	assert.Len(t, token, 64)
+	// Only the hash is stored
+	assert.NotEqual(t, token, stored.TokenHash)
+
+	tokenRepo.EXPECT().GetByHash(gomock.Any(), stored.TokenHash).Return(stored, nil)
+	userID, err := service.Authenticate(context.Background(), token)
+	require.NoError(t, err)
+	assert.Equal(t, int64(7), userID)
+
+	tokenRepo.EXPECT().Delete(gomock.Any(), int64(7)).Return(nil)
+	require.NoError(t, service.RevokeToken(context.Background(), 7))
+
+	tokenRepo.EXPECT().GetByHash(gomock.Any(), stored.TokenHash).Return(nil, sql.ErrNoRows)
+	_, err = service.Authenticate(context.Background(), token)
+	assert.ErrorIs(t, err, ErrCalendarTokenNotFound)
+}
+
+func TestCalendarService_WriteFeed(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userID := int64(7)
+	updated := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	due := time.Date(2026, 3, 6, 17, 0, 0, 0, time.UTC)
+	tasks := []models.Task{
+		{ID: 1, Title: "Write docs, then ship", Status: models.StatusInProgress, Priority: int(models.PriorityHigh), DueAt: &due, CreatedAt: updated, UpdatedAt: updated},
+		{ID: 2, Title: "Someday", Status: models.StatusDone, Priority: int(models.PriorityLow), CreatedAt: updated, UpdatedAt: updated},
+	}
+
+	taskRepo := mock_repo.NewMockTaskRepositoryInterface(ctrl)
+	taskRepo.EXPECT().ForEachVisible(gomock.Any(), userID, models.TaskFilter{AssigneeID: &userID}, gomock.Any()).DoAndReturn(
+		func(_ context.Context, userID int64, filter models.TaskFilter, fn func(task *models.Task) error) error {
+			for i := range tasks {
+				if err := fn(&tasks[i]); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+
+	var buf bytes.Buffer
+	service := NewCalendarService(nil, taskRepo, "https://tasks.example.com/task/{id}")
+	require.NoError(t, service.WriteFeed(context.Background(), userID, &buf))
+
+	feed := buf.String()
+	for _, line := range []string{
+		"BEGIN:VCALENDAR",
+		"UID:task-1@task-manager",
+		`SUMMARY:Write docs\, then ship`,
+		"URL:https://tasks.example.com/task/1",
+		"STATUS:IN-PROCESS",
+		"PRIORITY:1",
+		"DUE:20260306T170000Z",
+		"UID:task-1-due@task-manager",
+		"DTSTART:20260306T170000Z",
+		"UID:task-2@task-manager",
+		"STATUS:COMPLETED",
+		"PRIORITY:9",
+		"END:VCALENDAR",
+	} {
+		assert.Contains(t, feed, line+"\r\n")
+	}
+	assert.Equal(t, 2, strings.Count(feed, "BEGIN:VTODO"))
+	// Only tasks with a due date get an event
+	assert.Equal(t, 1, strings.Count(feed, "BEGIN:VEVENT"))
+}
@@ -0,0 +1,106 @@
+package services
+
+import (
+	"backend/models"
+	"backend/repositories"
+	"context"
+	"time"
+)
+
+// dashboardListLimit bounds each task list on the dashboard
+const dashboardListLimit = 10
+
+type DashboardServiceInterface interface {
+	Get(ctx context.Context, userID int64) (*Dashboard, error)
+}
+
+// TaskBreakdown counts tasks by status and priority
+type TaskBreakdown struct {
+	Total      int                       `json:"total"`
+	ByStatus   map[models.TaskStatus]int `json:"by_status"`
+	ByPriority map[int]int               `json:"by_priority"`
+}
+
+// DueTasks is the number of matching tasks and the first few of them
+type DueTasks struct {
+	Count int           `json:"count"`
+	Tasks []models.Task `json:"tasks"`
+}
+
+// Dashboard summarises the tasks a user assigned or is assigned to. Overdue
+// and due this week only include unfinished tasks; the week ends on Sunday
+// UTC.
+type Dashboard struct {
+	Assigned        TaskBreakdown `json:"assigned"`
+	Created         TaskBreakdown `json:"created"`
+	Overdue         DueTasks      `json:"overdue"`
+	DueThisWeek     DueTasks      `json:"due_this_week"`
+	RecentlyUpdated []models.Task `json:"recently_updated"`
+}
+
+type DashboardService struct {
+	taskRepo repositories.TaskRepositoryInterface
+	now      func() time.Time
+}
+
+func NewDashboardService(taskRepo repositories.TaskRepositoryInterface) DashboardServiceInterface {
+	return &DashboardService{taskRepo: taskRepo, now: time.Now}
+}
+
+func (s *DashboardService) Get(ctx context.Context, userID int64) (*Dashboard, error) {
+	now := s.now().UTC()
+	weekEnd := weekStart(now).AddDate(0, 0, 7)
+
+	counts, err := s.taskRepo.CountForUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	dashboard := &Dashboard{
+		Assigned: newTaskBreakdown(),
+		Created:  newTaskBreakdown(),
+	}
+	for _, count := range counts {
+		breakdown := &dashboard.Assigned
+		if count.Role == "created" {
+			breakdown = &dashboard.Created
+		}
+		breakdown.Total += count.Count
+		breakdown.ByStatus[count.Status] += count.Count
+		breakdown.ByPriority[count.Priority] += count.Count
+	}
+
+	dashboard.Overdue.Count, dashboard.DueThisWeek.Count, err = s.taskRepo.CountDueForUser(ctx, userID, now, weekEnd)
+	if err != nil {
+		return nil, err
+	}
+
+	if dashboard.Overdue.Tasks, err = s.taskRepo.ListDueForUser(ctx, userID, nil, now, dashboardListLimit); err != nil {
+		return nil, err
+	}
+	if dashboard.DueThisWeek.Tasks, err = s.taskRepo.ListDueForUser(ctx, userID, &now, weekEnd, dashboardListLimit); err != nil {
+		return nil, err
+	}
+	if dashboard.RecentlyUpdated, err = s.taskRepo.ListRecentlyUpdatedForUser(ctx, userID, dashboardListLimit); err != nil {
+		return nil, err
+	}
+
+	return dashboard, nil
+}
+
+// newTaskBreakdown lists every status and priority so clients get zeros
+// rather than missing keys
+func newTaskBreakdown() TaskBreakdown {
+	return TaskBreakdown{
+		ByStatus: map[models.TaskStatus]int{
+			models.StatusToDo:       0,
+			models.StatusInProgress: 0,
+			models.StatusDone:       0,
+		},
+		ByPriority: map[int]int{
+			int(models.PriorityLow):    0,
+			int(models.PriorityMedium): 0,
+			int(models.PriorityHigh):   0,
+		},
+	}
+}
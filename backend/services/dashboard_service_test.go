@@ -0,0 +1,55 @@
+package services
+
+import (
+	"backend/models"
+	"context"
+	"testing"
+	"time"
+
+	mock_repo "backend/repositories/mocks"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDashboardService_Get(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Wednesday, so the week ends at midnight between Sunday and Monday 9 March
+	now := time.Date(2026, 3, 4, 15, 0, 0, 0, time.UTC)
+	weekEnd := time.Date(2026, 3, 9, 0, 0, 0, 0, time.UTC)
+	userID := int64(1)
+
+	overdue := []models.Task{{ID: 7, Title: "Late"}}
+	dueSoon := []models.Task{{ID: 8, Title: "Soon"}}
+	recent := []models.Task{{ID: 8}, {ID: 7}}
+
+	taskRepo := mock_repo.NewMockTaskRepositoryInterface(ctrl)
+	taskRepo.EXPECT().CountForUser(gomock.Any(), userID).Return([]models.TaskCount{
+		{Role: "assigned", Status: models.StatusToDo, Priority: 1, Count: 2},
+		{Role: "assigned", Status: models.StatusToDo, Priority: 3, Count: 1},
+		{Role: "assigned", Status: models.StatusDone, Priority: 3, Count: 4},
+		{Role: "created", Status: models.StatusInProgress, Priority: 2, Count: 5},
+	}, nil)
+	taskRepo.EXPECT().CountDueForUser(gomock.Any(), userID, now, weekEnd).Return(1, 3, nil)
+	taskRepo.EXPECT().ListDueForUser(gomock.Any(), userID, nil, now, dashboardListLimit).Return(overdue, nil)
+	taskRepo.EXPECT().ListDueForUser(gomock.Any(), userID, &now, weekEnd, dashboardListLimit).Return(dueSoon, nil)
+	taskRepo.EXPECT().ListRecentlyUpdatedForUser(gomock.Any(), userID, dashboardListLimit).Return(recent, nil)
+
+	service := &DashboardService{taskRepo: taskRepo, now: func() time.Time { return now }}
+	dashboard, err := service.Get(context.Background(), userID)
+	require.NoError(t, err)
+
+	assert.Equal(t, TaskBreakdown{
+		Total:      7,
+		ByStatus:   map[models.TaskStatus]int{models.StatusToDo: 3, models.StatusInProgress: 0, models.StatusDone: 4},
+		ByPriority: map[int]int{1: 2, 2: 0, 3: 5},
+	}, dashboard.Assigned)
+	assert.Equal(t, 5, dashboard.Created.Total)
+	assert.Equal(t, 5, dashboard.Created.ByStatus[models.StatusInProgress])
+	assert.Equal(t, DueTasks{Count: 1, Tasks: overdue}, dashboard.Overdue)
+	assert.Equal(t, DueTasks{Count: 3, Tasks: dueSoon}, dashboard.DueThisWeek)
+	assert.Equal(t, recent, dashboard.RecentlyUpdated)
+}
@@ -0,0 +1,48 @@
+package services
+
+import "errors"
+
+// The kinds of failure callers can act on. Every error of this package that
+// is not an internal failure is one of these kinds, so errors.Is(err,
+// ErrNotFound) holds for ErrTaskNotFound and for errors wrapping it.
+var (
+	ErrNotFound   = errors.New("not found")
+	ErrConflict   = errors.New("conflict")
+	ErrValidation = errors.New("validation failed")
+	ErrForbidden  = errors.New("forbidden")
+	// ErrUnauthenticated is for callers that could not prove who they are
+	ErrUnauthenticated = errors.New("unauthenticated")
+)
+
+// errInternal is the message of errors that are not one of the kinds, whose
+// text can come from the database driver
+const errInternal = "internal error"
+
+// publicMessage returns the message of err to show to clients: its own for
+// errors of a kind and errInternal for any other, which callers log
+func publicMessage(err error) string {
+	for _, kind := range []error{ErrNotFound, ErrConflict, ErrValidation, ErrForbidden, ErrUnauthenticated} {
+		if errors.Is(err, kind) {
+			return err.Error()
+		}
+	}
+	return errInternal
+}
+
+// kindError is an error with its own message that is also its kind
+type kindError struct {
+	kind    error
+	message string
+}
+
+func newError(kind error, message string) error {
+	return &kindError{kind: kind, message: message}
+}
+
+func (e *kindError) Error() string {
+	return e.message
+}
+
+func (e *kindError) Unwrap() error {
+	return e.kind
+}
@@ -0,0 +1,38 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrorKinds(t *testing.T) {
+	kinds := []error{ErrNotFound, ErrConflict, ErrValidation, ErrForbidden}
+	tests := []struct {
+		err  error
+		kind error
+	}{
+		{ErrTaskNotFound, ErrNotFound},
+		{ErrUserNotFound, ErrNotFound},
+		{ErrWebhookNotFound, ErrNotFound},
+		{ErrUsernameTaken, ErrConflict},
+		{ErrTimerAlreadyRunning, ErrConflict},
+		{ErrInvalidTask, ErrValidation},
+		{ErrImportTooLarge, ErrValidation},
+		{ErrTaskForbidden, ErrForbidden},
+		{ErrInvalidCredentials, ErrUnauthenticated},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.err.Error(), func(t *testing.T) {
+			wrapped := fmt.Errorf("%w: details", tt.err)
+			for _, kind := range kinds {
+				assert.Equal(t, kind == tt.kind, errors.Is(tt.err, kind), kind.Error())
+				assert.Equal(t, kind == tt.kind, errors.Is(wrapped, kind), kind.Error())
+			}
+			assert.ErrorIs(t, wrapped, tt.err)
+		})
+	}
+}
@@ -0,0 +1,230 @@
+package services
+
+import (
+	"backend/models"
+	"backend/repositories"
+	"context"
+	"database/sql"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type ExportFormat string
+
+const (
+	ExportCSV    ExportFormat = "csv"
+	ExportJSON   ExportFormat = "json"
+	ExportNDJSON ExportFormat = "ndjson"
+)
+
+func (f ExportFormat) IsValid() bool {
+	switch f {
+	case ExportCSV, ExportJSON, ExportNDJSON:
+		return true
+	}
+	return false
+}
+
+var ErrInvalidExportFormat = newError(ErrValidation, "format must be csv, json or ndjson")
+
+var exportCSVHeader = []string{
+	"id", "title", "description", "status", "priority", "assignee", "assigner",
+	"due_at", "original_estimate_minutes", "remaining_estimate_minutes", "created_at", "updated_at",
+}
+
+type ExportServiceInterface interface {
+	Export(ctx context.Context, userID int64, filter models.TaskFilter, format ExportFormat, w io.Writer) error
+}
+
+// TaskExport is a task with usernames in place of user IDs
+type TaskExport struct {
+	ID                       int64             `json:"id"`
+	Title                    string            `json:"title"`
+	Description              string            `json:"description"`
+	Status                   models.TaskStatus `json:"status"`
+	Priority                 int               `json:"priority"`
+	Assignee                 string            `json:"assignee"`
+	Assigner                 string            `json:"assigner"`
+	DueAt                    *time.Time        `json:"due_at"`
+	OriginalEstimateMinutes  *int              `json:"original_estimate_minutes"`
+	RemainingEstimateMinutes *int              `json:"remaining_estimate_minutes"`
+	CreatedAt                time.Time         `json:"created_at"`
+	UpdatedAt                time.Time         `json:"updated_at"`
+}
+
+// ExportService writes the tasks a user can see as CSV, a JSON array or
+// newline-delimited JSON, one task at a time
+type ExportService struct {
+	taskRepo repositories.TaskRepositoryInterface
+	userRepo repositories.UserRepositoryInterface
+}
+
+func NewExportService(taskRepo repositories.TaskRepositoryInterface, userRepo repositories.UserRepositoryInterface) ExportServiceInterface {
+	return &ExportService{taskRepo: taskRepo, userRepo: userRepo}
+}
+
+func (s *ExportService) Export(ctx context.Context, userID int64, filter models.TaskFilter, format ExportFormat, w io.Writer) error {
+	if !format.IsValid() {
+		return ErrInvalidExportFormat
+	}
+
+	usernames := newUsernameCache(s.userRepo)
+	toExport := func(task *models.Task) (*TaskExport, error) {
+		assignee, err := usernames.lookup(ctx, task.AssigneeID)
+		if err != nil {
+			return nil, err
+		}
+		assigner, err := usernames.lookup(ctx, task.AssignerID)
+		if err != nil {
+			return nil, err
+		}
+		return &TaskExport{
+			ID:                       task.ID,
+			Title:                    task.Title,
+			Description:              task.Description,
+			Status:                   task.Status,
+			Priority:                 task.Priority,
+			Assignee:                 assignee,
+			Assigner:                 assigner,
+			DueAt:                    task.DueAt,
+			OriginalEstimateMinutes:  task.OriginalEstimateMinutes,
+			RemainingEstimateMinutes: task.RemainingEstimateMinutes,
+			CreatedAt:                task.CreatedAt,
+			UpdatedAt:                task.UpdatedAt,
+		}, nil
+	}
+
+	switch format {
+	case ExportCSV:
+		cw := csv.NewWriter(w)
+		if err := cw.Write(exportCSVHeader); err != nil {
+			return err
+		}
+		err := s.taskRepo.ForEachVisible(ctx, userID, filter, func(task *models.Task) error {
+			row, err := toExport(task)
+			if err != nil {
+				return err
+			}
+			return cw.Write(row.csvRecord())
+		})
+		if err != nil {
+			return err
+		}
+		cw.Flush()
+		return cw.Error()
+
+	case ExportJSON:
+		if _, err := io.WriteString(w, "["); err != nil {
+			return err
+		}
+		first := true
+		err := s.taskRepo.ForEachVisible(ctx, userID, filter, func(task *models.Task) error {
+			row, err := toExport(task)
+			if err != nil {
+				return err
+			}
+			if !first {
+				if _, err := io.WriteString(w, ","); err != nil {
+					return err
+				}
+			}
+			first = false
+			data, err := json.Marshal(row)
+			if err != nil {
+				return err
+			}
+			_, err = w.Write(data)
+			return err
+		})
+		if err != nil {
+			return err
+		}
+		_, err = io.WriteString(w, "]\n")
+		return err
+
+	default:
+		encoder := json.NewEncoder(w)
+		return s.taskRepo.ForEachVisible(ctx, userID, filter, func(task *models.Task) error {
+			row, err := toExport(task)
+			if err != nil {
+				return err
+			}
+			return encoder.Encode(row)
+		})
+	}
+}
+
+func (t *TaskExport) csvRecord() []string {
+	return []string{
+		strconv.FormatInt(t.ID, 10),
+		csvSafe(t.Title),
+		csvSafe(t.Description),
+		string(t.Status),
+		strconv.Itoa(t.Priority),
+		csvSafe(t.Assignee),
+		csvSafe(t.Assigner),
+		formatOptionalTime(t.DueAt),
+		formatOptionalInt(t.OriginalEstimateMinutes),
+		formatOptionalInt(t.RemainingEstimateMinutes),
+		t.CreatedAt.UTC().Format(time.RFC3339),
+		t.UpdatedAt.UTC().Format(time.RFC3339),
+	}
+}
+
+// csvSafe stops spreadsheets from evaluating user-provided text as a formula
+func csvSafe(value string) string {
+	if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
+
+func formatOptionalTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+func formatOptionalInt(n *int) string {
+	if n == nil {
+		return ""
+	}
+	return strconv.Itoa(*n)
+}
+
+// usernameCache resolves user IDs to usernames, looking each one up once
+type usernameCache struct {
+	userRepo  repositories.UserRepositoryInterface
+	usernames map[int64]string
+}
+
+func newUsernameCache(userRepo repositories.UserRepositoryInterface) *usernameCache {
+	return &usernameCache{userRepo: userRepo, usernames: make(map[int64]string)}
+}
+
+// lookup returns "" for a nil ID or a user that no longer exists
+func (c *usernameCache) lookup(ctx context.Context, id *int64) (string, error) {
+	if id == nil {
+		return "", nil
+	}
+	if username, ok := c.usernames[*id]; ok {
+		return username, nil
+	}
+
+	user, err := c.userRepo.FindById(ctx, *id)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return "", err
+	}
+	username := ""
+	if user != nil {
+		username = user.Username
+	}
+	c.usernames[*id] = username
+	return username, nil
+}
@@ -0,0 +1,101 @@
+package services
+
+import (
+	"backend/models"
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	mock_repo "backend/repositories/mocks"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportService_Export(t *testing.T) {
+	alice, bob, deleted := int64(1), int64(2), int64(3)
+	created := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	tasks := []models.Task{
+		{ID: 10, Title: "=SUM(A1)", Description: "Formula, with comma", Status: models.StatusToDo, Priority: 2, AssignerID: &alice, AssigneeID: &bob, CreatedAt: created, UpdatedAt: created},
+		{ID: 11, Title: "Ship it", Status: models.StatusDone, Priority: 3, AssignerID: &alice, AssigneeID: &deleted, CreatedAt: created, UpdatedAt: created},
+	}
+
+	tests := []struct {
+		name     string
+		format   ExportFormat
+		expected string
+	}{
+		{
+			name:   "CSV",
+			format: ExportCSV,
+			expected: "id,title,description,status,priority,assignee,assigner,due_at,original_estimate_minutes,remaining_estimate_minutes,created_at,updated_at\n" +
+				"10,'=SUM(A1),\"Formula, with comma\",TO_DO,2,bob,alice,,,,2026-03-02T09:00:00Z,2026-03-02T09:00:00Z\n" +
+				"11,Ship it,,DONE,3,,alice,,,,2026-03-02T09:00:00Z,2026-03-02T09:00:00Z\n",
+		},
+		{
+			name:   "NDJSON",
+			format: ExportNDJSON,
+		},
+		{
+			name:   "JSON",
+			format: ExportJSON,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			filter := models.TaskFilter{}
+			taskRepo := mock_repo.NewMockTaskRepositoryInterface(ctrl)
+			userRepo := mock_repo.NewMockUserRepositoryInterface(ctrl)
+			taskRepo.EXPECT().ForEachVisible(gomock.Any(), alice, filter, gomock.Any()).DoAndReturn(
+				func(_ context.Context, userID int64, filter models.TaskFilter, fn func(task *models.Task) error) error {
+					for i := range tasks {
+						if err := fn(&tasks[i]); err != nil {
+							return err
+						}
+					}
+					return nil
+				})
+			// Each user is looked up once
+			userRepo.EXPECT().FindById(gomock.Any(), alice).Return(&models.User{ID: alice, Username: "alice"}, nil)
+			userRepo.EXPECT().FindById(gomock.Any(), bob).Return(&models.User{ID: bob, Username: "bob"}, nil)
+			userRepo.EXPECT().FindById(gomock.Any(), deleted).Return(nil, sql.ErrNoRows)
+
+			var buf bytes.Buffer
+			service := NewExportService(taskRepo, userRepo)
+			require.NoError(t, service.Export(context.Background(), alice, filter, tt.format, &buf))
+
+			switch tt.format {
+			case ExportCSV:
+				assert.Equal(t, tt.expected, buf.String())
+			case ExportNDJSON:
+				lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+				require.Len(t, lines, 2)
+				var first TaskExport
+				require.NoError(t, json.Unmarshal([]byte(lines[0]), &first))
+				assert.Equal(t, "=SUM(A1)", first.Title)
+				assert.Equal(t, "bob", first.Assignee)
+			case ExportJSON:
+				var exported []TaskExport
+				require.NoError(t, json.Unmarshal(buf.Bytes(), &exported))
+				require.Len(t, exported, 2)
+				assert.Equal(t, "alice", exported[1].Assigner)
+				assert.Equal(t, "", exported[1].Assignee)
+			}
+		})
+	}
+}
+
+func TestExportService_InvalidFormat(t *testing.T) {
+	service := NewExportService(nil, nil)
+	err := service.Export(context.Background(), 1, models.TaskFilter{}, "xml", &bytes.Buffer{})
+	assert.ErrorIs(t, err, ErrInvalidExportFormat)
+}
@@ -0,0 +1,352 @@
+package services
+
+import (
+	"backend/models"
+	"backend/repositories"
+	"context"
+	"database/sql"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxImportRows bounds the size of a single import
+const maxImportRows = 1000
+
+type ImportFormat string
+
+const (
+	ImportCSV  ImportFormat = "csv"
+	ImportJSON ImportFormat = "json"
+)
+
+type ImportMode string
+
+const (
+	// ImportTransactional creates every row or, if any row is invalid, none
+	ImportTransactional ImportMode = "transactional"
+	// ImportBestEffort creates the valid rows and reports the others
+	ImportBestEffort ImportMode = "best_effort"
+)
+
+// Task fields rows can be mapped to
+const (
+	importFieldTitle             = "title"
+	importFieldDescription       = "description"
+	importFieldStatus            = "status"
+	importFieldPriority          = "priority"
+	importFieldAssignee          = "assignee"
+	importFieldDueAt             = "due_at"
+	importFieldOriginalEstimate  = "original_estimate_minutes"
+	importFieldRemainingEstimate = "remaining_estimate_minutes"
+)
+
+var importFields = []string{
+	importFieldTitle,
+	importFieldDescription,
+	importFieldStatus,
+	importFieldPriority,
+	importFieldAssignee,
+	importFieldDueAt,
+	importFieldOriginalEstimate,
+	importFieldRemainingEstimate,
+}
+
+var (
+	ErrInvalidImportFormat = newError(ErrValidation, "format must be csv or json")
+	ErrInvalidImportMode   = newError(ErrValidation, "mode must be transactional or best_effort")
+	ErrInvalidImportFile   = newError(ErrValidation, "import file could not be read")
+	ErrImportTooLarge      = newError(ErrValidation, fmt.Sprintf("imports are limited to %d rows", maxImportRows))
+	ErrImportMapping       = newError(ErrValidation, "invalid column mapping")
+)
+
+type ImportServiceInterface interface {
+	Import(ctx context.Context, userID int64, r io.Reader, options ImportOptions) (*ImportResult, error)
+}
+
+// ImportOptions configure an import. Mapping maps task fields to source
+// columns (CSV headers or JSON keys); unmapped fields are read from a column
+// with the field's name.
+type ImportOptions struct {
+	Format  ImportFormat
+	Mode    ImportMode
+	DryRun  bool
+	Mapping map[string]string
+}
+
+type ImportRowResult struct {
+	// Row is 1-based and does not count the CSV header
+	Row    int      `json:"row"`
+	TaskID *int64   `json:"task_id,omitempty"`
+	Errors []string `json:"errors,omitempty"`
+}
+
+type ImportResult struct {
+	Mode    ImportMode        `json:"mode"`
+	DryRun  bool              `json:"dry_run"`
+	Total   int               `json:"total"`
+	Valid   int               `json:"valid"`
+	Created int               `json:"created"`
+	Rows    []ImportRowResult `json:"rows"`
+}
+
+// ImportService creates tasks from CSV or JSON files. Imported tasks are
+// assigned by the importing user.
+type ImportService struct {
+	taskService TaskServiceInterface
+	userRepo    repositories.UserRepositoryInterface
+}
+
+func NewImportService(taskService TaskServiceInterface, userRepo repositories.UserRepositoryInterface) ImportServiceInterface {
+	return &ImportService{taskService: taskService, userRepo: userRepo}
+}
+
+func (s *ImportService) Import(ctx context.Context, userID int64, r io.Reader, options ImportOptions) (*ImportResult, error) {
+	if options.Mode == "" {
+		options.Mode = ImportTransactional
+	}
+	if options.Mode != ImportTransactional && options.Mode != ImportBestEffort {
+		return nil, ErrInvalidImportMode
+	}
+	for field := range options.Mapping {
+		if !isImportField(field) {
+			return nil, fmt.Errorf("%w: unknown field %q", ErrImportMapping, field)
+		}
+	}
+
+	var records []map[string]string
+	var err error
+	switch options.Format {
+	case ImportCSV:
+		records, err = readCSVRecords(r)
+	case ImportJSON:
+		records, err = readJSONRecords(r)
+	default:
+		return nil, ErrInvalidImportFormat
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ImportResult{Mode: options.Mode, DryRun: options.DryRun, Total: len(records)}
+	usernames := make(map[string]*int64)
+	var tasks []*models.Task
+	var rows []int
+
+	for i, record := range records {
+		row := ImportRowResult{Row: i + 1}
+		task, errs := s.parseRow(ctx, userID, record, options.Mapping, usernames)
+		if len(errs) > 0 {
+			row.Errors = errs
+		} else {
+			result.Valid++
+			tasks = append(tasks, task)
+			rows = append(rows, i)
+		}
+		result.Rows = append(result.Rows, row)
+	}
+
+	if options.DryRun || len(tasks) == 0 {
+		return result, nil
+	}
+	if options.Mode == ImportTransactional {
+		if result.Valid < result.Total {
+			return result, nil
+		}
+		created, err := s.taskService.CreateMany(ctx, tasks)
+		if err != nil {
+			return nil, err
+		}
+		for i, task := range created {
+			result.Rows[rows[i]].TaskID = &task.ID
+		}
+		result.Created = len(created)
+		return result, nil
+	}
+
+	for i, task := range tasks {
+		created, err := s.taskService.Create(ctx, task)
+		if err != nil {
+			result.Rows[rows[i]].Errors = append(result.Rows[rows[i]].Errors, err.Error())
+			continue
+		}
+		result.Rows[rows[i]].TaskID = &created.ID
+		result.Created++
+	}
+	return result, nil
+}
+
+// parseRow builds a task from a record, collecting every problem with it
+func (s *ImportService) parseRow(ctx context.Context, userID int64, record map[string]string, mapping map[string]string, usernames map[string]*int64) (*models.Task, []string) {
+	value := func(field string) string {
+		column := field
+		if mapped, ok := mapping[field]; ok {
+			column = mapped
+		}
+		return strings.TrimSpace(record[column])
+	}
+
+	var errs []string
+	task := &models.Task{
+		Title:       value(importFieldTitle),
+		Description: value(importFieldDescription),
+		Status:      models.StatusToDo,
+		Priority:    int(models.PriorityMedium),
+		AssignerID:  &userID,
+	}
+
+	if task.Title == "" {
+		errs = append(errs, "title is required")
+	}
+
+	if raw := value(importFieldStatus); raw != "" {
+		task.Status = models.TaskStatus(strings.ToUpper(raw))
+		if !task.Status.IsValid() {
+			errs = append(errs, fmt.Sprintf("invalid status %q. Must be TO_DO, IN_PROGRESS, or DONE", raw))
+		}
+	}
+
+	if raw := value(importFieldPriority); raw != "" {
+		priority, err := strconv.Atoi(raw)
+		if err != nil || priority < int(models.PriorityLow) || priority > int(models.PriorityHigh) {
+			errs = append(errs, fmt.Sprintf("invalid priority %q. Must be 1, 2 or 3", raw))
+		}
+		task.Priority = priority
+	}
+
+	if raw := value(importFieldAssignee); raw != "" {
+		assigneeID, err := s.lookupUsername(ctx, raw, usernames)
+		if err != nil {
+			errs = append(errs, err.Error())
+		} else if assigneeID == nil {
+			errs = append(errs, fmt.Sprintf("unknown assignee %q", raw))
+		}
+		task.AssigneeID = assigneeID
+	}
+
+	if raw := value(importFieldDueAt); raw != "" {
+		dueAt, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			dueAt, err = time.Parse(time.DateOnly, raw)
+		}
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("invalid due_at %q. Use RFC 3339 or YYYY-MM-DD", raw))
+		}
+		task.DueAt = &dueAt
+	}
+
+	estimates := []struct {
+		field  string
+		target **int
+	}{
+		{importFieldOriginalEstimate, &task.OriginalEstimateMinutes},
+		{importFieldRemainingEstimate, &task.RemainingEstimateMinutes},
+	}
+	for _, estimate := range estimates {
+		if raw := value(estimate.field); raw != "" {
+			minutes, err := strconv.Atoi(raw)
+			if err != nil || minutes < 0 {
+				errs = append(errs, fmt.Sprintf("invalid %s %q", estimate.field, raw))
+			}
+			*estimate.target = &minutes
+		}
+	}
+
+	return task, errs
+}
+
+// lookupUsername returns nil for a username that does not exist
+func (s *ImportService) lookupUsername(ctx context.Context, username string, cache map[string]*int64) (*int64, error) {
+	if id, ok := cache[username]; ok {
+		return id, nil
+	}
+
+	user, err := s.userRepo.FindByUsername(ctx, username)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+	var id *int64
+	if user != nil {
+		id = &user.ID
+	}
+	cache[username] = id
+	return id, nil
+}
+
+func readCSVRecords(r io.Reader) ([]map[string]string, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidImportFile, err)
+	}
+	// Spreadsheet exports often start with a byte order mark
+	if len(header) > 0 {
+		header[0] = strings.TrimPrefix(header[0], "\ufeff")
+	}
+
+	var records []map[string]string
+	for {
+		fields, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidImportFile, err)
+		}
+		if len(records) == maxImportRows {
+			return nil, ErrImportTooLarge
+		}
+
+		record := make(map[string]string, len(header))
+		for i, column := range header {
+			if i < len(fields) {
+				record[strings.TrimSpace(column)] = fields[i]
+			}
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+// readJSONRecords reads an array of objects. Non-string values are kept in
+// their JSON form, so numbers can be used for priority and estimates.
+func readJSONRecords(r io.Reader) ([]map[string]string, error) {
+	var raw []map[string]json.RawMessage
+	if err := json.NewDecoder(r).Decode(&raw); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidImportFile, err)
+	}
+	if len(raw) > maxImportRows {
+		return nil, ErrImportTooLarge
+	}
+
+	records := make([]map[string]string, 0, len(raw))
+	for _, object := range raw {
+		record := make(map[string]string, len(object))
+		for key, value := range object {
+			var s string
+			if err := json.Unmarshal(value, &s); err == nil {
+				record[key] = s
+			} else if string(value) != "null" {
+				record[key] = string(value)
+			}
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+func isImportField(field string) bool {
+	for _, f := range importFields {
+		if f == field {
+			return true
+		}
+	}
+	return false
+}
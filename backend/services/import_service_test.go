@@ -0,0 +1,117 @@
+package services
+
+import (
+	"backend/models"
+	"context"
+	"database/sql"
+	"strings"
+	"testing"
+
+	mock_repo "backend/repositories/mocks"
+	mock_service "backend/services/mocks"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const importCSV = `Summary,Owner,State,priority
+Write docs,bob,in_progress,3
+Fix login,nobody,DONE,2
+,bob,WAITING,7
+`
+
+func TestImportService_Import(t *testing.T) {
+	importer, bob := int64(1), int64(2)
+	mapping := map[string]string{"title": "Summary", "assignee": "Owner", "status": "State"}
+	rowErrors := [][]string{
+		nil,
+		{`unknown assignee "nobody"`},
+		{"title is required", `invalid status "WAITING". Must be TO_DO, IN_PROGRESS, or DONE`, `invalid priority "7". Must be 1, 2 or 3`},
+	}
+
+	tests := []struct {
+		name            string
+		options         ImportOptions
+		setupMocks      func(taskService *mock_service.MockTaskServiceInterface)
+		expectedCreated int
+	}{
+		{
+			name:    "Dry run only validates",
+			options: ImportOptions{Format: ImportCSV, Mode: ImportBestEffort, DryRun: true, Mapping: mapping},
+		},
+		{
+			name:    "Transactional import with invalid rows creates nothing",
+			options: ImportOptions{Format: ImportCSV, Mode: ImportTransactional, Mapping: mapping},
+		},
+		{
+			name:    "Best effort import creates the valid rows",
+			options: ImportOptions{Format: ImportCSV, Mode: ImportBestEffort, Mapping: mapping},
+			setupMocks: func(taskService *mock_service.MockTaskServiceInterface) {
+				taskService.EXPECT().Create(gomock.Any(), &models.Task{
+					Title:      "Write docs",
+					Status:     models.StatusInProgress,
+					Priority:   3,
+					AssigneeID: &bob,
+					AssignerID: &importer,
+				}).Return(&models.Task{ID: 40}, nil)
+			},
+			expectedCreated: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			taskService := mock_service.NewMockTaskServiceInterface(ctrl)
+			userRepo := mock_repo.NewMockUserRepositoryInterface(ctrl)
+			userRepo.EXPECT().FindByUsername(gomock.Any(), "bob").Return(&models.User{ID: bob, Username: "bob"}, nil)
+			userRepo.EXPECT().FindByUsername(gomock.Any(), "nobody").Return(nil, sql.ErrNoRows)
+			if tt.setupMocks != nil {
+				tt.setupMocks(taskService)
+			}
+
+			service := NewImportService(taskService, userRepo)
+			result, err := service.Import(context.Background(), importer, strings.NewReader(importCSV), tt.options)
+			require.NoError(t, err)
+
+			assert.Equal(t, 3, result.Total)
+			assert.Equal(t, 1, result.Valid)
+			assert.Equal(t, tt.expectedCreated, result.Created)
+			for i, row := range result.Rows {
+				assert.Equal(t, i+1, row.Row)
+				assert.Equal(t, rowErrors[i], row.Errors)
+			}
+		})
+	}
+}
+
+func TestImportService_ImportJSONTransactional(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	importer := int64(1)
+	taskService := mock_service.NewMockTaskServiceInterface(ctrl)
+	taskService.EXPECT().CreateMany(gomock.Any(), gomock.Len(2)).DoAndReturn(func(_ context.Context, tasks []*models.Task) ([]*models.Task, error) {
+		assert.Equal(t, "First", tasks[0].Title)
+		assert.Equal(t, 1, tasks[0].Priority)
+		assert.Equal(t, 90, *tasks[1].OriginalEstimateMinutes)
+		return []*models.Task{{ID: 10}, {ID: 11}}, nil
+	})
+
+	body := `[{"title": "First", "priority": 1}, {"title": "Second", "original_estimate_minutes": 90, "due_at": null}]`
+	service := NewImportService(taskService, nil)
+	result, err := service.Import(context.Background(), importer, strings.NewReader(body), ImportOptions{Format: ImportJSON})
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, result.Created)
+	assert.Equal(t, int64(11), *result.Rows[1].TaskID)
+}
+
+func TestImportService_InvalidMapping(t *testing.T) {
+	service := NewImportService(nil, nil)
+	_, err := service.Import(context.Background(), 1, strings.NewReader(""), ImportOptions{Format: ImportCSV, Mapping: map[string]string{"owner": "Owner"}})
+	assert.ErrorIs(t, err, ErrImportMapping)
+}
@@ -0,0 +1,111 @@
+package services
+
+import (
+	"backend/models"
+	"backend/pkg/logging"
+	"backend/pkg/mention"
+	"backend/repositories"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+type MentionServiceInterface interface {
+	ListForTask(ctx context.Context, userID, taskID int64) ([]models.Mention, error)
+}
+
+// mentionNotifier is the part of NotificationService used for mentions
+type mentionNotifier interface {
+	Notify(ctx context.Context, userID int64, notificationType models.NotificationType, taskID, actorID *int64, message string) error
+}
+
+// MentionService resolves @username mentions in task text, records them and
+// notifies the mentioned users. Users who cannot see the task are recorded
+// as flagged and not notified, so a mention never reveals that a task exists.
+type MentionService struct {
+	mentionRepo repositories.MentionRepositoryInterface
+	userRepo    repositories.UserRepositoryInterface
+	taskRepo    repositories.TaskRepositoryInterface
+	notifier    mentionNotifier
+}
+
+func NewMentionService(
+	mentionRepo repositories.MentionRepositoryInterface,
+	userRepo repositories.UserRepositoryInterface,
+	taskRepo repositories.TaskRepositoryInterface,
+	notifier mentionNotifier,
+) *MentionService {
+	return &MentionService{
+		mentionRepo: mentionRepo,
+		userRepo:    userRepo,
+		taskRepo:    taskRepo,
+		notifier:    notifier,
+	}
+}
+
+// ListForTask returns the mentions of a task visible to userID
+func (s *MentionService) ListForTask(ctx context.Context, userID, taskID int64) ([]models.Mention, error) {
+	task, err := s.taskRepo.Get(ctx, taskID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrTaskNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !taskVisibleTo(task, userID) {
+		return nil, ErrTaskNotFound
+	}
+
+	return s.mentionRepo.ListByTaskID(ctx, taskID)
+}
+
+func (s *MentionService) OnTaskEvent(ctx context.Context, event TaskEvent) {
+	if event.Type == TaskDeleted {
+		return
+	}
+	if event.Previous != nil && event.Previous.Description == event.Task.Description {
+		return
+	}
+
+	if err := s.Process(ctx, event.Task, models.MentionSourceTaskDescription, event.Task.Description, event.ActorID); err != nil {
+		logging.FromContext(ctx).Error("process mentions failed", "task_id", event.Task.ID, "error", err)
+	}
+}
+
+// Process records the mentions found in text, a piece of content belonging
+// to task, and notifies users mentioned for the first time by that source.
+func (s *MentionService) Process(ctx context.Context, task *models.Task, source models.MentionSource, text string, actorID *int64) error {
+	for _, username := range mention.Parse(text) {
+		user, err := s.userRepo.FindByUsername(ctx, username)
+		if errors.Is(err, sql.ErrNoRows) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		record := &models.Mention{
+			TaskID:          task.ID,
+			Source:          source,
+			MentionedUserID: user.ID,
+			ActorID:         actorID,
+			Flagged:         !taskVisibleTo(task, user.ID),
+		}
+		created, err := s.mentionRepo.Create(ctx, record)
+		if err != nil {
+			return err
+		}
+		if !created || record.Flagged {
+			continue
+		}
+
+		taskID := task.ID
+		message := fmt.Sprintf("You were mentioned in task #%d %q", task.ID, task.Title)
+		if err := s.notifier.Notify(ctx, user.ID, models.NotificationMention, &taskID, actorID, message); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
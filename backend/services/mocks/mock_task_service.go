@@ -0,0 +1,140 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: services/task_service.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	models "backend/models"
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockTaskServiceInterface is a mock of TaskServiceInterface interface.
+type MockTaskServiceInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockTaskServiceInterfaceMockRecorder
+}
+
+// MockTaskServiceInterfaceMockRecorder is the mock recorder for MockTaskServiceInterface.
+type MockTaskServiceInterfaceMockRecorder struct {
+	mock *MockTaskServiceInterface
+}
+
+// NewMockTaskServiceInterface creates a new mock instance.
+func NewMockTaskServiceInterface(ctrl *gomock.Controller) *MockTaskServiceInterface {
+	mock := &MockTaskServiceInterface{ctrl: ctrl}
+	mock.recorder = &MockTaskServiceInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTaskServiceInterface) EXPECT() *MockTaskServiceInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Bulk mocks base method.
+func (m *MockTaskServiceInterface) Bulk(ctx context.Context, userID int64, op models.BulkOperation) (*models.BulkResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Bulk", ctx, userID, op)
+	ret0, _ := ret[0].(*models.BulkResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Bulk indicates an expected call of Bulk.
+func (mr *MockTaskServiceInterfaceMockRecorder) Bulk(ctx, userID, op interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Bulk", reflect.TypeOf((*MockTaskServiceInterface)(nil).Bulk), ctx, userID, op)
+}
+
+// Create mocks base method.
+func (m *MockTaskServiceInterface) Create(ctx context.Context, task *models.Task) (*models.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, task)
+	ret0, _ := ret[0].(*models.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockTaskServiceInterfaceMockRecorder) Create(ctx, task interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockTaskServiceInterface)(nil).Create), ctx, task)
+}
+
+// CreateMany mocks base method.
+func (m *MockTaskServiceInterface) CreateMany(ctx context.Context, tasks []*models.Task) ([]*models.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateMany", ctx, tasks)
+	ret0, _ := ret[0].([]*models.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateMany indicates an expected call of CreateMany.
+func (mr *MockTaskServiceInterfaceMockRecorder) CreateMany(ctx, tasks interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMany", reflect.TypeOf((*MockTaskServiceInterface)(nil).CreateMany), ctx, tasks)
+}
+
+// Delete mocks base method.
+func (m *MockTaskServiceInterface) Delete(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockTaskServiceInterfaceMockRecorder) Delete(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockTaskServiceInterface)(nil).Delete), ctx, id)
+}
+
+// Get mocks base method.
+func (m *MockTaskServiceInterface) Get(ctx context.Context, id int64) (*models.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, id)
+	ret0, _ := ret[0].(*models.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockTaskServiceInterfaceMockRecorder) Get(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockTaskServiceInterface)(nil).Get), ctx, id)
+}
+
+// GetTasksByAssignerID mocks base method.
+func (m *MockTaskServiceInterface) GetTasksByAssignerID(ctx context.Context, assignerID int64) ([]models.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTasksByAssignerID", ctx, assignerID)
+	ret0, _ := ret[0].([]models.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTasksByAssignerID indicates an expected call of GetTasksByAssignerID.
+func (mr *MockTaskServiceInterfaceMockRecorder) GetTasksByAssignerID(ctx, assignerID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTasksByAssignerID", reflect.TypeOf((*MockTaskServiceInterface)(nil).GetTasksByAssignerID), ctx, assignerID)
+}
+
+// Update mocks base method.
+func (m *MockTaskServiceInterface) Update(ctx context.Context, task *models.Task) (*models.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, task)
+	ret0, _ := ret[0].(*models.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockTaskServiceInterfaceMockRecorder) Update(ctx, task interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockTaskServiceInterface)(nil).Update), ctx, task)
+}
@@ -6,6 +6,7 @@ package mocks
 
 import (
 	models "backend/models"
+	context "context"
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
@@ -35,60 +36,60 @@ func (m *MockUserServiceInterface) EXPECT() *MockUserServiceInterfaceMockRecorde
 }
 
 // GetUserByEmail mocks base method.
-func (m *MockUserServiceInterface) GetUserByEmail(email string) (*models.UserResponse, error) {
+func (m *MockUserServiceInterface) GetUserByEmail(ctx context.Context, email string) (*models.UserResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetUserByEmail", email)
+	ret := m.ctrl.Call(m, "GetUserByEmail", ctx, email)
 	ret0, _ := ret[0].(*models.UserResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetUserByEmail indicates an expected call of GetUserByEmail.
-func (mr *MockUserServiceInterfaceMockRecorder) GetUserByEmail(email interface{}) *gomock.Call {
+func (mr *MockUserServiceInterfaceMockRecorder) GetUserByEmail(ctx, email interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByEmail", reflect.TypeOf((*MockUserServiceInterface)(nil).GetUserByEmail), email)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByEmail", reflect.TypeOf((*MockUserServiceInterface)(nil).GetUserByEmail), ctx, email)
 }
 
 // GetUserById mocks base method.
-func (m *MockUserServiceInterface) GetUserById(userId int64) (*models.UserResponse, error) {
+func (m *MockUserServiceInterface) GetUserById(ctx context.Context, userId int64) (*models.UserResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetUserById", userId)
+	ret := m.ctrl.Call(m, "GetUserById", ctx, userId)
 	ret0, _ := ret[0].(*models.UserResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetUserById indicates an expected call of GetUserById.
-func (mr *MockUserServiceInterfaceMockRecorder) GetUserById(userId interface{}) *gomock.Call {
+func (mr *MockUserServiceInterfaceMockRecorder) GetUserById(ctx, userId interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserById", reflect.TypeOf((*MockUserServiceInterface)(nil).GetUserById), userId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserById", reflect.TypeOf((*MockUserServiceInterface)(nil).GetUserById), ctx, userId)
 }
 
 // Login mocks base method.
-func (m *MockUserServiceInterface) Login(email, password string) (string, error) {
+func (m *MockUserServiceInterface) Login(ctx context.Context, email, password string) (string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Login", email, password)
+	ret := m.ctrl.Call(m, "Login", ctx, email, password)
 	ret0, _ := ret[0].(string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Login indicates an expected call of Login.
-func (mr *MockUserServiceInterfaceMockRecorder) Login(email, password interface{}) *gomock.Call {
+func (mr *MockUserServiceInterfaceMockRecorder) Login(ctx, email, password interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Login", reflect.TypeOf((*MockUserServiceInterface)(nil).Login), email, password)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Login", reflect.TypeOf((*MockUserServiceInterface)(nil).Login), ctx, email, password)
 }
 
 // Register mocks base method.
-func (m *MockUserServiceInterface) Register(user *models.User) error {
+func (m *MockUserServiceInterface) Register(ctx context.Context, user *models.User) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Register", user)
+	ret := m.ctrl.Call(m, "Register", ctx, user)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Register indicates an expected call of Register.
-func (mr *MockUserServiceInterfaceMockRecorder) Register(user interface{}) *gomock.Call {
+func (mr *MockUserServiceInterfaceMockRecorder) Register(ctx, user interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Register", reflect.TypeOf((*MockUserServiceInterface)(nil).Register), user)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Register", reflect.TypeOf((*MockUserServiceInterface)(nil).Register), ctx, user)
 }
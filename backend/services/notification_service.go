@@ -0,0 +1,137 @@
+package services
+
+import (
+	"backend/models"
+	"backend/pkg/logging"
+	"backend/repositories"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrNotificationNotFound    = newError(ErrNotFound, "notification not found")
+	ErrInvalidNotificationType = newError(ErrValidation, "unknown notification type")
+)
+
+type NotificationServiceInterface interface {
+	List(ctx context.Context, userID int64, unreadOnly bool, limit int) ([]models.Notification, error)
+	MarkRead(ctx context.Context, userID, id int64) error
+	MarkAllRead(ctx context.Context, userID int64) (int64, error)
+	GetPreferences(ctx context.Context, userID int64) (map[models.NotificationType]bool, error)
+	UpdatePreferences(ctx context.Context, userID int64, preferences map[models.NotificationType]bool) (map[models.NotificationType]bool, error)
+}
+
+// NotificationService maintains users' in-app inboxes. It listens to task
+// events for assignment and status changes and exposes Notify for other
+// services, such as mentions.
+type NotificationService struct {
+	notificationRepo repositories.NotificationRepositoryInterface
+}
+
+func NewNotificationService(notificationRepo repositories.NotificationRepositoryInterface) *NotificationService {
+	return &NotificationService{notificationRepo: notificationRepo}
+}
+
+func (s *NotificationService) List(ctx context.Context, userID int64, unreadOnly bool, limit int) ([]models.Notification, error) {
+	if limit <= 0 || limit > 100 {
+		limit = 50
+	}
+	return s.notificationRepo.ListByUserID(ctx, userID, unreadOnly, limit)
+}
+
+func (s *NotificationService) MarkRead(ctx context.Context, userID, id int64) error {
+	err := s.notificationRepo.MarkRead(ctx, id, userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrNotificationNotFound
+	}
+	return err
+}
+
+func (s *NotificationService) MarkAllRead(ctx context.Context, userID int64) (int64, error) {
+	return s.notificationRepo.MarkAllRead(ctx, userID)
+}
+
+// GetPreferences returns the effective setting for every notification type
+func (s *NotificationService) GetPreferences(ctx context.Context, userID int64) (map[models.NotificationType]bool, error) {
+	stored, err := s.notificationRepo.GetPreferences(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	preferences := make(map[models.NotificationType]bool, len(models.NotificationTypes))
+	for _, notificationType := range models.NotificationTypes {
+		enabled, ok := stored[notificationType]
+		preferences[notificationType] = !ok || enabled
+	}
+	return preferences, nil
+}
+
+func (s *NotificationService) UpdatePreferences(ctx context.Context, userID int64, preferences map[models.NotificationType]bool) (map[models.NotificationType]bool, error) {
+	for notificationType := range preferences {
+		if !notificationType.IsValid() {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidNotificationType, notificationType)
+		}
+	}
+
+	for notificationType, enabled := range preferences {
+		if err := s.notificationRepo.SetPreference(ctx, userID, notificationType, enabled); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.GetPreferences(ctx, userID)
+}
+
+// Notify adds a notification to userID's inbox unless they opted out of its
+// type or caused it themselves.
+func (s *NotificationService) Notify(ctx context.Context, userID int64, notificationType models.NotificationType, taskID, actorID *int64, message string) error {
+	if actorID != nil && *actorID == userID {
+		return nil
+	}
+
+	preferences, err := s.GetPreferences(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if !preferences[notificationType] {
+		return nil
+	}
+
+	return s.notificationRepo.Create(ctx, &models.Notification{
+		UserID:  userID,
+		Type:    notificationType,
+		TaskID:  taskID,
+		ActorID: actorID,
+		Message: message,
+	})
+}
+
+func (s *NotificationService) OnTaskEvent(ctx context.Context, event TaskEvent) {
+	task, prev := event.Task, event.Previous
+	if event.Type == TaskDeleted {
+		return
+	}
+
+	assigneeChanged := task.AssigneeID != nil &&
+		(prev == nil || prev.AssigneeID == nil || *prev.AssigneeID != *task.AssigneeID)
+	if assigneeChanged {
+		s.notify(ctx, *task.AssigneeID, models.NotificationTaskAssigned, event,
+			fmt.Sprintf("You were assigned to task #%d %q", task.ID, task.Title))
+	}
+
+	if prev != nil && prev.Status != task.Status {
+		message := fmt.Sprintf("Task #%d %q moved from %s to %s", task.ID, task.Title, prev.Status, task.Status)
+		for _, userID := range taskParticipants(task) {
+			s.notify(ctx, userID, models.NotificationStatusChanged, event, message)
+		}
+	}
+}
+
+func (s *NotificationService) notify(ctx context.Context, userID int64, notificationType models.NotificationType, event TaskEvent, message string) {
+	taskID := event.Task.ID
+	if err := s.Notify(ctx, userID, notificationType, &taskID, event.ActorID, message); err != nil {
+		logging.FromContext(ctx).Error("notify user failed", "user_id", userID, "type", notificationType, "error", err)
+	}
+}
@@ -0,0 +1,130 @@
+package services
+
+import (
+	"backend/models"
+	"context"
+	"database/sql"
+	"testing"
+
+	mock_repo "backend/repositories/mocks"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNotificationService_OnTaskEvent(t *testing.T) {
+	alice, bob, carol := int64(1), int64(2), int64(3)
+
+	tests := []struct {
+		name       string
+		event      TaskEvent
+		setupMocks func(*mock_repo.MockNotificationRepositoryInterface)
+	}{
+		{
+			name: "New assignee is notified",
+			event: TaskEvent{
+				Type:    TaskCreated,
+				Task:    &models.Task{ID: 1, Title: "Deploy", Status: models.StatusToDo, AssignerID: &alice, AssigneeID: &bob},
+				ActorID: &alice,
+			},
+			setupMocks: func(m *mock_repo.MockNotificationRepositoryInterface) {
+				m.EXPECT().GetPreferences(gomock.Any(), bob).Return(map[models.NotificationType]bool{}, nil)
+				m.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, n *models.Notification) error {
+					assert.Equal(t, bob, n.UserID)
+					assert.Equal(t, models.NotificationTaskAssigned, n.Type)
+					assert.Equal(t, alice, *n.ActorID)
+					return nil
+				})
+			},
+		},
+		{
+			name: "Self-assignment is not notified",
+			event: TaskEvent{
+				Type:    TaskCreated,
+				Task:    &models.Task{ID: 1, Status: models.StatusToDo, AssignerID: &alice, AssigneeID: &alice},
+				ActorID: &alice,
+			},
+			setupMocks: func(m *mock_repo.MockNotificationRepositoryInterface) {},
+		},
+		{
+			name: "Status change notifies participants except the actor",
+			event: TaskEvent{
+				Type:     TaskUpdated,
+				Task:     &models.Task{ID: 1, Status: models.StatusDone, AssignerID: &carol, AssigneeID: &bob},
+				Previous: &models.Task{ID: 1, Status: models.StatusInProgress, AssignerID: &alice, AssigneeID: &bob},
+				ActorID:  &carol,
+			},
+			setupMocks: func(m *mock_repo.MockNotificationRepositoryInterface) {
+				m.EXPECT().GetPreferences(gomock.Any(), bob).Return(map[models.NotificationType]bool{}, nil)
+				m.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, n *models.Notification) error {
+					assert.Equal(t, bob, n.UserID)
+					assert.Equal(t, models.NotificationStatusChanged, n.Type)
+					return nil
+				})
+			},
+		},
+		{
+			name: "Disabled type creates no entry",
+			event: TaskEvent{
+				Type:     TaskUpdated,
+				Task:     &models.Task{ID: 1, Status: models.StatusToDo, AssignerID: &alice, AssigneeID: &carol},
+				Previous: &models.Task{ID: 1, Status: models.StatusToDo, AssignerID: &alice, AssigneeID: &bob},
+				ActorID:  &alice,
+			},
+			setupMocks: func(m *mock_repo.MockNotificationRepositoryInterface) {
+				m.EXPECT().GetPreferences(gomock.Any(), carol).Return(map[models.NotificationType]bool{
+					models.NotificationTaskAssigned: false,
+				}, nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := mock_repo.NewMockNotificationRepositoryInterface(ctrl)
+			tt.setupMocks(repo)
+
+			NewNotificationService(repo).OnTaskEvent(context.Background(), tt.event)
+		})
+	}
+}
+
+func TestNotificationService_Preferences(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock_repo.NewMockNotificationRepositoryInterface(ctrl)
+	service := NewNotificationService(repo)
+
+	repo.EXPECT().SetPreference(gomock.Any(), int64(1), models.NotificationMention, false).Return(nil)
+	repo.EXPECT().GetPreferences(gomock.Any(), int64(1)).Return(map[models.NotificationType]bool{
+		models.NotificationMention: false,
+	}, nil)
+
+	preferences, err := service.UpdatePreferences(context.Background(), 1, map[models.NotificationType]bool{
+		models.NotificationMention: false,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, map[models.NotificationType]bool{
+		models.NotificationTaskAssigned:  true,
+		models.NotificationStatusChanged: true,
+		models.NotificationMention:       false,
+	}, preferences)
+
+	_, err = service.UpdatePreferences(context.Background(), 1, map[models.NotificationType]bool{"task.archived": true})
+	assert.ErrorIs(t, err, ErrInvalidNotificationType)
+}
+
+func TestNotificationService_MarkRead(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock_repo.NewMockNotificationRepositoryInterface(ctrl)
+	repo.EXPECT().MarkRead(gomock.Any(), int64(9), int64(1)).Return(sql.ErrNoRows)
+
+	err := NewNotificationService(repo).MarkRead(context.Background(), 1, 9)
+	assert.ErrorIs(t, err, ErrNotificationNotFound)
+}
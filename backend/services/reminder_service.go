@@ -0,0 +1,150 @@
+package services
+
+import (
+	"backend/models"
+	"backend/pkg/logging"
+	"backend/pkg/notifier"
+	"backend/repositories"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// JobTypeTaskReminder is the job type used for due-date reminders
+const JobTypeTaskReminder = "task.reminder"
+
+type reminderPayload struct {
+	TaskID int64     `json:"task_id"`
+	DueAt  time.Time `json:"due_at"`
+	Offset string    `json:"offset"`
+	// Missing from jobs queued before reminders were tied to an assignee
+	AssigneeID *int64 `json:"assignee_id,omitempty"`
+}
+
+// ReminderService schedules due-date reminders as background jobs and
+// delivers them to task assignees when the jobs run.
+type ReminderService struct {
+	jobRepo  repositories.JobRepositoryInterface
+	taskRepo repositories.TaskRepositoryInterface
+	userRepo repositories.UserRepositoryInterface
+	notifier notifier.Notifier
+	offsets  []time.Duration
+	now      func() time.Time
+}
+
+func NewReminderService(
+	jobRepo repositories.JobRepositoryInterface,
+	taskRepo repositories.TaskRepositoryInterface,
+	userRepo repositories.UserRepositoryInterface,
+	n notifier.Notifier,
+	offsets []time.Duration,
+) *ReminderService {
+	return &ReminderService{
+		jobRepo:  jobRepo,
+		taskRepo: taskRepo,
+		userRepo: userRepo,
+		notifier: n,
+		offsets:  offsets,
+		now:      time.Now,
+	}
+}
+
+// OnTaskEvent enqueues reminder jobs when a task gains a due date or an
+// assignee. Jobs for stale due dates or a previous assignee are left in place
+// and skipped at run time.
+func (s *ReminderService) OnTaskEvent(ctx context.Context, event TaskEvent) {
+	if event.Type == TaskDeleted || !s.needsSchedule(event) {
+		return
+	}
+
+	if err := s.Schedule(ctx, event.Task); err != nil {
+		logging.FromContext(ctx).Error("schedule reminders failed", "task_id", event.Task.ID, "error", err)
+	}
+}
+
+func (s *ReminderService) needsSchedule(event TaskEvent) bool {
+	task := event.Task
+	if task.DueAt == nil || task.AssigneeID == nil || task.Status == models.StatusDone {
+		return false
+	}
+
+	prev := event.Previous
+	if prev == nil || prev.DueAt == nil || prev.AssigneeID == nil {
+		return true
+	}
+	return !prev.DueAt.Equal(*task.DueAt) || *prev.AssigneeID != *task.AssigneeID
+}
+
+// Schedule enqueues one reminder job per configured offset that is still in
+// the future.
+func (s *ReminderService) Schedule(ctx context.Context, task *models.Task) error {
+	if task.DueAt == nil {
+		return nil
+	}
+
+	for _, offset := range s.offsets {
+		runAt := task.DueAt.Add(-offset)
+		if runAt.Before(s.now()) {
+			continue
+		}
+
+		payload, err := json.Marshal(reminderPayload{
+			TaskID:     task.ID,
+			DueAt:      *task.DueAt,
+			Offset:     offset.String(),
+			AssigneeID: task.AssigneeID,
+		})
+		if err != nil {
+			return err
+		}
+
+		_, err = s.jobRepo.Enqueue(ctx, &models.Job{
+			Type:    JobTypeTaskReminder,
+			Payload: string(payload),
+			RunAt:   runAt,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// HandleReminder is the jobs.Handler for JobTypeTaskReminder.
+func (s *ReminderService) HandleReminder(ctx context.Context, job *models.Job) error {
+	var payload reminderPayload
+	if err := json.Unmarshal([]byte(job.Payload), &payload); err != nil {
+		return err
+	}
+
+	task, err := s.taskRepo.Get(ctx, payload.TaskID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	// The task moved on since the reminder was scheduled
+	if task.Status == models.StatusDone || task.AssigneeID == nil ||
+		task.DueAt == nil || !task.DueAt.Equal(payload.DueAt) ||
+		(payload.AssigneeID != nil && *payload.AssigneeID != *task.AssigneeID) {
+		return nil
+	}
+
+	assignee, err := s.userRepo.FindById(ctx, *task.AssigneeID)
+	if err != nil {
+		return err
+	}
+
+	return s.notifier.Send(ctx, notifier.Message{
+		To:      assignee.Email,
+		Subject: fmt.Sprintf("Reminder: %q is due %s", task.Title, task.DueAt.Format(time.RFC1123)),
+		Body: fmt.Sprintf("Hi %s, task #%d %q is due in %s.",
+			assignee.Username, task.ID, task.Title, payload.Offset),
+	})
+}
@@ -0,0 +1,205 @@
+package services
+
+import (
+	"backend/models"
+	"backend/pkg/notifier"
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	mock_repo "backend/repositories/mocks"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+type recordingNotifier struct {
+	sent []notifier.Message
+}
+
+func (n *recordingNotifier) Send(ctx context.Context, msg notifier.Message) error {
+	n.sent = append(n.sent, msg)
+	return nil
+}
+
+func TestReminderService_OnTaskEvent(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	dueAt := now.Add(48 * time.Hour)
+	assigneeID := int64(7)
+
+	tests := []struct {
+		name         string
+		event        TaskEvent
+		expectedJobs int
+	}{
+		{
+			name: "New task with due date schedules every future offset",
+			event: TaskEvent{Type: TaskCreated, Task: &models.Task{
+				ID: 1, Status: models.StatusToDo, AssigneeID: &assigneeID, DueAt: &dueAt,
+			}},
+			expectedJobs: 2,
+		},
+		{
+			name: "Task without assignee is ignored",
+			event: TaskEvent{Type: TaskCreated, Task: &models.Task{
+				ID: 1, Status: models.StatusToDo, DueAt: &dueAt,
+			}},
+			expectedJobs: 0,
+		},
+		{
+			name: "Update that keeps the due date does not reschedule",
+			event: TaskEvent{
+				Type:     TaskUpdated,
+				Task:     &models.Task{ID: 1, Status: models.StatusInProgress, AssigneeID: &assigneeID, DueAt: &dueAt},
+				Previous: &models.Task{ID: 1, Status: models.StatusToDo, AssigneeID: &assigneeID, DueAt: &dueAt},
+			},
+			expectedJobs: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			jobRepo := mock_repo.NewMockJobRepositoryInterface(ctrl)
+			jobRepo.EXPECT().
+				Enqueue(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, job *models.Job) (*models.Job, error) {
+					assert.Equal(t, JobTypeTaskReminder, job.Type)
+					assert.True(t, job.RunAt.After(now))
+					return job, nil
+				}).
+				Times(tt.expectedJobs)
+
+			service := NewReminderService(jobRepo, nil, nil, &recordingNotifier{},
+				[]time.Duration{24 * time.Hour, time.Hour, 72 * time.Hour})
+			service.now = func() time.Time { return now }
+
+			service.OnTaskEvent(context.Background(), tt.event)
+		})
+	}
+}
+
+func TestReminderService_HandleReminder(t *testing.T) {
+	dueAt := time.Date(2026, 1, 3, 12, 0, 0, 0, time.UTC)
+	assigneeID := int64(7)
+	job := &models.Job{
+		Type:    JobTypeTaskReminder,
+		Payload: `{"task_id":1,"due_at":"2026-01-03T12:00:00Z","offset":"24h0m0s","assignee_id":7}`,
+	}
+
+	tests := []struct {
+		name         string
+		setupMocks   func(*mock_repo.MockTaskRepositoryInterface, *mock_repo.MockUserRepositoryInterface)
+		expectedSent int
+	}{
+		{
+			name: "Sends reminder to assignee",
+			setupMocks: func(tr *mock_repo.MockTaskRepositoryInterface, ur *mock_repo.MockUserRepositoryInterface) {
+				tr.EXPECT().Get(gomock.Any(), int64(1)).Return(&models.Task{
+					ID: 1, Title: "Ship it", Status: models.StatusToDo, AssigneeID: &assigneeID, DueAt: &dueAt,
+				}, nil)
+				ur.EXPECT().FindById(gomock.Any(), assigneeID).Return(&models.User{ID: assigneeID, Username: "bob", Email: "bob@example.com"}, nil)
+			},
+			expectedSent: 1,
+		},
+		{
+			name: "Skips tasks that are already done",
+			setupMocks: func(tr *mock_repo.MockTaskRepositoryInterface, ur *mock_repo.MockUserRepositoryInterface) {
+				tr.EXPECT().Get(gomock.Any(), int64(1)).Return(&models.Task{
+					ID: 1, Status: models.StatusDone, AssigneeID: &assigneeID, DueAt: &dueAt,
+				}, nil)
+			},
+			expectedSent: 0,
+		},
+		{
+			name: "Skips reminders for a rescheduled due date",
+			setupMocks: func(tr *mock_repo.MockTaskRepositoryInterface, ur *mock_repo.MockUserRepositoryInterface) {
+				moved := dueAt.Add(time.Hour)
+				tr.EXPECT().Get(gomock.Any(), int64(1)).Return(&models.Task{
+					ID: 1, Status: models.StatusToDo, AssigneeID: &assigneeID, DueAt: &moved,
+				}, nil)
+			},
+			expectedSent: 0,
+		},
+		{
+			name: "Skips reminders for a previous assignee",
+			setupMocks: func(tr *mock_repo.MockTaskRepositoryInterface, ur *mock_repo.MockUserRepositoryInterface) {
+				other := int64(8)
+				tr.EXPECT().Get(gomock.Any(), int64(1)).Return(&models.Task{
+					ID: 1, Status: models.StatusToDo, AssigneeID: &other, DueAt: &dueAt,
+				}, nil)
+			},
+			expectedSent: 0,
+		},
+		{
+			name: "Skips deleted tasks",
+			setupMocks: func(tr *mock_repo.MockTaskRepositoryInterface, ur *mock_repo.MockUserRepositoryInterface) {
+				tr.EXPECT().Get(gomock.Any(), int64(1)).Return(nil, sql.ErrNoRows)
+			},
+			expectedSent: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			taskRepo := mock_repo.NewMockTaskRepositoryInterface(ctrl)
+			userRepo := mock_repo.NewMockUserRepositoryInterface(ctrl)
+			tt.setupMocks(taskRepo, userRepo)
+
+			sink := &recordingNotifier{}
+			service := NewReminderService(nil, taskRepo, userRepo, sink, nil)
+
+			err := service.HandleReminder(context.Background(), job)
+
+			assert.NoError(t, err)
+			assert.Len(t, sink.sent, tt.expectedSent)
+		})
+	}
+}
+
+func TestReminderService_Reassign(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	dueAt := now.Add(48 * time.Hour)
+	alice, bob := int64(7), int64(8)
+	assigned := &models.Task{ID: 1, Title: "Ship it", Status: models.StatusToDo, AssigneeID: &alice, DueAt: &dueAt}
+	reassigned := &models.Task{ID: 1, Title: "Ship it", Status: models.StatusToDo, AssigneeID: &bob, DueAt: &dueAt}
+
+	var queued []*models.Job
+	jobRepo := mock_repo.NewMockJobRepositoryInterface(ctrl)
+	jobRepo.EXPECT().Enqueue(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, job *models.Job) (*models.Job, error) {
+		queued = append(queued, job)
+		return job, nil
+	}).AnyTimes()
+	taskRepo := mock_repo.NewMockTaskRepositoryInterface(ctrl)
+	taskRepo.EXPECT().Get(gomock.Any(), int64(1)).Return(reassigned, nil).AnyTimes()
+	userRepo := mock_repo.NewMockUserRepositoryInterface(ctrl)
+	userRepo.EXPECT().FindById(gomock.Any(), bob).Return(&models.User{ID: bob, Username: "bob", Email: "bob@example.com"}, nil).AnyTimes()
+
+	sink := &recordingNotifier{}
+	offsets := []time.Duration{24 * time.Hour, time.Hour}
+	service := NewReminderService(jobRepo, taskRepo, userRepo, sink, offsets)
+	service.now = func() time.Time { return now }
+
+	service.OnTaskEvent(context.Background(), TaskEvent{Type: TaskCreated, Task: assigned})
+	service.OnTaskEvent(context.Background(), TaskEvent{Type: TaskUpdated, Task: reassigned, Previous: assigned})
+	assert.Len(t, queued, 2*len(offsets))
+
+	for _, job := range queued {
+		assert.NoError(t, service.HandleReminder(context.Background(), job))
+	}
+
+	// One reminder per offset, all to the new assignee
+	assert.Len(t, sink.sent, len(offsets))
+	for _, msg := range sink.sent {
+		assert.Equal(t, "bob@example.com", msg.To)
+	}
+}
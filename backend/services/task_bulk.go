@@ -0,0 +1,183 @@
+package services
+
+import (
+	"backend/models"
+	"backend/pkg/logging"
+	"context"
+	"fmt"
+)
+
+// maxBulkTasks bounds the number of tasks a bulk operation can touch
+const maxBulkTasks = 500
+
+var (
+	ErrInvalidBulkOperation = newError(ErrValidation, "invalid bulk operation")
+	ErrLabelsUnsupported    = newError(ErrValidation, "tasks do not have labels")
+)
+
+// Bulk applies op to every task userID is allowed to change, in one
+// transaction, and reports the outcome for each task. Status and priority
+// changes are allowed for a task's assigner and assignee; reassigning and
+// deleting only for its assigner.
+func (s *TaskService) Bulk(ctx context.Context, userID int64, op models.BulkOperation) (result *models.BulkResult, err error) {
+	ctx, end := startSpan(ctx, "TaskService.Bulk")
+	defer func() { end(err) }()
+
+	ids, err := validateBulkOperation(op)
+	if err != nil {
+		return nil, err
+	}
+
+	result = &models.BulkResult{Action: op.Action}
+	var events []TaskEvent
+	var applyErr error
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		existing, err := s.taskRepository.GetManyForUpdate(ctx, ids)
+		if err != nil {
+			return err
+		}
+		byID := make(map[int64]*models.Task, len(existing))
+		for i := range existing {
+			byID[existing[i].ID] = &existing[i]
+		}
+
+		var allowed []*models.Task
+		for _, id := range ids {
+			item := models.BulkItemResult{TaskID: id, Status: models.BulkItemOK}
+			task, ok := byID[id]
+			switch {
+			case !ok || !taskVisibleTo(task, userID):
+				item.Status = models.BulkItemNotFound
+				item.Error = ErrTaskNotFound.Error()
+			case !bulkPermitted(op.Action, task, userID):
+				item.Status = models.BulkItemForbidden
+				item.Error = ErrTaskForbidden.Error()
+			default:
+				allowed = append(allowed, task)
+			}
+			result.Items = append(result.Items, item)
+		}
+
+		if len(allowed) == 0 {
+			return nil
+		}
+		events, applyErr = s.applyBulk(ctx, userID, op, allowed)
+		return applyErr
+	})
+	if err != nil && applyErr == nil {
+		return nil, err
+	}
+
+	if err != nil {
+		// Nothing was changed, the transaction was rolled back
+		events = nil
+		message := publicMessage(applyErr)
+		if message == errInternal {
+			logging.FromContext(ctx).Error("bulk operation failed", "action", op.Action, "error", applyErr)
+		}
+		for i := range result.Items {
+			if result.Items[i].Status == models.BulkItemOK {
+				result.Items[i].Status = models.BulkItemFailed
+				result.Items[i].Error = message
+			}
+		}
+	}
+
+	for _, event := range events {
+		s.publish(ctx, event)
+	}
+	for _, item := range result.Items {
+		if item.Status == models.BulkItemOK {
+			result.Succeeded++
+		} else {
+			result.Failed++
+		}
+	}
+	return result, nil
+}
+
+// applyBulk changes tasks and returns the events to publish once the
+// transaction is committed
+func (s *TaskService) applyBulk(ctx context.Context, userID int64, op models.BulkOperation, tasks []*models.Task) ([]TaskEvent, error) {
+	var events []TaskEvent
+	if op.Action == models.BulkDelete {
+		ids := make([]int64, len(tasks))
+		for i, task := range tasks {
+			ids[i] = task.ID
+		}
+		if err := s.taskRepository.DeleteMany(ctx, ids); err != nil {
+			return nil, err
+		}
+		for _, task := range tasks {
+			events = append(events, TaskEvent{Type: TaskDeleted, Task: task, Previous: task, ActorID: &userID})
+		}
+		return events, nil
+	}
+
+	changed := make([]*models.Task, len(tasks))
+	for i, task := range tasks {
+		next := *task
+		switch op.Action {
+		case models.BulkSetStatus:
+			next.Status = *op.Status
+		case models.BulkReassign:
+			next.AssigneeID = op.AssigneeID
+		case models.BulkSetPriority:
+			next.Priority = *op.Priority
+		}
+		changed[i] = &next
+	}
+
+	updated, err := s.taskRepository.UpdateMany(ctx, changed)
+	if err != nil {
+		return nil, taskWriteError(err)
+	}
+	for i, task := range updated {
+		events = append(events, TaskEvent{Type: TaskUpdated, Task: task, Previous: tasks[i], ActorID: &userID})
+	}
+	return events, nil
+}
+
+// validateBulkOperation checks op and returns its task IDs without duplicates
+func validateBulkOperation(op models.BulkOperation) ([]int64, error) {
+	switch op.Action {
+	case models.BulkSetStatus:
+		if op.Status == nil || !op.Status.IsValid() {
+			return nil, fmt.Errorf("%w: status must be TO_DO, IN_PROGRESS, or DONE", ErrInvalidBulkOperation)
+		}
+	case models.BulkSetPriority:
+		if op.Priority == nil || *op.Priority < int(models.PriorityLow) || *op.Priority > int(models.PriorityHigh) {
+			return nil, fmt.Errorf("%w: priority must be 1, 2 or 3", ErrInvalidBulkOperation)
+		}
+	case models.BulkReassign, models.BulkDelete:
+	case models.BulkSetLabels:
+		return nil, ErrLabelsUnsupported
+	default:
+		return nil, fmt.Errorf("%w: action must be status, reassign, priority or delete", ErrInvalidBulkOperation)
+	}
+
+	if len(op.TaskIDs) == 0 {
+		return nil, fmt.Errorf("%w: task_ids is required", ErrInvalidBulkOperation)
+	}
+
+	seen := make(map[int64]bool, len(op.TaskIDs))
+	var ids []int64
+	for _, id := range op.TaskIDs {
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) > maxBulkTasks {
+		return nil, fmt.Errorf("%w: at most %d tasks at a time", ErrInvalidBulkOperation, maxBulkTasks)
+	}
+	return ids, nil
+}
+
+func bulkPermitted(action models.BulkAction, task *models.Task, userID int64) bool {
+	switch action {
+	case models.BulkReassign, models.BulkDelete:
+		return task.AssignerID != nil && *task.AssignerID == userID
+	}
+	return true
+}
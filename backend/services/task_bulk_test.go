@@ -0,0 +1,143 @@
+package services
+
+import (
+	"backend/models"
+	"backend/repositories"
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	mock_repo "backend/repositories/mocks"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type recordingListener struct {
+	events []TaskEvent
+}
+
+func (l *recordingListener) OnTaskEvent(_ context.Context, event TaskEvent) {
+	l.events = append(l.events, event)
+}
+
+func TestTaskService_Bulk(t *testing.T) {
+	me, other := int64(1), int64(2)
+	done := models.StatusDone
+	existing := []models.Task{
+		// Created by me
+		{ID: 10, Title: "Mine", Status: models.StatusToDo, AssignerID: &me, AssigneeID: &other},
+		// Assigned to me by someone else
+		{ID: 11, Title: "Assigned", Status: models.StatusInProgress, AssignerID: &other, AssigneeID: &me},
+		// Not visible to me
+		{ID: 12, Title: "Hidden", Status: models.StatusToDo, AssignerID: &other},
+	}
+
+	tests := []struct {
+		name          string
+		op            models.BulkOperation
+		setupMocks    func(m *mock_repo.MockTaskRepositoryInterface)
+		expectedItems []models.BulkItemStatus
+		// expectedFailure is the error of the items that failed
+		expectedFailure string
+		expectedEvent   TaskEventType
+		expectedError   error
+	}{
+		{
+			name: "Status change applies to visible tasks",
+			op:   models.BulkOperation{Action: models.BulkSetStatus, TaskIDs: []int64{10, 11, 12, 13, 10}, Status: &done},
+			setupMocks: func(m *mock_repo.MockTaskRepositoryInterface) {
+				m.EXPECT().UpdateMany(gomock.Any(), gomock.Len(2)).DoAndReturn(func(_ context.Context, tasks []*models.Task) ([]*models.Task, error) {
+					for _, task := range tasks {
+						assert.Equal(t, models.StatusDone, task.Status)
+					}
+					return tasks, nil
+				})
+			},
+			expectedItems: []models.BulkItemStatus{models.BulkItemOK, models.BulkItemOK, models.BulkItemNotFound, models.BulkItemNotFound},
+			expectedEvent: TaskUpdated,
+		},
+		{
+			name: "Only the assigner can delete",
+			op:   models.BulkOperation{Action: models.BulkDelete, TaskIDs: []int64{10, 11}},
+			setupMocks: func(m *mock_repo.MockTaskRepositoryInterface) {
+				m.EXPECT().DeleteMany(gomock.Any(), []int64{10}).Return(nil)
+			},
+			expectedItems: []models.BulkItemStatus{models.BulkItemOK, models.BulkItemForbidden},
+			expectedEvent: TaskDeleted,
+		},
+		{
+			name: "Failed transaction changes nothing",
+			op:   models.BulkOperation{Action: models.BulkReassign, TaskIDs: []int64{10}, AssigneeID: &me},
+			setupMocks: func(m *mock_repo.MockTaskRepositoryInterface) {
+				m.EXPECT().UpdateMany(gomock.Any(), gomock.Any()).Return(nil, errors.New(`pq: relation "tasks" does not exist`))
+			},
+			expectedItems:   []models.BulkItemStatus{models.BulkItemFailed},
+			expectedFailure: "internal error",
+		},
+		{
+			name: "Failed transaction reports why when it can",
+			op:   models.BulkOperation{Action: models.BulkReassign, TaskIDs: []int64{10}, AssigneeID: &me},
+			setupMocks: func(m *mock_repo.MockTaskRepositoryInterface) {
+				m.EXPECT().UpdateMany(gomock.Any(), gomock.Any()).Return(nil, fmt.Errorf("%w: tasks_assignee_id_fkey", repositories.ErrForeignKey))
+			},
+			expectedItems:   []models.BulkItemStatus{models.BulkItemFailed},
+			expectedFailure: ErrTaskUserNotFound.Error(),
+		},
+		{
+			name:          "Labels are not supported",
+			op:            models.BulkOperation{Action: models.BulkSetLabels, TaskIDs: []int64{10}},
+			expectedError: ErrLabelsUnsupported,
+		},
+		{
+			name:          "Invalid priority is rejected",
+			op:            models.BulkOperation{Action: models.BulkSetPriority, TaskIDs: []int64{10}, Priority: new(int)},
+			expectedError: ErrInvalidBulkOperation,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			taskRepo := mock_repo.NewMockTaskRepositoryInterface(ctrl)
+			if tt.expectedError == nil {
+				taskRepo.EXPECT().GetManyForUpdate(inTx{}, gomock.Any()).Return(append([]models.Task(nil), existing...), nil)
+			}
+			if tt.setupMocks != nil {
+				tt.setupMocks(taskRepo)
+			}
+
+			listener := &recordingListener{}
+			service := NewTaskService(taskRepo, &fakeTransactor{}, listener)
+			result, err := service.Bulk(context.Background(), me, tt.op)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+
+			var statuses []models.BulkItemStatus
+			for _, item := range result.Items {
+				statuses = append(statuses, item.Status)
+				if item.Status == models.BulkItemFailed {
+					assert.Equal(t, tt.expectedFailure, item.Error)
+				}
+			}
+			assert.Equal(t, tt.expectedItems, statuses)
+
+			var succeeded int
+			for _, event := range listener.events {
+				assert.Equal(t, tt.expectedEvent, event.Type)
+				assert.Equal(t, &me, event.ActorID)
+				succeeded++
+			}
+			assert.Equal(t, result.Succeeded, succeeded)
+			assert.Equal(t, len(tt.expectedItems)-succeeded, result.Failed)
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package services
+
+import (
+	"backend/models"
+	"context"
+)
+
+type TaskEventType string
+
+const (
+	TaskCreated TaskEventType = "task.created"
+	TaskUpdated TaskEventType = "task.updated"
+	TaskDeleted TaskEventType = "task.deleted"
+)
+
+// TaskEvent describes a change made through TaskService. Previous is the
+// stored task before an update or delete and is nil for creates. ActorID is
+// the user who made the change, when known.
+type TaskEvent struct {
+	Type     TaskEventType
+	Task     *models.Task
+	Previous *models.Task
+	ActorID  *int64
+}
+
+// TaskEventListener is notified after a task change has been persisted. ctx
+// is the context of the change without its deadline or cancellation, so
+// listeners log with the request's logger but are not cut short when the
+// request ends.
+type TaskEventListener interface {
+	OnTaskEvent(ctx context.Context, event TaskEvent)
+}
+
+func (s *TaskService) publish(ctx context.Context, event TaskEvent) {
+	ctx = context.WithoutCancel(ctx)
+	for _, listener := range s.listeners {
+		listener.OnTaskEvent(ctx, event)
+	}
+}
@@ -0,0 +1,25 @@
+package services
+
+import (
+	"backend/metrics"
+	"backend/models"
+	"context"
+)
+
+// TaskMetrics counts created tasks and tasks moved to DONE by an update
+type TaskMetrics struct{}
+
+func NewTaskMetrics() *TaskMetrics {
+	return &TaskMetrics{}
+}
+
+func (m *TaskMetrics) OnTaskEvent(_ context.Context, event TaskEvent) {
+	switch event.Type {
+	case TaskCreated:
+		metrics.TasksCreated.Inc()
+	case TaskUpdated:
+		if event.Task.Status == models.StatusDone && (event.Previous == nil || event.Previous.Status != models.StatusDone) {
+			metrics.TasksCompleted.Inc()
+		}
+	}
+}
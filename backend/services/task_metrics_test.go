@@ -0,0 +1,28 @@
+package services
+
+import (
+	"backend/metrics"
+	"backend/models"
+	"context"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTaskMetrics_OnTaskEvent(t *testing.T) {
+	todo := &models.Task{ID: 1, Status: models.StatusToDo}
+	done := &models.Task{ID: 1, Status: models.StatusDone}
+	created, completed := testutil.ToFloat64(metrics.TasksCreated), testutil.ToFloat64(metrics.TasksCompleted)
+
+	m := NewTaskMetrics()
+	ctx := context.Background()
+	m.OnTaskEvent(ctx, TaskEvent{Type: TaskCreated, Task: todo})
+	m.OnTaskEvent(ctx, TaskEvent{Type: TaskUpdated, Task: done, Previous: todo})
+	// Updating a task that was already done does not complete it again
+	m.OnTaskEvent(ctx, TaskEvent{Type: TaskUpdated, Task: done, Previous: done})
+	m.OnTaskEvent(ctx, TaskEvent{Type: TaskDeleted, Task: done, Previous: done})
+
+	assert.Equal(t, created+1, testutil.ToFloat64(metrics.TasksCreated))
+	assert.Equal(t, completed+1, testutil.ToFloat64(metrics.TasksCompleted))
+}
@@ -3,51 +3,185 @@ package services
 import (
 	"backend/models"
 	"backend/repositories"
+	"context"
+	"database/sql"
 	"errors"
 )
 
+var (
+	ErrTaskNotFound = newError(ErrNotFound, "task not found")
+	// ErrTaskUserNotFound is returned when a task's assignee or assigner is
+	// not a user
+	ErrTaskUserNotFound = newError(ErrValidation, "assignee or assigner does not exist")
+	ErrInvalidTask      = newError(ErrValidation, "status must be TO_DO, IN_PROGRESS, or DONE and priority 1, 2 or 3")
+	ErrTaskForbidden    = newError(ErrForbidden, "only the task's assigner can do this")
+)
+
 type TaskService struct {
 	taskRepository repositories.TaskRepositoryInterface
+	transactor     repositories.Transactor
+	listeners      []TaskEventListener
 }
 
-func NewTaskService(taskRepository repositories.TaskRepositoryInterface) TaskServiceInterface {
-	return &TaskService{taskRepository: taskRepository}
+// NewTaskService runs each read-modify-write of a task inside a transaction
+// of transactor. Listeners are notified once it has been committed.
+func NewTaskService(taskRepository repositories.TaskRepositoryInterface, transactor repositories.Transactor, listeners ...TaskEventListener) TaskServiceInterface {
+	return &TaskService{taskRepository: taskRepository, transactor: transactor, listeners: listeners}
 }
 
 type TaskServiceInterface interface {
-	Create(task *models.Task) (taskResponse *models.Task, err error)
-	Update(task *models.Task) (taskResponse *models.Task, err error)
-	Get(id int64) (task *models.Task, err error)
-	Delete(id int64) (err error)
-	GetTasksByAssignerID(assignerID int64) (tasks []models.Task, err error)
+	Create(ctx context.Context, task *models.Task) (taskResponse *models.Task, err error)
+	Update(ctx context.Context, task *models.Task) (taskResponse *models.Task, err error)
+	Get(ctx context.Context, id int64) (task *models.Task, err error)
+	Delete(ctx context.Context, id int64) (err error)
+	GetTasksByAssignerID(ctx context.Context, assignerID int64) (tasks []models.Task, err error)
+	CreateMany(ctx context.Context, tasks []*models.Task) (created []*models.Task, err error)
+	Bulk(ctx context.Context, userID int64, op models.BulkOperation) (*models.BulkResult, error)
 }
 
-func (s *TaskService) Create(task *models.Task) (taskResponse *models.Task, err error) {
-	return s.taskRepository.Create(task)
+func (s *TaskService) Create(ctx context.Context, task *models.Task) (taskResponse *models.Task, err error) {
+	ctx, end := startSpan(ctx, "TaskService.Create")
+	defer func() { end(err) }()
+
+	normalizeDueAt(task)
+	taskResponse, err = s.taskRepository.Create(ctx, task)
+	if err != nil {
+		return nil, taskWriteError(err)
+	}
+
+	// Handlers set AssignerID to the authenticated user making the change
+	s.publish(ctx, TaskEvent{Type: TaskCreated, Task: taskResponse, ActorID: task.AssignerID})
+	return taskResponse, nil
 }
 
-func (s *TaskService) Update(task *models.Task) (taskResponse *models.Task, err error) {
-	// Check if the task exists
-	existingTask, err := s.taskRepository.Get(task.ID)
+// CreateMany creates tasks in a single transaction and publishes an event for
+// each once all of them are stored
+func (s *TaskService) CreateMany(ctx context.Context, tasks []*models.Task) (created []*models.Task, err error) {
+	ctx, end := startSpan(ctx, "TaskService.CreateMany")
+	defer func() { end(err) }()
+
+	for _, task := range tasks {
+		normalizeDueAt(task)
+	}
+	created, err = s.taskRepository.CreateMany(ctx, tasks)
 	if err != nil {
-		return nil, err
+		return nil, taskWriteError(err)
 	}
 
-	if existingTask == nil {
-		return nil, errors.New("task not found")
+	for _, task := range created {
+		s.publish(ctx, TaskEvent{Type: TaskCreated, Task: task, ActorID: task.AssignerID})
 	}
+	return created, nil
+}
+
+// Update locks the task while it is read and written so concurrent updates
+// are applied one after the other and each event has the right Previous
+func (s *TaskService) Update(ctx context.Context, task *models.Task) (taskResponse *models.Task, err error) {
+	ctx, end := startSpan(ctx, "TaskService.Update")
+	defer func() { end(err) }()
 
-	return s.taskRepository.Update(task)
+	var existingTask *models.Task
+	normalizeDueAt(task)
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		existingTask, err = s.taskRepository.GetForUpdate(ctx, task.ID)
+		if err != nil {
+			return taskLookupError(err)
+		}
+
+		taskResponse, err = s.taskRepository.Update(ctx, task)
+		return err
+	})
+	if err != nil {
+		return nil, taskWriteError(err)
+	}
+
+	s.publish(ctx, TaskEvent{Type: TaskUpdated, Task: taskResponse, Previous: existingTask, ActorID: task.AssignerID})
+	return taskResponse, nil
 }
 
-func (s *TaskService) Get(id int64) (task *models.Task, err error) {
-	return s.taskRepository.Get(id)
+func (s *TaskService) Get(ctx context.Context, id int64) (task *models.Task, err error) {
+	ctx, end := startSpan(ctx, "TaskService.Get")
+	defer func() { end(err) }()
+
+	task, err = s.taskRepository.Get(ctx, id)
+	if err != nil {
+		return nil, taskLookupError(err)
+	}
+	return task, nil
 }
 
-func (s *TaskService) Delete(id int64) (err error) {
-	return s.taskRepository.Delete(id)
+func (s *TaskService) Delete(ctx context.Context, id int64) (err error) {
+	ctx, end := startSpan(ctx, "TaskService.Delete")
+	defer func() { end(err) }()
+
+	var existingTask *models.Task
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		existingTask, err = s.taskRepository.GetForUpdate(ctx, id)
+		if err != nil {
+			return taskLookupError(err)
+		}
+		return s.taskRepository.Delete(ctx, id)
+	})
+	if err != nil {
+		return err
+	}
+
+	s.publish(ctx, TaskEvent{Type: TaskDeleted, Task: existingTask, Previous: existingTask})
+	return nil
+}
+
+func (s *TaskService) GetTasksByAssignerID(ctx context.Context, assignerID int64) (tasks []models.Task, err error) {
+	ctx, end := startSpan(ctx, "TaskService.GetTasksByAssignerID")
+	defer func() { end(err) }()
+
+	return s.taskRepository.GetTasksByAssignerID(ctx, assignerID)
+}
+
+// taskLookupError turns a missing task into ErrTaskNotFound
+func taskLookupError(err error) error {
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrTaskNotFound
+	}
+	return err
+}
+
+// taskWriteError turns the constraint violations of a task write into the
+// errors of this package
+func taskWriteError(err error) error {
+	switch {
+	case errors.Is(err, repositories.ErrForeignKey):
+		return ErrTaskUserNotFound
+	case errors.Is(err, repositories.ErrCheck):
+		return ErrInvalidTask
+	}
+	return err
+}
+
+// normalizeDueAt stores due dates in UTC, the zone every storage returns
+// them in
+func normalizeDueAt(task *models.Task) {
+	if task.DueAt != nil {
+		dueAt := task.DueAt.UTC()
+		task.DueAt = &dueAt
+	}
+}
+
+// taskParticipants returns the distinct users that assigned or are assigned
+// to task
+func taskParticipants(task *models.Task) []int64 {
+	var ids []int64
+	if task.AssignerID != nil {
+		ids = append(ids, *task.AssignerID)
+	}
+	if task.AssigneeID != nil && (task.AssignerID == nil || *task.AssigneeID != *task.AssignerID) {
+		ids = append(ids, *task.AssigneeID)
+	}
+	return ids
 }
 
-func (s *TaskService) GetTasksByAssignerID(assignerID int64) (tasks []models.Task, err error) {
-	return s.taskRepository.GetTasksByAssignerID(assignerID)
+// taskVisibleTo reports whether userID may see task: its assigner and its
+// assignee can
+func taskVisibleTo(task *models.Task, userID int64) bool {
+	return (task.AssignerID != nil && *task.AssignerID == userID) ||
+		(task.AssigneeID != nil && *task.AssigneeID == userID)
 }
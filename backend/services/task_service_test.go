@@ -0,0 +1,174 @@
+package services
+
+import (
+	"backend/models"
+	"backend/repositories"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"testing"
+
+	mock_repo "backend/repositories/mocks"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type txKey struct{}
+
+// fakeTransactor runs fn with a context marking it as inside a transaction
+// and records whether it was committed
+type fakeTransactor struct {
+	committed  int
+	rolledBack int
+}
+
+func (f *fakeTransactor) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if err := fn(context.WithValue(ctx, txKey{}, true)); err != nil {
+		f.rolledBack++
+		return err
+	}
+	f.committed++
+	return nil
+}
+
+// inTx matches a context created by fakeTransactor
+type inTx struct{}
+
+func (inTx) Matches(x interface{}) bool {
+	ctx, ok := x.(context.Context)
+	return ok && ctx.Value(txKey{}) == true
+}
+
+func (inTx) String() string { return "is inside a transaction" }
+
+func TestTaskService_Update(t *testing.T) {
+	me := int64(1)
+	existing := &models.Task{ID: 7, Title: "Old", Status: models.StatusToDo, AssignerID: &me}
+	update := &models.Task{ID: 7, Title: "New", Status: models.StatusDone, AssignerID: &me}
+
+	t.Run("Reads and writes in one transaction", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		taskRepo := mock_repo.NewMockTaskRepositoryInterface(ctrl)
+		gomock.InOrder(
+			taskRepo.EXPECT().GetForUpdate(inTx{}, int64(7)).Return(existing, nil),
+			taskRepo.EXPECT().Update(inTx{}, update).Return(update, nil),
+		)
+
+		tx := &fakeTransactor{}
+		listener := &recordingListener{}
+		service := NewTaskService(taskRepo, tx, listener)
+
+		updated, err := service.Update(context.Background(), update)
+		require.NoError(t, err)
+		assert.Equal(t, update, updated)
+		assert.Equal(t, 1, tx.committed)
+		require.Len(t, listener.events, 1)
+		assert.Equal(t, existing, listener.events[0].Previous)
+	})
+
+	t.Run("Failed update is rolled back and not published", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		taskRepo := mock_repo.NewMockTaskRepositoryInterface(ctrl)
+		taskRepo.EXPECT().GetForUpdate(inTx{}, int64(7)).Return(existing, nil)
+		taskRepo.EXPECT().Update(inTx{}, update).Return(nil, errors.New("connection reset"))
+
+		tx := &fakeTransactor{}
+		listener := &recordingListener{}
+		service := NewTaskService(taskRepo, tx, listener)
+
+		_, err := service.Update(context.Background(), update)
+		assert.Error(t, err)
+		assert.Equal(t, 1, tx.rolledBack)
+		assert.Empty(t, listener.events)
+	})
+}
+
+func TestTaskService_ConstraintErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		repoErr  error
+		expected error
+	}{
+		{"Missing user", fmt.Errorf("%w: tasks_assignee_id_fkey", repositories.ErrForeignKey), ErrTaskUserNotFound},
+		{"Invalid status", fmt.Errorf("%w: tasks_status_check", repositories.ErrCheck), ErrInvalidTask},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			me := int64(1)
+			task := &models.Task{ID: 7, Title: "Task", Status: models.StatusToDo, AssignerID: &me}
+			taskRepo := mock_repo.NewMockTaskRepositoryInterface(ctrl)
+			taskRepo.EXPECT().Create(gomock.Any(), task).Return(nil, tt.repoErr)
+			taskRepo.EXPECT().GetForUpdate(gomock.Any(), int64(7)).Return(task, nil)
+			taskRepo.EXPECT().Update(gomock.Any(), task).Return(nil, tt.repoErr)
+			service := NewTaskService(taskRepo, &fakeTransactor{})
+
+			_, err := service.Create(context.Background(), task)
+			assert.ErrorIs(t, err, tt.expected)
+			_, err = service.Update(context.Background(), task)
+			assert.ErrorIs(t, err, tt.expected)
+		})
+	}
+}
+
+func TestTaskService_Delete(t *testing.T) {
+	task := &models.Task{ID: 7, Title: "Task", Status: models.StatusToDo}
+
+	tests := []struct {
+		name     string
+		found    bool
+		expected error
+	}{
+		{"Deletes the task", true, nil},
+		{"Missing task", false, ErrTaskNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			taskRepo := mock_repo.NewMockTaskRepositoryInterface(ctrl)
+			if tt.found {
+				taskRepo.EXPECT().GetForUpdate(inTx{}, int64(7)).Return(task, nil)
+				taskRepo.EXPECT().Delete(inTx{}, int64(7)).Return(nil)
+			} else {
+				taskRepo.EXPECT().GetForUpdate(inTx{}, int64(7)).Return(nil, sql.ErrNoRows)
+			}
+			listener := &recordingListener{}
+			service := NewTaskService(taskRepo, &fakeTransactor{}, listener)
+
+			err := service.Delete(context.Background(), 7)
+			if tt.expected != nil {
+				assert.ErrorIs(t, err, tt.expected)
+				assert.Empty(t, listener.events)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, listener.events, 1)
+			assert.Equal(t, TaskDeleted, listener.events[0].Type)
+		})
+	}
+}
+
+func TestTaskService_Get_NotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	taskRepo := mock_repo.NewMockTaskRepositoryInterface(ctrl)
+	taskRepo.EXPECT().Get(gomock.Any(), int64(7)).Return(nil, sql.ErrNoRows)
+	service := NewTaskService(taskRepo, &fakeTransactor{})
+
+	_, err := service.Get(context.Background(), 7)
+	assert.ErrorIs(t, err, ErrTaskNotFound)
+}
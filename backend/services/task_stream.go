@@ -0,0 +1,40 @@
+package services
+
+import (
+	"backend/pkg/logging"
+	"backend/realtime"
+	"context"
+	"encoding/json"
+)
+
+// TaskStreamPublisher forwards task events to the realtime hub, addressed to
+// everyone who could see the task before or after the change.
+type TaskStreamPublisher struct {
+	publisher realtime.Publisher
+}
+
+func NewTaskStreamPublisher(publisher realtime.Publisher) *TaskStreamPublisher {
+	return &TaskStreamPublisher{publisher: publisher}
+}
+
+func (p *TaskStreamPublisher) OnTaskEvent(ctx context.Context, event TaskEvent) {
+	data, err := json.Marshal(event.Task)
+	if err != nil {
+		logging.FromContext(ctx).Error("encode task event failed", "task_id", event.Task.ID, "error", err)
+		return
+	}
+
+	userIDs := taskParticipants(event.Task)
+	if event.Previous != nil {
+		userIDs = append(userIDs, taskParticipants(event.Previous)...)
+	}
+	if len(userIDs) == 0 {
+		return
+	}
+
+	p.publisher.Publish(realtime.Message{
+		Event:   string(event.Type),
+		Data:    data,
+		UserIDs: userIDs,
+	})
+}
@@ -0,0 +1,215 @@
+package services
+
+import (
+	"backend/models"
+	"backend/repositories"
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+)
+
+var (
+	ErrTimerAlreadyRunning = newError(ErrConflict, "a timer is already running, stop it first")
+	ErrNoRunningTimer      = newError(ErrConflict, "no timer is running on this task")
+	ErrInvalidWorkLog      = newError(ErrValidation, "duration must be positive")
+	ErrInvalidTimeRange    = newError(ErrValidation, "from must be before to")
+)
+
+type TimeTrackingServiceInterface interface {
+	StartTimer(ctx context.Context, userID, taskID int64, note string) (*models.WorkLog, error)
+	StopTimer(ctx context.Context, userID, taskID int64) (*models.WorkLog, error)
+	LogWork(ctx context.Context, userID, taskID int64, startedAt *time.Time, duration time.Duration, note string) (*models.WorkLog, error)
+	ListWorkLogs(ctx context.Context, userID, taskID int64) ([]models.WorkLog, error)
+	TaskTotals(ctx context.Context, userID, taskID int64, from, to time.Time) (*TaskTimeReport, error)
+	UserTotals(ctx context.Context, viewerID, userID int64, from, to time.Time) (*UserTimeReport, error)
+}
+
+// TaskTimeReport is the time logged on a task over a date range
+type TaskTimeReport struct {
+	TaskID                   int64                  `json:"task_id"`
+	From                     time.Time              `json:"from"`
+	To                       time.Time              `json:"to"`
+	TotalSeconds             int64                  `json:"total_seconds"`
+	OriginalEstimateMinutes  *int                   `json:"original_estimate_minutes"`
+	RemainingEstimateMinutes *int                   `json:"remaining_estimate_minutes"`
+	Users                    []models.UserTimeTotal `json:"users"`
+}
+
+// UserTimeReport is the time a user logged over a date range
+type UserTimeReport struct {
+	UserID       int64                  `json:"user_id"`
+	From         time.Time              `json:"from"`
+	To           time.Time              `json:"to"`
+	TotalSeconds int64                  `json:"total_seconds"`
+	Tasks        []models.TaskTimeTotal `json:"tasks"`
+}
+
+type TimeTrackingService struct {
+	workLogRepo repositories.WorkLogRepositoryInterface
+	taskRepo    repositories.TaskRepositoryInterface
+	now         func() time.Time
+}
+
+func NewTimeTrackingService(workLogRepo repositories.WorkLogRepositoryInterface, taskRepo repositories.TaskRepositoryInterface) TimeTrackingServiceInterface {
+	return &TimeTrackingService{
+		workLogRepo: workLogRepo,
+		taskRepo:    taskRepo,
+		now:         time.Now,
+	}
+}
+
+// StartTimer starts a timer for userID on a task. Users can only have one
+// running timer at a time.
+func (s *TimeTrackingService) StartTimer(ctx context.Context, userID, taskID int64, note string) (*models.WorkLog, error) {
+	if _, err := s.visibleTask(ctx, userID, taskID); err != nil {
+		return nil, err
+	}
+
+	_, err := s.workLogRepo.GetRunningTimer(ctx, userID)
+	if err == nil {
+		return nil, ErrTimerAlreadyRunning
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+
+	workLog := &models.WorkLog{
+		TaskID:    taskID,
+		UserID:    userID,
+		StartedAt: s.now().UTC(),
+		Note:      note,
+	}
+	if err := s.workLogRepo.Create(ctx, workLog); err != nil {
+		// Another timer was started since the check above
+		if errors.Is(err, repositories.ErrDuplicate) {
+			return nil, ErrTimerAlreadyRunning
+		}
+		return nil, err
+	}
+	return workLog, nil
+}
+
+func (s *TimeTrackingService) StopTimer(ctx context.Context, userID, taskID int64) (*models.WorkLog, error) {
+	running, err := s.workLogRepo.GetRunningTimer(ctx, userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNoRunningTimer
+	}
+	if err != nil {
+		return nil, err
+	}
+	if running.TaskID != taskID {
+		return nil, ErrNoRunningTimer
+	}
+
+	workLog, err := s.workLogRepo.StopTimer(ctx, running.ID, s.now().UTC())
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNoRunningTimer
+	}
+	return workLog, err
+}
+
+// LogWork records time spent without a timer. The entry ends now unless
+// startedAt is given.
+func (s *TimeTrackingService) LogWork(ctx context.Context, userID, taskID int64, startedAt *time.Time, duration time.Duration, note string) (*models.WorkLog, error) {
+	if duration <= 0 {
+		return nil, ErrInvalidWorkLog
+	}
+	if _, err := s.visibleTask(ctx, userID, taskID); err != nil {
+		return nil, err
+	}
+
+	var start time.Time
+	if startedAt != nil {
+		start = startedAt.UTC()
+	} else {
+		start = s.now().UTC().Add(-duration)
+	}
+	end := start.Add(duration)
+	seconds := int64(duration / time.Second)
+
+	workLog := &models.WorkLog{
+		TaskID:          taskID,
+		UserID:          userID,
+		StartedAt:       start,
+		EndedAt:         &end,
+		DurationSeconds: &seconds,
+		Note:            note,
+	}
+	if err := s.workLogRepo.Create(ctx, workLog); err != nil {
+		return nil, err
+	}
+	return workLog, nil
+}
+
+func (s *TimeTrackingService) ListWorkLogs(ctx context.Context, userID, taskID int64) ([]models.WorkLog, error) {
+	if _, err := s.visibleTask(ctx, userID, taskID); err != nil {
+		return nil, err
+	}
+	return s.workLogRepo.ListByTaskID(ctx, taskID)
+}
+
+func (s *TimeTrackingService) TaskTotals(ctx context.Context, userID, taskID int64, from, to time.Time) (*TaskTimeReport, error) {
+	if !from.Before(to) {
+		return nil, ErrInvalidTimeRange
+	}
+	task, err := s.visibleTask(ctx, userID, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	totals, err := s.workLogRepo.TotalsForTask(ctx, taskID, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &TaskTimeReport{
+		TaskID:                   taskID,
+		From:                     from,
+		To:                       to,
+		OriginalEstimateMinutes:  task.OriginalEstimateMinutes,
+		RemainingEstimateMinutes: task.RemainingEstimateMinutes,
+		Users:                    totals,
+	}
+	for _, total := range totals {
+		report.TotalSeconds += total.TotalSeconds
+	}
+	return report, nil
+}
+
+// UserTotals reports the time userID logged, limited to tasks viewerID can see
+func (s *TimeTrackingService) UserTotals(ctx context.Context, viewerID, userID int64, from, to time.Time) (*UserTimeReport, error) {
+	if !from.Before(to) {
+		return nil, ErrInvalidTimeRange
+	}
+
+	totals, err := s.workLogRepo.TotalsForUser(ctx, userID, viewerID, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &UserTimeReport{
+		UserID: userID,
+		From:   from,
+		To:     to,
+		Tasks:  totals,
+	}
+	for _, total := range totals {
+		report.TotalSeconds += total.TotalSeconds
+	}
+	return report, nil
+}
+
+func (s *TimeTrackingService) visibleTask(ctx context.Context, userID, taskID int64) (*models.Task, error) {
+	task, err := s.taskRepo.Get(ctx, taskID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrTaskNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !taskVisibleTo(task, userID) {
+		return nil, ErrTaskNotFound
+	}
+	return task, nil
+}
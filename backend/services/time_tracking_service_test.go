@@ -0,0 +1,131 @@
+package services
+
+import (
+	"backend/models"
+	"backend/repositories"
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+	"time"
+
+	mock_repo "backend/repositories/mocks"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTimeTrackingService_StartTimer(t *testing.T) {
+	owner, stranger := int64(1), int64(2)
+
+	tests := []struct {
+		name          string
+		userID        int64
+		running       *models.WorkLog
+		expectCreate  bool
+		createErr     error
+		expectedError error
+	}{
+		{
+			name:         "Timer starts when none is running",
+			userID:       owner,
+			expectCreate: true,
+		},
+		{
+			name:          "Second timer is rejected",
+			userID:        owner,
+			running:       &models.WorkLog{ID: 3, TaskID: 9, UserID: owner},
+			expectedError: ErrTimerAlreadyRunning,
+		},
+		{
+			name:          "Timer started concurrently is rejected",
+			userID:        owner,
+			expectCreate:  true,
+			createErr:     fmt.Errorf("%w: idx_work_logs_running_timer", repositories.ErrDuplicate),
+			expectedError: ErrTimerAlreadyRunning,
+		},
+		{
+			name:          "Stranger cannot track time",
+			userID:        stranger,
+			expectedError: ErrTaskNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			taskRepo := mock_repo.NewMockTaskRepositoryInterface(ctrl)
+			workLogRepo := mock_repo.NewMockWorkLogRepositoryInterface(ctrl)
+			taskRepo.EXPECT().Get(gomock.Any(), int64(5)).Return(&models.Task{ID: 5, AssignerID: &owner}, nil)
+			if tt.userID == owner {
+				if tt.running != nil {
+					workLogRepo.EXPECT().GetRunningTimer(gomock.Any(), owner).Return(tt.running, nil)
+				} else {
+					workLogRepo.EXPECT().GetRunningTimer(gomock.Any(), owner).Return(nil, sql.ErrNoRows)
+				}
+			}
+			if tt.expectCreate {
+				workLogRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(tt.createErr)
+			}
+
+			service := NewTimeTrackingService(workLogRepo, taskRepo)
+			workLog, err := service.StartTimer(context.Background(), tt.userID, 5, "debugging")
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, int64(5), workLog.TaskID)
+			assert.Nil(t, workLog.EndedAt)
+		})
+	}
+}
+
+func TestTimeTrackingService_StopTimer(t *testing.T) {
+	owner := int64(1)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	taskRepo := mock_repo.NewMockTaskRepositoryInterface(ctrl)
+	workLogRepo := mock_repo.NewMockWorkLogRepositoryInterface(ctrl)
+	workLogRepo.EXPECT().GetRunningTimer(gomock.Any(), owner).Return(&models.WorkLog{ID: 3, TaskID: 9, UserID: owner}, nil).Times(2)
+
+	now := time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC)
+	service := &TimeTrackingService{workLogRepo: workLogRepo, taskRepo: taskRepo, now: func() time.Time { return now }}
+
+	// The running timer belongs to another task
+	_, err := service.StopTimer(context.Background(), owner, 5)
+	assert.ErrorIs(t, err, ErrNoRunningTimer)
+
+	workLogRepo.EXPECT().StopTimer(gomock.Any(), int64(3), now).Return(&models.WorkLog{ID: 3, TaskID: 9, EndedAt: &now}, nil)
+	workLog, err := service.StopTimer(context.Background(), owner, 9)
+	require.NoError(t, err)
+	assert.Equal(t, &now, workLog.EndedAt)
+}
+
+func TestTimeTrackingService_LogWork(t *testing.T) {
+	owner := int64(1)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	taskRepo := mock_repo.NewMockTaskRepositoryInterface(ctrl)
+	workLogRepo := mock_repo.NewMockWorkLogRepositoryInterface(ctrl)
+	service := NewTimeTrackingService(workLogRepo, taskRepo)
+
+	_, err := service.LogWork(context.Background(), owner, 5, nil, 0, "")
+	assert.ErrorIs(t, err, ErrInvalidWorkLog)
+
+	taskRepo.EXPECT().Get(gomock.Any(), int64(5)).Return(&models.Task{ID: 5, AssigneeID: &owner}, nil)
+	workLogRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+
+	startedAt := time.Date(2026, 3, 2, 9, 0, 0, 0, time.FixedZone("CET", 3600))
+	workLog, err := service.LogWork(context.Background(), owner, 5, &startedAt, 90*time.Minute, "review")
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2026, 3, 2, 8, 0, 0, 0, time.UTC), workLog.StartedAt)
+	assert.Equal(t, time.Date(2026, 3, 2, 9, 30, 0, 0, time.UTC), *workLog.EndedAt)
+	assert.Equal(t, int64(5400), *workLog.DurationSeconds)
+}
@@ -0,0 +1,24 @@
+package services
+
+import (
+	"backend/tracing"
+	"context"
+
+	"go.opentelemetry.io/otel/codes"
+)
+
+// startSpan starts the span of a service method. Call the returned function
+// with the method's error when it returns:
+//
+//	ctx, end := startSpan(ctx, "TaskService.Create")
+//	defer func() { end(err) }()
+func startSpan(ctx context.Context, name string) (context.Context, func(error)) {
+	ctx, span := tracing.Tracer().Start(ctx, name)
+	return ctx, func(err error) {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}
+}
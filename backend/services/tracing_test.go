@@ -0,0 +1,43 @@
+package services
+
+import (
+	"backend/models"
+	mock_repo "backend/repositories/mocks"
+	"backend/tracing"
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/codes"
+)
+
+func TestTaskService_Spans(t *testing.T) {
+	exporter := tracing.InMemory()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	taskRepo := mock_repo.NewMockTaskRepositoryInterface(ctrl)
+	taskRepo.EXPECT().Get(gomock.Any(), int64(7)).Return(&models.Task{ID: 7}, nil)
+	taskRepo.EXPECT().Get(gomock.Any(), int64(8)).Return(nil, sql.ErrNoRows)
+	service := NewTaskService(taskRepo, &fakeTransactor{})
+
+	ctx, parent := tracing.Tracer().Start(context.Background(), "request")
+	_, err := service.Get(ctx, 7)
+	require.NoError(t, err)
+	_, err = service.Get(ctx, 8)
+	assert.ErrorIs(t, err, ErrTaskNotFound)
+	parent.End()
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 3)
+	for _, span := range spans[:2] {
+		assert.Equal(t, "TaskService.Get", span.Name)
+		assert.Equal(t, parent.SpanContext().SpanID(), span.Parent.SpanID())
+	}
+	assert.Equal(t, codes.Unset, spans[0].Status.Code)
+	assert.Equal(t, codes.Error, spans[1].Status.Code)
+	assert.Equal(t, "task not found", spans[1].Status.Description)
+}
@@ -1,61 +1,113 @@
 package services
 
 import (
+	"backend/metrics"
 	"backend/models"
 	"backend/pkg/hash"
 	"backend/pkg/jwt"
 	"backend/repositories"
+	"context"
+	"database/sql"
 	"errors"
+	"strings"
+	"time"
 )
 
+var (
+	ErrUsernameTaken = newError(ErrConflict, "username already exists")
+	ErrEmailTaken    = newError(ErrConflict, "email already exists")
+	ErrUserNotFound  = newError(ErrNotFound, "user not found")
+	// ErrInvalidCredentials is returned for an unknown email and for a wrong
+	// password alike, so logins don't reveal which emails are registered
+	ErrInvalidCredentials = newError(ErrUnauthenticated, "invalid credentials")
+)
+
+// AuthConfig configures how passwords are hashed and tokens are signed
+type AuthConfig struct {
+	JWTSecret     string
+	TokenLifetime time.Duration
+	BcryptCost    int
+}
+
 type UserService struct {
 	userRepo repositories.UserRepositoryInterface
+	auth     AuthConfig
 }
 
 // Interface
 type UserServiceInterface interface {
-	Register(user *models.User) error
-	Login(email, password string) (string, error)
-	GetUserById(userId int64) (*models.UserResponse, error)
-	GetUserByEmail(email string) (*models.UserResponse, error)
+	Register(ctx context.Context, user *models.User) error
+	Login(ctx context.Context, email, password string) (string, error)
+	GetUserById(ctx context.Context, userId int64) (*models.UserResponse, error)
+	GetUserByEmail(ctx context.Context, email string) (*models.UserResponse, error)
 }
 
-func NewUserService(userRepo repositories.UserRepositoryInterface) UserServiceInterface {
-	return &UserService{userRepo: userRepo}
+func NewUserService(userRepo repositories.UserRepositoryInterface, auth AuthConfig) UserServiceInterface {
+	return &UserService{userRepo: userRepo, auth: auth}
 }
 
-func (s *UserService) Register(user *models.User) error {
+func (s *UserService) Register(ctx context.Context, user *models.User) (err error) {
+	ctx, end := startSpan(ctx, "UserService.Register")
+	defer func() { end(err) }()
+
 	// Check if username already exists
-	if _, err := s.userRepo.FindByUsername(user.Username); err == nil {
-		return errors.New("username already exists")
+	if _, err := s.userRepo.FindByUsername(ctx, user.Username); err == nil {
+		return ErrUsernameTaken
 	}
 
 	// Check if email already exists
-	if _, err := s.userRepo.FindByEmail(user.Email); err == nil {
-		return errors.New("email already exists")
+	if _, err := s.userRepo.FindByEmail(ctx, user.Email); err == nil {
+		return ErrEmailTaken
 	}
 
-	hashedPassword, err := hash.HashPassword(user.Password)
+	hashedPassword, err := hash.HashPassword(user.Password, s.auth.BcryptCost)
 	if err != nil {
 		return err
 	}
 
 	user.Password = hashedPassword
-	return s.userRepo.Create(user)
+	err = s.userRepo.Create(ctx, user)
+	if errors.Is(err, repositories.ErrDuplicate) {
+		// Another registration took the name or email since the checks
+		// above. The error names the constraint.
+		if strings.Contains(err.Error(), "email") {
+			return ErrEmailTaken
+		}
+		return ErrUsernameTaken
+	}
+	return err
 }
 
-func (s *UserService) Login(email, password string) (string, error) {
-	user, err := s.userRepo.FindByEmail(email)
+func (s *UserService) Login(ctx context.Context, email, password string) (token string, err error) {
+	ctx, end := startSpan(ctx, "UserService.Login")
+	defer func() { end(err) }()
+
+	defer func() {
+		// Only wrong credentials are failed logins, an unavailable
+		// database is not
+		result := "success"
+		if errors.Is(err, ErrInvalidCredentials) {
+			result = "failure"
+		} else if err != nil {
+			result = "error"
+		}
+		metrics.Logins.WithLabelValues(result).Inc()
+	}()
+
+	user, err := s.userRepo.FindByEmail(ctx, email)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", ErrInvalidCredentials
+	}
 	if err != nil {
-		return "", errors.New("invalid email")
+		return "", err
 	}
 
 	if !hash.CheckPasswordHash(password, user.Password) {
-		return "", errors.New("invalid credentials")
+		return "", ErrInvalidCredentials
 	}
 
 	// Generate JWT token
-	token, err := jwt.GenerateToken(uint(user.ID), "your-secret-key") // TODO: Use config for secret
+	token, err = jwt.GenerateToken(uint(user.ID), s.auth.JWTSecret, s.auth.TokenLifetime)
 	if err != nil {
 		return "", err
 	}
@@ -63,8 +115,14 @@ func (s *UserService) Login(email, password string) (string, error) {
 	return token, nil
 }
 
-func (s *UserService) GetUserById(userId int64) (*models.UserResponse, error) {
-	user, err := s.userRepo.FindById(userId)
+func (s *UserService) GetUserById(ctx context.Context, userId int64) (response *models.UserResponse, err error) {
+	ctx, end := startSpan(ctx, "UserService.GetUserById")
+	defer func() { end(err) }()
+
+	user, err := s.userRepo.FindById(ctx, userId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrUserNotFound
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -77,8 +135,14 @@ func (s *UserService) GetUserById(userId int64) (*models.UserResponse, error) {
 	}, nil
 }
 
-func (s *UserService) GetUserByEmail(email string) (*models.UserResponse, error) {
-	user, err := s.userRepo.FindByEmail(email)
+func (s *UserService) GetUserByEmail(ctx context.Context, email string) (response *models.UserResponse, err error) {
+	ctx, end := startSpan(ctx, "UserService.GetUserByEmail")
+	defer func() { end(err) }()
+
+	user, err := s.userRepo.FindByEmail(ctx, email)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrUserNotFound
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -1,9 +1,15 @@
 package services
 
 import (
+	"backend/metrics"
 	"backend/models"
 	"backend/pkg/hash"
+	"backend/pkg/jwt"
+	"backend/repositories"
+	"context"
+	"database/sql"
 	"errors"
+	"fmt"
 	"testing"
 	"time"
 
@@ -11,9 +17,14 @@ import (
 	mock_repo "backend/repositories/mocks"
 
 	"github.com/golang/mock/gomock"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/bcrypt"
 )
 
+// testAuth keeps password hashing fast in tests
+var testAuth = AuthConfig{JWTSecret: "test-secret", TokenLifetime: time.Hour, BcryptCost: bcrypt.MinCost}
+
 func TestUserService_Register(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -22,7 +33,7 @@ func TestUserService_Register(t *testing.T) {
 	mockRepo := mock_repo.NewMockUserRepositoryInterface(ctrl)
 
 	// Initialize service
-	service := NewUserService(mockRepo)
+	service := NewUserService(mockRepo, testAuth)
 
 	tests := []struct {
 		name          string
@@ -38,9 +49,9 @@ func TestUserService_Register(t *testing.T) {
 				Password: "password123",
 			},
 			setupMocks: func() {
-				mockRepo.EXPECT().FindByUsername("testuser").Return(nil, errors.New("not found"))
-				mockRepo.EXPECT().FindByEmail("test@example.com").Return(nil, errors.New("not found"))
-				mockRepo.EXPECT().Create(gomock.Any()).Return(nil) // Use gomock.Any() instead of mock.AnythingOfType()
+				mockRepo.EXPECT().FindByUsername(gomock.Any(), "testuser").Return(nil, errors.New("not found"))
+				mockRepo.EXPECT().FindByEmail(gomock.Any(), "test@example.com").Return(nil, errors.New("not found"))
+				mockRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil) // Use gomock.Any() instead of mock.AnythingOfType()
 			},
 			expectedError: nil,
 		},
@@ -52,10 +63,24 @@ func TestUserService_Register(t *testing.T) {
 				Password: "password123",
 			},
 			setupMocks: func() {
-				mockRepo.EXPECT().FindByUsername("existinguser").Return(&models.User{}, nil)
+				mockRepo.EXPECT().FindByUsername(gomock.Any(), "existinguser").Return(&models.User{}, nil)
 			},
 			expectedError: errors.New("username already exists"),
 		},
+		{
+			name: "Email taken by a concurrent registration",
+			user: &models.User{
+				Username: "racer",
+				Email:    "race@example.com",
+				Password: "password123",
+			},
+			setupMocks: func() {
+				mockRepo.EXPECT().FindByUsername(gomock.Any(), "racer").Return(nil, errors.New("not found"))
+				mockRepo.EXPECT().FindByEmail(gomock.Any(), "race@example.com").Return(nil, errors.New("not found"))
+				mockRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(fmt.Errorf("%w: users_email_key", repositories.ErrDuplicate))
+			},
+			expectedError: ErrEmailTaken,
+		},
 	}
 
 	for _, tt := range tests {
@@ -63,7 +88,7 @@ func TestUserService_Register(t *testing.T) {
 			// Remove the controller creation from here since we already have it at the top
 			tt.setupMocks()
 
-			err := service.Register(tt.user)
+			err := service.Register(context.Background(), tt.user)
 
 			if tt.expectedError != nil {
 				assert.EqualError(t, err, tt.expectedError.Error())
@@ -87,9 +112,9 @@ func TestUserService_Login(t *testing.T) {
 			email:    "test@example.com", // Use email instead of username
 			password: "password123",
 			setupMocks: func(mockRepo *mocks.MockUserRepositoryInterface) {
-				hashedPassword, _ := hash.HashPassword("password123")
+				hashedPassword, _ := hash.HashPassword("password123", bcrypt.MinCost)
 				mockRepo.EXPECT().
-					FindByEmail("test@example.com"). // Change to FindByEmail
+					FindByEmail(gomock.Any(), "test@example.com"). // Change to FindByEmail
 					Return(&models.User{
 						ID:        1,
 						Username:  "testuser",
@@ -107,20 +132,20 @@ func TestUserService_Login(t *testing.T) {
 			password: "password123",
 			setupMocks: func(mockRepo *mocks.MockUserRepositoryInterface) {
 				mockRepo.EXPECT().
-					FindByEmail("nonexistent@example.com").
-					Return(nil, errors.New("user not found"))
+					FindByEmail(gomock.Any(), "nonexistent@example.com").
+					Return(nil, sql.ErrNoRows)
 			},
 			expectedToken: "",
-			expectedError: errors.New("invalid email"),
+			expectedError: ErrInvalidCredentials,
 		},
 		{
 			name:     "Invalid password",
 			email:    "test@example.com",
 			password: "wrongpassword",
 			setupMocks: func(mockRepo *mocks.MockUserRepositoryInterface) {
-				hashedPassword, _ := hash.HashPassword("password123")
+				hashedPassword, _ := hash.HashPassword("password123", bcrypt.MinCost)
 				mockRepo.EXPECT().
-					FindByEmail("test@example.com").
+					FindByEmail(gomock.Any(), "test@example.com").
 					Return(&models.User{
 						ID:        1,
 						Username:  "testuser",
@@ -130,7 +155,19 @@ func TestUserService_Login(t *testing.T) {
 					}, nil)
 			},
 			expectedToken: "",
-			expectedError: errors.New("invalid credentials"),
+			expectedError: ErrInvalidCredentials,
+		},
+		{
+			name:     "Database unavailable",
+			email:    "test@example.com",
+			password: "password123",
+			setupMocks: func(mockRepo *mocks.MockUserRepositoryInterface) {
+				mockRepo.EXPECT().
+					FindByEmail(gomock.Any(), "test@example.com").
+					Return(nil, errors.New("dial tcp: connection refused"))
+			},
+			expectedToken: "",
+			expectedError: errors.New("dial tcp: connection refused"),
 		},
 	}
 
@@ -145,10 +182,17 @@ func TestUserService_Login(t *testing.T) {
 				tt.setupMocks(mockRepo)
 			}
 
-			service := NewUserService(mockRepo)
+			service := NewUserService(mockRepo, testAuth)
+			result := "success"
+			if errors.Is(tt.expectedError, ErrInvalidCredentials) {
+				result = "failure"
+			} else if tt.expectedError != nil {
+				result = "error"
+			}
+			logins := testutil.ToFloat64(metrics.Logins.WithLabelValues(result))
 
 			// Execute
-			token, err := service.Login(tt.email, tt.password)
+			token, err := service.Login(context.Background(), tt.email, tt.password)
 
 			// Assert
 			if tt.expectedError != nil {
@@ -157,8 +201,11 @@ func TestUserService_Login(t *testing.T) {
 				assert.Empty(t, token)
 			} else {
 				assert.NoError(t, err)
-				assert.NotEmpty(t, token)
+				claims, err := jwt.ValidateToken(token, testAuth.JWTSecret)
+				assert.NoError(t, err)
+				assert.InDelta(t, time.Now().Add(testAuth.TokenLifetime).Unix(), claims["exp"], 5)
 			}
+			assert.Equal(t, logins+1, testutil.ToFloat64(metrics.Logins.WithLabelValues(result)))
 		})
 	}
 }
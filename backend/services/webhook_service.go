@@ -0,0 +1,302 @@
+package services
+
+import (
+	"backend/models"
+	"backend/pkg/egress"
+	"backend/pkg/logging"
+	"backend/pkg/signature"
+	"backend/repositories"
+	"bytes"
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// JobTypeWebhookDelivery is the job type used to send one webhook delivery
+const JobTypeWebhookDelivery = "webhook.deliver"
+
+// webhookMaxAttempts bounds retries before a delivery is dead-lettered
+const webhookMaxAttempts = 8
+
+var (
+	ErrWebhookNotFound     = newError(ErrNotFound, "webhook not found")
+	ErrInvalidWebhookURL   = newError(ErrValidation, "webhook url must be an absolute http or https url")
+	ErrForbiddenWebhookURL = newError(ErrValidation, "webhook url must not point to a private, loopback or link-local address")
+	ErrInvalidWebhookEvent = newError(ErrValidation, "event types must be one or more of task.created, task.updated, task.deleted")
+)
+
+type WebhookServiceInterface interface {
+	CreateSubscription(ctx context.Context, userID int64, rawURL string, eventTypes []string) (*models.WebhookSubscription, error)
+	ListSubscriptions(ctx context.Context, userID int64) ([]models.WebhookSubscription, error)
+	DeleteSubscription(ctx context.Context, userID, id int64) error
+	ListDeliveries(ctx context.Context, userID, subscriptionID int64, limit int) ([]models.WebhookDelivery, error)
+}
+
+// WebhookService manages subscriptions and fans task events out to them.
+// Deliveries are sent by the jobs pool so they survive restarts and are
+// retried with exponential backoff. Receivers must be on public addresses,
+// which is checked on subscribe and again on every connection since DNS can
+// change in between.
+type WebhookService struct {
+	webhookRepo repositories.WebhookRepositoryInterface
+	jobRepo     repositories.JobRepositoryInterface
+	client      *http.Client
+	lookup      egress.LookupFunc
+}
+
+func NewWebhookService(webhookRepo repositories.WebhookRepositoryInterface, jobRepo repositories.JobRepositoryInterface) *WebhookService {
+	return &WebhookService{
+		webhookRepo: webhookRepo,
+		jobRepo:     jobRepo,
+		client:      egress.Client(10 * time.Second),
+		lookup:      net.DefaultResolver.LookupNetIP,
+	}
+}
+
+type webhookPayload struct {
+	Event      TaskEventType `json:"event"`
+	OccurredAt time.Time     `json:"occurred_at"`
+	Task       *models.Task  `json:"task"`
+}
+
+type webhookJobPayload struct {
+	DeliveryID int64 `json:"delivery_id"`
+}
+
+func (s *WebhookService) CreateSubscription(ctx context.Context, userID int64, rawURL string, eventTypes []string) (*models.WebhookSubscription, error) {
+	parsed, err := url.Parse(rawURL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return nil, ErrInvalidWebhookURL
+	}
+	err = egress.CheckHost(ctx, s.lookup, parsed.Hostname())
+	if errors.Is(err, egress.ErrForbiddenAddress) {
+		return nil, ErrForbiddenWebhookURL
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidWebhookURL, err)
+	}
+
+	if len(eventTypes) == 0 {
+		return nil, ErrInvalidWebhookEvent
+	}
+	for _, eventType := range eventTypes {
+		switch TaskEventType(eventType) {
+		case TaskCreated, TaskUpdated, TaskDeleted:
+		default:
+			return nil, ErrInvalidWebhookEvent
+		}
+	}
+
+	secret, err := generateWebhookSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	sub := &models.WebhookSubscription{
+		UserID:     userID,
+		URL:        rawURL,
+		Secret:     secret,
+		EventTypes: eventTypes,
+		Active:     true,
+	}
+	if err := s.webhookRepo.CreateSubscription(ctx, sub); err != nil {
+		return nil, err
+	}
+
+	return sub, nil
+}
+
+func (s *WebhookService) ListSubscriptions(ctx context.Context, userID int64) ([]models.WebhookSubscription, error) {
+	subs, err := s.webhookRepo.ListSubscriptionsByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// The secret is only shown once, when the subscription is created
+	for i := range subs {
+		subs[i].Secret = ""
+	}
+	return subs, nil
+}
+
+func (s *WebhookService) DeleteSubscription(ctx context.Context, userID, id int64) error {
+	if _, err := s.ownedSubscription(ctx, userID, id); err != nil {
+		return err
+	}
+	return s.webhookRepo.DeleteSubscription(ctx, id)
+}
+
+func (s *WebhookService) ListDeliveries(ctx context.Context, userID, subscriptionID int64, limit int) ([]models.WebhookDelivery, error) {
+	if _, err := s.ownedSubscription(ctx, userID, subscriptionID); err != nil {
+		return nil, err
+	}
+	if limit <= 0 || limit > 100 {
+		limit = 50
+	}
+	return s.webhookRepo.ListDeliveries(ctx, subscriptionID, limit)
+}
+
+func (s *WebhookService) ownedSubscription(ctx context.Context, userID, id int64) (*models.WebhookSubscription, error) {
+	sub, err := s.webhookRepo.GetSubscription(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrWebhookNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if sub.UserID != userID {
+		return nil, ErrWebhookNotFound
+	}
+	return sub, nil
+}
+
+// OnTaskEvent records a delivery for every subscription owned by the task's
+// assigner or assignee that listens to the event type.
+func (s *WebhookService) OnTaskEvent(ctx context.Context, event TaskEvent) {
+	if err := s.dispatch(ctx, event); err != nil {
+		logging.FromContext(ctx).Error("dispatch webhooks failed", "event", event.Type, "task_id", event.Task.ID, "error", err)
+	}
+}
+
+func (s *WebhookService) dispatch(ctx context.Context, event TaskEvent) error {
+	userIDs := taskParticipants(event.Task)
+	if len(userIDs) == 0 {
+		return nil
+	}
+
+	subs, err := s.webhookRepo.FindSubscriptionsForEvent(ctx, userIDs, string(event.Type))
+	if err != nil {
+		return err
+	}
+	if len(subs) == 0 {
+		return nil
+	}
+
+	body, err := json.Marshal(webhookPayload{
+		Event:      event.Type,
+		OccurredAt: time.Now().UTC(),
+		Task:       event.Task,
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, sub := range subs {
+		delivery := &models.WebhookDelivery{
+			SubscriptionID: sub.ID,
+			EventType:      string(event.Type),
+			Payload:        string(body),
+			Status:         models.DeliveryPending,
+		}
+		if err := s.webhookRepo.CreateDelivery(ctx, delivery); err != nil {
+			return err
+		}
+
+		jobPayload, err := json.Marshal(webhookJobPayload{DeliveryID: delivery.ID})
+		if err != nil {
+			return err
+		}
+		_, err = s.jobRepo.Enqueue(ctx, &models.Job{
+			Type:        JobTypeWebhookDelivery,
+			Payload:     string(jobPayload),
+			MaxAttempts: webhookMaxAttempts,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// HandleDelivery is the jobs.Handler for JobTypeWebhookDelivery. A non-2xx
+// response or transport error fails the job so the pool retries it; once the
+// job is out of attempts the delivery is marked dead.
+func (s *WebhookService) HandleDelivery(ctx context.Context, job *models.Job) error {
+	var payload webhookJobPayload
+	if err := json.Unmarshal([]byte(job.Payload), &payload); err != nil {
+		return err
+	}
+
+	delivery, err := s.webhookRepo.GetDelivery(ctx, payload.DeliveryID)
+	if errors.Is(err, sql.ErrNoRows) {
+		// The subscription was deleted along with its deliveries
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	sub, err := s.webhookRepo.GetSubscription(ctx, delivery.SubscriptionID)
+	if err != nil {
+		return err
+	}
+
+	delivery.Attempts = job.Attempts
+	code, sendErr := s.send(ctx, sub, delivery)
+	if code != 0 {
+		delivery.ResponseCode = &code
+	}
+
+	switch {
+	case sendErr == nil:
+		delivery.Status = models.DeliverySucceeded
+		delivery.LastError = nil
+	case job.Attempts >= job.MaxAttempts:
+		delivery.Status = models.DeliveryDead
+	default:
+		delivery.Status = models.DeliveryRetrying
+	}
+	if sendErr != nil {
+		msg := sendErr.Error()
+		delivery.LastError = &msg
+	}
+
+	if err := s.webhookRepo.UpdateDelivery(ctx, delivery); err != nil {
+		return err
+	}
+	return sendErr
+}
+
+func (s *WebhookService) send(ctx context.Context, sub *models.WebhookSubscription, delivery *models.WebhookDelivery) (int, error) {
+	body := []byte(delivery.Payload)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, sub.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "quick-task-manager-webhooks/1.0")
+	req.Header.Set("X-Webhook-Event", delivery.EventType)
+	req.Header.Set("X-Webhook-Delivery", strconv.FormatInt(delivery.ID, 10))
+	req.Header.Set("X-Webhook-Signature", signature.Sign(sub.Secret, body))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("receiver responded with %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
+
+func generateWebhookSecret() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
@@ -0,0 +1,226 @@
+package services
+
+import (
+	"backend/models"
+	"backend/pkg/egress"
+	"backend/pkg/signature"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+
+	mock_repo "backend/repositories/mocks"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWebhookService_HandleDelivery(t *testing.T) {
+	const secret = "s3cret"
+	payload := `{"event":"task.created","task":{"id":1}}`
+
+	tests := []struct {
+		name           string
+		receiverStatus int
+		attempts       int
+		expectedStatus models.WebhookDeliveryStatus
+		expectError    bool
+	}{
+		{
+			name:           "Successful delivery",
+			receiverStatus: http.StatusOK,
+			attempts:       1,
+			expectedStatus: models.DeliverySucceeded,
+		},
+		{
+			name:           "Receiver error is retried",
+			receiverStatus: http.StatusInternalServerError,
+			attempts:       1,
+			expectedStatus: models.DeliveryRetrying,
+			expectError:    true,
+		},
+		{
+			name:           "Last failed attempt is dead-lettered",
+			receiverStatus: http.StatusBadGateway,
+			attempts:       webhookMaxAttempts,
+			expectedStatus: models.DeliveryDead,
+			expectError:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, _ := io.ReadAll(r.Body)
+				assert.JSONEq(t, payload, string(body))
+				assert.True(t, signature.Verify(secret, body, r.Header.Get("X-Webhook-Signature")))
+				assert.Equal(t, "task.created", r.Header.Get("X-Webhook-Event"))
+				assert.Equal(t, "10", r.Header.Get("X-Webhook-Delivery"))
+				w.WriteHeader(tt.receiverStatus)
+			}))
+			defer receiver.Close()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			webhookRepo := mock_repo.NewMockWebhookRepositoryInterface(ctrl)
+			webhookRepo.EXPECT().GetDelivery(gomock.Any(), int64(10)).Return(&models.WebhookDelivery{
+				ID: 10, SubscriptionID: 3, EventType: "task.created", Payload: payload, Status: models.DeliveryPending,
+			}, nil)
+			webhookRepo.EXPECT().GetSubscription(gomock.Any(), int64(3)).Return(&models.WebhookSubscription{
+				ID: 3, UserID: 1, URL: receiver.URL, Secret: secret, Active: true,
+			}, nil)
+			webhookRepo.EXPECT().
+				UpdateDelivery(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, delivery *models.WebhookDelivery) error {
+					assert.Equal(t, tt.expectedStatus, delivery.Status)
+					assert.Equal(t, tt.attempts, delivery.Attempts)
+					assert.Equal(t, tt.receiverStatus, *delivery.ResponseCode)
+					return nil
+				})
+
+			service := NewWebhookService(webhookRepo, nil)
+			// The receiver listens on loopback, which the default client refuses
+			service.client = receiver.Client()
+			job := &models.Job{
+				Type:        JobTypeWebhookDelivery,
+				Payload:     `{"delivery_id":10}`,
+				Attempts:    tt.attempts,
+				MaxAttempts: webhookMaxAttempts,
+			}
+
+			err := service.HandleDelivery(context.Background(), job)
+
+			if tt.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestWebhookService_OnTaskEvent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	assignerID, assigneeID := int64(1), int64(2)
+	task := &models.Task{ID: 5, Title: "Write docs", AssignerID: &assignerID, AssigneeID: &assigneeID}
+
+	webhookRepo := mock_repo.NewMockWebhookRepositoryInterface(ctrl)
+	jobRepo := mock_repo.NewMockJobRepositoryInterface(ctrl)
+
+	webhookRepo.EXPECT().
+		FindSubscriptionsForEvent(gomock.Any(), []int64{1, 2}, "task.updated").
+		Return([]models.WebhookSubscription{{ID: 3, UserID: 1}, {ID: 4, UserID: 2}}, nil)
+	webhookRepo.EXPECT().
+		CreateDelivery(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, delivery *models.WebhookDelivery) error {
+			var body webhookPayload
+			assert.NoError(t, json.Unmarshal([]byte(delivery.Payload), &body))
+			assert.Equal(t, TaskUpdated, body.Event)
+			assert.Equal(t, int64(5), body.Task.ID)
+			delivery.ID = delivery.SubscriptionID * 10
+			return nil
+		}).
+		Times(2)
+	jobRepo.EXPECT().
+		Enqueue(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, job *models.Job) (*models.Job, error) {
+			assert.Equal(t, JobTypeWebhookDelivery, job.Type)
+			assert.Equal(t, webhookMaxAttempts, job.MaxAttempts)
+			return job, nil
+		}).
+		Times(2)
+
+	service := NewWebhookService(webhookRepo, jobRepo)
+	service.OnTaskEvent(context.Background(), TaskEvent{Type: TaskUpdated, Task: task})
+}
+
+func TestWebhookService_HandleDelivery_PrivateAddress(t *testing.T) {
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("delivery reached a loopback address")
+	}))
+	defer receiver.Close()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// The host passed the check on subscribe, then started resolving to loopback
+	webhookRepo := mock_repo.NewMockWebhookRepositoryInterface(ctrl)
+	webhookRepo.EXPECT().GetDelivery(gomock.Any(), int64(10)).Return(&models.WebhookDelivery{
+		ID: 10, SubscriptionID: 3, EventType: "task.created", Payload: "{}", Status: models.DeliveryPending,
+	}, nil)
+	webhookRepo.EXPECT().GetSubscription(gomock.Any(), int64(3)).Return(&models.WebhookSubscription{
+		ID: 3, UserID: 1, URL: receiver.URL, Secret: "s3cret", Active: true,
+	}, nil)
+	webhookRepo.EXPECT().
+		UpdateDelivery(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, delivery *models.WebhookDelivery) error {
+			assert.Equal(t, models.DeliveryRetrying, delivery.Status)
+			assert.Contains(t, *delivery.LastError, egress.ErrForbiddenAddress.Error())
+			return nil
+		})
+
+	service := NewWebhookService(webhookRepo, nil)
+	job := &models.Job{Type: JobTypeWebhookDelivery, Payload: `{"delivery_id":10}`, Attempts: 1, MaxAttempts: webhookMaxAttempts}
+
+	err := service.HandleDelivery(context.Background(), job)
+	assert.ErrorIs(t, err, egress.ErrForbiddenAddress)
+}
+
+func TestWebhookService_CreateSubscription(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	webhookRepo := mock_repo.NewMockWebhookRepositoryInterface(ctrl)
+	webhookRepo.EXPECT().CreateSubscription(gomock.Any(), gomock.Any()).Return(nil)
+
+	service := NewWebhookService(webhookRepo, nil)
+	service.lookup = func(ctx context.Context, network, host string) ([]netip.Addr, error) {
+		switch host {
+		case "example.com":
+			return []netip.Addr{netip.MustParseAddr("93.184.216.34")}, nil
+		case "localhost":
+			return []netip.Addr{netip.MustParseAddr("127.0.0.1"), netip.MustParseAddr("::1")}, nil
+		case "intranet.example.com":
+			return []netip.Addr{netip.MustParseAddr("192.168.10.4")}, nil
+		}
+		return nil, errors.New("no such host")
+	}
+	ctx := context.Background()
+
+	sub, err := service.CreateSubscription(ctx, 1, "https://example.com/hook", []string{"task.created"})
+	assert.NoError(t, err)
+	assert.Len(t, sub.Secret, 64)
+
+	_, err = service.CreateSubscription(ctx, 1, "ftp://example.com", []string{"task.created"})
+	assert.ErrorIs(t, err, ErrInvalidWebhookURL)
+
+	_, err = service.CreateSubscription(ctx, 1, "https://missing.example.com/hook", []string{"task.created"})
+	assert.ErrorIs(t, err, ErrInvalidWebhookURL)
+
+	_, err = service.CreateSubscription(ctx, 1, "https://example.com/hook", []string{"task.archived"})
+	assert.ErrorIs(t, err, ErrInvalidWebhookEvent)
+
+	for _, rawURL := range []string{
+		"http://127.0.0.1:8080/hook",
+		"http://localhost/hook",
+		"http://[::1]/hook",
+		"http://10.0.0.1/hook",
+		"http://172.16.5.5/hook",
+		"http://intranet.example.com/hook",
+		"http://169.254.169.254/latest/meta-data/",
+		"http://[fe80::1]/hook",
+		"http://0.0.0.0/hook",
+	} {
+		t.Run(rawURL, func(t *testing.T) {
+			_, err := service.CreateSubscription(ctx, 1, rawURL, []string{"task.created"})
+			assert.ErrorIs(t, err, ErrForbiddenWebhookURL)
+		})
+	}
+}